@@ -0,0 +1,183 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Values of auth_type.
+const (
+	authTypeIAM    = "iam"
+	authTypeNoAuth = "noauth"
+)
+
+// config holds the settings shared by every command. Each setting comes from the first of its flag, its
+// SECADVISOR_* environment variable and the selected profile of the config file that is set.
+type config struct {
+	AccountID string `yaml:"account_id"`
+
+	// The IAM API key. Without one, and unless auth_type is noauth, the services are configured from the
+	// FINDINGS_API_* and NOTIFICATIONS_API_* environment variables or credentials file of the SDK core.
+	APIKey string `yaml:"apikey"`
+
+	// iam (the default) or noauth.
+	AuthType string `yaml:"auth_type"`
+
+	// The IAM token URL, for non-production environments.
+	IAMURL string `yaml:"iam_url"`
+
+	FindingsURL string `yaml:"findings_url"`
+
+	NotificationsURL string `yaml:"notifications_url"`
+}
+
+// configFile is the format of the config file: named profiles of settings.
+type configFile struct {
+	Profiles map[string]config `yaml:"profiles"`
+}
+
+// configFlags are the global flags, with their environment variables.
+type configFlags struct {
+	path     *string
+	profile  *string
+	settings []configSetting
+}
+
+type configSetting struct {
+	value   *string
+	env     string
+	profile func(*config) *string
+}
+
+func registerConfigFlags(flags *flag.FlagSet) *configFlags {
+	configFlags := &configFlags{
+		path:    flags.String("config", "", "config file (env SECADVISOR_CONFIG, default ~/.secadvisor/config.yaml)"),
+		profile: flags.String("profile", "", "profile of the config file (env SECADVISOR_PROFILE, default \"default\")"),
+	}
+	setting := func(name string, env string, profile func(*config) *string, description string) {
+		configFlags.settings = append(configFlags.settings, configSetting{
+			value:   flags.String(name, "", description+" (env "+env+")"),
+			env:     env,
+			profile: profile,
+		})
+	}
+	setting("account-id", "SECADVISOR_ACCOUNT_ID", func(c *config) *string { return &c.AccountID }, "account ID")
+	setting("apikey", "SECADVISOR_APIKEY", func(c *config) *string { return &c.APIKey }, "IAM API key")
+	setting("auth-type", "SECADVISOR_AUTH_TYPE", func(c *config) *string { return &c.AuthType }, "iam or noauth")
+	setting("iam-url", "SECADVISOR_IAM_URL", func(c *config) *string { return &c.IAMURL }, "IAM token URL")
+	setting("findings-url", "SECADVISOR_FINDINGS_URL", func(c *config) *string { return &c.FindingsURL }, "Findings API URL")
+	setting("notifications-url", "SECADVISOR_NOTIFICATIONS_URL", func(c *config) *string { return &c.NotificationsURL }, "Notifications API URL")
+	return configFlags
+}
+
+// loadConfig merges the flags, the environment and the profile. A missing config file is an error only when its
+// path is set explicitly.
+func loadConfig(flags *configFlags, getenv func(string) string) (*config, error) {
+	path, explicit := firstOf(*flags.path, getenv("SECADVISOR_CONFIG")), true
+	if path == "" {
+		explicit = false
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".secadvisor", "config.yaml")
+		}
+	}
+	profileName := firstOf(*flags.profile, getenv("SECADVISOR_PROFILE"), "default")
+
+	var profile config
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			var file configFile
+			if err = yaml.UnmarshalStrict(data, &file); err != nil {
+				return nil, fmt.Errorf("invalid config file %s: %s", path, err.Error())
+			}
+			selected, ok := file.Profiles[profileName]
+			if !ok && (explicit || *flags.profile != "" || getenv("SECADVISOR_PROFILE") != "") {
+				return nil, fmt.Errorf("profile %q not found in %s", profileName, path)
+			}
+			profile = selected
+		case !os.IsNotExist(err) || explicit:
+			return nil, fmt.Errorf("failed to read config file: %s", err.Error())
+		}
+	}
+
+	result := &config{}
+	for _, setting := range flags.settings {
+		*setting.profile(result) = firstOf(*setting.value, getenv(setting.env), *setting.profile(&profile))
+	}
+	switch result.AuthType {
+	case "", authTypeIAM, authTypeNoAuth:
+	default:
+		return nil, fmt.Errorf("unsupported auth type %q, expected %s or %s", result.AuthType, authTypeIAM, authTypeNoAuth)
+	}
+	return result, nil
+}
+
+// accountID returns the account ID, which every operation requires.
+func (config *config) accountID() (string, error) {
+	if config.AccountID == "" {
+		return "", fmt.Errorf("an account ID is required: set --account-id, SECADVISOR_ACCOUNT_ID or account_id in the profile")
+	}
+	return config.AccountID, nil
+}
+
+// authenticator returns the authenticator of the config, or nil to configure it from the SDK core's environment.
+func (config *config) authenticator() core.Authenticator {
+	switch {
+	case config.AuthType == authTypeNoAuth:
+		return &core.NoAuthAuthenticator{}
+	case config.APIKey != "":
+		return &core.IamAuthenticator{ApiKey: config.APIKey, URL: config.IAMURL}
+	default:
+		return nil
+	}
+}
+
+func (config *config) findingsService() (*findingsapiv1.FindingsApiV1, error) {
+	options := &findingsapiv1.FindingsApiV1Options{URL: config.FindingsURL, Authenticator: config.authenticator()}
+	if options.Authenticator == nil {
+		return findingsapiv1.NewFindingsApiV1UsingExternalConfig(options)
+	}
+	return findingsapiv1.NewFindingsApiV1(options)
+}
+
+func (config *config) notificationsService() (*notificationsapiv1.NotificationsApiV1, error) {
+	options := &notificationsapiv1.NotificationsApiV1Options{URL: config.NotificationsURL, Authenticator: config.authenticator()}
+	if options.Authenticator == nil {
+		return notificationsapiv1.NewNotificationsApiV1UsingExternalConfig(options)
+	}
+	return notificationsapiv1.NewNotificationsApiV1(options)
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`config`, func() {
+	load := func(args []string, env map[string]string) (*config, error) {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(ioutil.Discard)
+		configFlags := registerConfigFlags(flags)
+		Expect(flags.Parse(args)).To(Succeed())
+		return loadConfig(configFlags, func(name string) string { return env[name] })
+	}
+
+	It(`takes flags over env vars over the profile`, func() {
+		loaded, err := load([]string{"--config", "testdata/config.yaml", "--profile", "staging", "--apikey", "flag-key"},
+			map[string]string{"SECADVISOR_APIKEY": "env-key", "SECADVISOR_ACCOUNT_ID": "env-account"})
+		Expect(err).To(BeNil())
+		Expect(loaded).To(Equal(&config{
+			AccountID:   "env-account",
+			APIKey:      "flag-key",
+			IAMURL:      "https://iam.test.cloud.ibm.com",
+			FindingsURL: "https://staging.example.com/findings",
+		}))
+		Expect(loaded.authenticator()).To(Equal(&core.IamAuthenticator{ApiKey: "flag-key", URL: "https://iam.test.cloud.ibm.com"}))
+	})
+	It(`selects the profile from the environment`, func() {
+		config, err := load(nil, map[string]string{"SECADVISOR_CONFIG": "testdata/config.yaml"})
+		Expect(err).To(BeNil())
+		Expect(config.AccountID).To(Equal("profile-account"))
+		Expect(config.authenticator()).To(Equal(&core.NoAuthAuthenticator{}))
+
+		config, err = load(nil, map[string]string{"SECADVISOR_CONFIG": "testdata/config.yaml", "SECADVISOR_PROFILE": "staging"})
+		Expect(err).To(BeNil())
+		Expect(config.AccountID).To(Equal("staging-account"))
+	})
+	It(`falls back to the SDK core configuration without API key`, func() {
+		config, err := load([]string{"--config", "testdata/config.yaml", "--profile", "default", "--auth-type", "iam"}, nil)
+		Expect(err).To(BeNil())
+		Expect(config.authenticator()).To(BeNil())
+	})
+	It(`rejects invalid configurations`, func() {
+		_, err := load([]string{"--config", "testdata/missing.yaml"}, nil)
+		Expect(err).NotTo(BeNil())
+		_, err = load([]string{"--config", "testdata/config.yaml", "--profile", "missing"}, nil)
+		Expect(err).To(MatchError(`profile "missing" not found in testdata/config.yaml`))
+		_, err = load([]string{"--config", "testdata/config.yaml", "--auth-type", "basic"}, nil)
+		Expect(err).NotTo(BeNil())
+
+		config, err := load([]string{"--config", "testdata/config.yaml", "--account-id", ""}, map[string]string{"SECADVISOR_PROFILE": "staging"})
+		Expect(err).To(BeNil())
+		config.AccountID = ""
+		_, err = config.accountID()
+		Expect(err).NotTo(BeNil())
+	})
+})
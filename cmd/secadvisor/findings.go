@@ -0,0 +1,437 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// findings returns the Findings API client and the account ID.
+func (cli *cli) findings() (*findingsapiv1.FindingsApiV1, string, error) {
+	accountID, err := cli.config.accountID()
+	if err != nil {
+		return nil, "", err
+	}
+	service, err := cli.config.findingsService()
+	return service, accountID, err
+}
+
+func notesCommand(cli *cli, args []string) error {
+	return dispatch(cli, args, map[string]command{
+		"list":   listNotes,
+		"get":    getNote,
+		"create": createNote,
+		"update": updateNote,
+		"delete": deleteNote,
+	})
+}
+
+func listNotes(cli *cli, args []string) error {
+	flags := cli.flagSet("notes list")
+	providerID := flags.String("provider", "", "provider ID")
+	pageSize := flags.Int64("page-size", 0, "number of notes per page")
+	pageToken := flags.String("page-token", "", "token of the page to return")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options := service.NewListNotesOptions(accountID, *providerID)
+	if *pageSize > 0 {
+		options.SetPageSize(*pageSize)
+	}
+	if *pageToken != "" {
+		options.SetPageToken(*pageToken)
+	}
+	result, _, err := service.ListNotes(options)
+	if err != nil {
+		return err
+	}
+	return cli.printList(result, result.Notes)
+}
+
+func getNote(cli *cli, args []string) error {
+	flags := cli.flagSet("notes get")
+	providerID := flags.String("provider", "", "provider ID")
+	noteID := flags.String("note", "", "note ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID, "note": *noteID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.GetNote(service.NewGetNoteOptions(accountID, *providerID, *noteID))
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func createNote(cli *cli, args []string) error {
+	flags := cli.flagSet("notes create")
+	providerID := flags.String("provider", "", "provider ID, overriding provider_id of the body")
+	file := flags.String("file", "", "JSON note, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	options := &findingsapiv1.CreateNoteOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	if *providerID != "" {
+		options.SetProviderID(*providerID)
+	}
+	result, _, err := service.CreateNote(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func updateNote(cli *cli, args []string) error {
+	flags := cli.flagSet("notes update")
+	providerID := flags.String("provider", "", "provider ID, overriding provider_id of the body")
+	noteID := flags.String("note", "", "ID of the note to update, overriding id of the body")
+	file := flags.String("file", "", "JSON note, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	options := &findingsapiv1.UpdateNoteOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	if *providerID != "" {
+		options.SetProviderID(*providerID)
+	}
+	if *noteID != "" {
+		options.SetID(*noteID)
+	}
+	options.NoteID = options.ID
+	result, _, err := service.UpdateNote(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func deleteNote(cli *cli, args []string) error {
+	flags := cli.silentFlagSet("notes delete")
+	providerID := flags.String("provider", "", "provider ID")
+	noteID := flags.String("note", "", "note ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID, "note": *noteID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	_, err = service.DeleteNote(service.NewDeleteNoteOptions(accountID, *providerID, *noteID))
+	return err
+}
+
+func occurrencesCommand(cli *cli, args []string) error {
+	return dispatch(cli, args, map[string]command{
+		"list":   listOccurrences,
+		"get":    getOccurrence,
+		"note":   getOccurrenceNote,
+		"create": createOccurrence,
+		"update": updateOccurrence,
+		"delete": deleteOccurrence,
+	})
+}
+
+func listOccurrences(cli *cli, args []string) error {
+	flags := cli.flagSet("occurrences list")
+	providerID := flags.String("provider", "", "provider ID")
+	noteID := flags.String("note", "", "only list the occurrences of this note")
+	pageSize := flags.Int64("page-size", 0, "number of occurrences per page")
+	pageToken := flags.String("page-token", "", "token of the page to return")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+
+	var result, items interface{}
+	if *noteID != "" {
+		options := service.NewListNoteOccurrencesOptions(accountID, *providerID, *noteID)
+		if *pageSize > 0 {
+			options.SetPageSize(*pageSize)
+		}
+		if *pageToken != "" {
+			options.SetPageToken(*pageToken)
+		}
+		var list *findingsapiv1.ApiListNoteOccurrencesResponse
+		if list, _, err = service.ListNoteOccurrences(options); err == nil {
+			result, items = list, list.Occurrences
+		}
+	} else {
+		options := service.NewListOccurrencesOptions(accountID, *providerID)
+		if *pageSize > 0 {
+			options.SetPageSize(*pageSize)
+		}
+		if *pageToken != "" {
+			options.SetPageToken(*pageToken)
+		}
+		var list *findingsapiv1.ApiListOccurrencesResponse
+		if list, _, err = service.ListOccurrences(options); err == nil {
+			result, items = list, list.Occurrences
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return cli.printList(result, items)
+}
+
+func getOccurrence(cli *cli, args []string) error {
+	flags := cli.flagSet("occurrences get")
+	providerID := flags.String("provider", "", "provider ID")
+	occurrenceID := flags.String("occurrence", "", "occurrence ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID, "occurrence": *occurrenceID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.GetOccurrence(service.NewGetOccurrenceOptions(accountID, *providerID, *occurrenceID))
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func getOccurrenceNote(cli *cli, args []string) error {
+	flags := cli.flagSet("occurrences note")
+	providerID := flags.String("provider", "", "provider ID")
+	occurrenceID := flags.String("occurrence", "", "occurrence ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID, "occurrence": *occurrenceID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.GetOccurrenceNote(service.NewGetOccurrenceNoteOptions(accountID, *providerID, *occurrenceID))
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func createOccurrence(cli *cli, args []string) error {
+	flags := cli.flagSet("occurrences create")
+	providerID := flags.String("provider", "", "provider ID, overriding provider_id of the body")
+	noteName := flags.String("note-name", "", "note name, overriding note_name of the body")
+	replaceIfExists := flags.Bool("replace-if-exists", false, "replace the occurrence if it already exists")
+	file := flags.String("file", "", "JSON occurrence, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	options := &findingsapiv1.CreateOccurrenceOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	if *providerID != "" {
+		options.SetProviderID(*providerID)
+	}
+	if *noteName != "" {
+		options.SetNoteName(*noteName)
+	}
+	if *replaceIfExists {
+		options.SetReplaceIfExists(true)
+	}
+	result, _, err := service.CreateOccurrence(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func updateOccurrence(cli *cli, args []string) error {
+	flags := cli.flagSet("occurrences update")
+	providerID := flags.String("provider", "", "provider ID, overriding provider_id of the body")
+	occurrenceID := flags.String("occurrence", "", "ID of the occurrence to update, overriding id of the body")
+	noteName := flags.String("note-name", "", "note name, overriding note_name of the body")
+	file := flags.String("file", "", "JSON occurrence, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	options := &findingsapiv1.UpdateOccurrenceOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	if *providerID != "" {
+		options.SetProviderID(*providerID)
+	}
+	if *noteName != "" {
+		options.SetNoteName(*noteName)
+	}
+	if *occurrenceID != "" {
+		options.SetID(*occurrenceID)
+	}
+	options.OccurrenceID = options.ID
+	result, _, err := service.UpdateOccurrence(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func deleteOccurrence(cli *cli, args []string) error {
+	flags := cli.silentFlagSet("occurrences delete")
+	providerID := flags.String("provider", "", "provider ID")
+	occurrenceID := flags.String("occurrence", "", "occurrence ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"provider": *providerID, "occurrence": *occurrenceID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	_, err = service.DeleteOccurrence(service.NewDeleteOccurrenceOptions(accountID, *providerID, *occurrenceID))
+	return err
+}
+
+func providersCommand(cli *cli, args []string) error {
+	return dispatch(cli, args, map[string]command{
+		"list": listProviders,
+	})
+}
+
+func listProviders(cli *cli, args []string) error {
+	flags := cli.flagSet("providers list")
+	limit := flags.Int64("limit", 0, "number of providers to return")
+	skip := flags.Int64("skip", 0, "number of providers to skip")
+	start := flags.String("start", "", "first provider ID to return")
+	end := flags.String("end", "", "last provider ID to return")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options := service.NewListProvidersOptions(accountID)
+	if *limit > 0 {
+		options.SetLimit(*limit)
+	}
+	if *skip > 0 {
+		options.SetSkip(*skip)
+	}
+	if *start != "" {
+		options.SetStartProviderID(*start)
+	}
+	if *end != "" {
+		options.SetEndProviderID(*end)
+	}
+	result, _, err := service.ListProviders(options)
+	if err != nil {
+		return err
+	}
+	return cli.printList(result, result.Providers)
+}
+
+// graphCommand runs the query given as argument, or read from --file.
+func graphCommand(cli *cli, args []string) error {
+	flags := cli.flagSet("graph")
+	file := flags.String("file", "", "file of the query, - for stdin")
+	contentType := flags.String("content-type", "application/graphql", "content type of the query")
+	if err := cli.parseFlags(flags, args); err != nil {
+		return err
+	}
+	var query []byte
+	switch {
+	case *file != "" && flags.NArg() == 0:
+		data, err := cli.readInput(*file)
+		if err != nil {
+			return err
+		}
+		query = data
+	case *file == "" && flags.NArg() == 1:
+		query = []byte(flags.Arg(0))
+	default:
+		return usagef("expected either a query or --file")
+	}
+	if strings.TrimSpace(string(query)) == "" {
+		return usagef("the query is empty")
+	}
+
+	service, accountID, err := cli.findings()
+	if err != nil {
+		return err
+	}
+	options := service.NewPostGraphOptions(accountID)
+	options.SetBody(ioutil.NopCloser(bytes.NewReader(query)))
+	options.SetContentType(*contentType)
+	response, err := service.PostGraph(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(response.Result)
+}
@@ -0,0 +1,147 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`findings commands`, func() {
+	var api *testAPI
+	BeforeEach(func() {
+		api = newTestAPI()
+	})
+	AfterEach(func() {
+		api.server.Close()
+	})
+
+	It(`creates a note from the JSON of a file`, func() {
+		api.response = `{"id": "sec_advisor_202X_note", "kind": "FINDING"}`
+		status, stdout, stderr := api.run("", "notes", "create", "--file", "../../testInput/json/note.json")
+		Expect(stderr).To(BeEmpty())
+		Expect(status).To(Equal(0))
+		Expect(api.requests).To(HaveLen(1))
+		Expect(api.requests[0].Method).To(Equal("POST"))
+		Expect(api.requests[0].Path).To(Equal("/findings/v1/account/providers/sec_advisor_202X_provider/notes"))
+		Expect(api.requests[0].JSON()["finding"]).To(Equal(map[string]interface{}{
+			"severity":   "CRITICAL",
+			"next_steps": []interface{}{map[string]interface{}{"title": "string", "url": "string"}},
+		}))
+
+		var result map[string]interface{}
+		Expect(json.Unmarshal([]byte(stdout), &result)).To(Succeed())
+		Expect(result["id"]).To(Equal("sec_advisor_202X_note"))
+	})
+	It(`updates a note read from stdin`, func() {
+		status, _, _ := api.run(`{"provider_id": "p", "id": "n", "kind": "FINDING", "short_description": "s",
+			"long_description": "l", "reported_by": {"id": "i", "title": "t"}, "finding": {"severity": "LOW"}}`,
+			"notes", "update", "--provider", "other", "--file", "-")
+		Expect(status).To(Equal(0))
+		Expect(api.requests[0].Method).To(Equal("PUT"))
+		Expect(api.requests[0].Path).To(Equal("/findings/v1/account/providers/other/notes/n"))
+	})
+	It(`maps every note and occurrence operation`, func() {
+		for _, args := range [][]string{
+			{"notes", "list", "--provider", "p", "--page-size", "2"},
+			{"notes", "get", "--provider", "p", "--note", "n"},
+			{"notes", "delete", "--provider", "p", "--note", "n"},
+			{"occurrences", "list", "--provider", "p"},
+			{"occurrences", "list", "--provider", "p", "--note", "n"},
+			{"occurrences", "get", "--provider", "p", "--occurrence", "o"},
+			{"occurrences", "note", "--provider", "p", "--occurrence", "o"},
+			{"occurrences", "delete", "--provider", "p", "--occurrence", "o"},
+			{"providers", "list", "--limit", "5"},
+		} {
+			status, _, stderr := api.run("", args...)
+			Expect(stderr).To(BeEmpty())
+			Expect(status).To(Equal(0))
+		}
+		var calls []string
+		for _, request := range api.requests {
+			calls = append(calls, request.Method+" "+request.Path+"?"+request.Query)
+		}
+		Expect(calls).To(Equal([]string{
+			"GET /findings/v1/account/providers/p/notes?page_size=2",
+			"GET /findings/v1/account/providers/p/notes/n?",
+			"DELETE /findings/v1/account/providers/p/notes/n?",
+			"GET /findings/v1/account/providers/p/occurrences?",
+			"GET /findings/v1/account/providers/p/notes/n/occurrences?",
+			"GET /findings/v1/account/providers/p/occurrences/o?",
+			"GET /findings/v1/account/providers/p/occurrences/o/note?",
+			"DELETE /findings/v1/account/providers/p/occurrences/o?",
+			"GET /findings/v1/account/providers?limit=5",
+		}))
+	})
+	It(`doesn't accept output flags on deletions, which print nothing`, func() {
+		status, stdout, stderr := api.run("", "notes", "delete", "--provider", "p", "--note", "n", "-o", "table")
+		Expect(status).To(Equal(2))
+		Expect(stdout).To(BeEmpty())
+		Expect(stderr).To(ContainSubstring("flag provided but not defined: -o"))
+		Expect(api.requests).To(BeEmpty())
+	})
+	It(`creates and updates occurrences`, func() {
+		status, _, stderr := api.run("", "occurrences", "create", "--file", "../../testInput/json/providerOccurrence.json",
+			"--note-name", "account/providers/sec_advisor_202X_provider/notes/sec_advisor_202X_note", "--replace-if-exists")
+		Expect(stderr).To(BeEmpty())
+		Expect(status).To(Equal(0))
+		Expect(api.requests[0].Path).To(Equal("/findings/v1/account/providers/sec_advisor_202X_provider_occ_test/occurrences"))
+		Expect(api.requests[0].Header.Get("Replace-If-Exists")).To(Equal("true"))
+		Expect(api.requests[0].JSON()["note_name"]).To(Equal("account/providers/sec_advisor_202X_provider/notes/sec_advisor_202X_note"))
+
+		status, _, _ = api.run("", "occurrences", "update", "--file", "../../testInput/json/editedKpiOcc.json", "--occurrence", "o",
+			"--note-name", "account/providers/sec_advisor_202X_provider/notes/sec_advisor_202X_kpi_note")
+		Expect(status).To(Equal(0))
+		Expect(api.requests[1].Method).To(Equal("PUT"))
+		Expect(api.requests[1].Path).To(HaveSuffix("/occurrences/o"))
+	})
+	It(`renders lists in the selected format`, func() {
+		api.response = `{"occurrences": [{"id": "o1", "kind": "FINDING", "finding": {"severity": "HIGH"},
+			"context": {"resource_name": "cluster"}}], "next_page_token": "next"}`
+		status, stdout, stderr := api.run("", "occurrences", "list", "--provider", "p", "-o", "table", "--columns", "id,finding.severity,context.resource_name")
+		Expect(stderr).To(BeEmpty())
+		Expect(status).To(Equal(0))
+		Expect(stdout).To(Equal("ID  FINDING_SEVERITY  CONTEXT_RESOURCE_NAME\no1  HIGH              cluster\n"))
+
+		status, stdout, _ = api.run("", "occurrences", "list", "--provider", "p")
+		Expect(status).To(Equal(0))
+		Expect(stdout).To(ContainSubstring(`"next_page_token": "next"`))
+
+		status, stdout, _ = api.run("", "occurrences", "list", "--provider", "p", "--output", "template", "--template", "{{.id}} {{.finding.severity}}")
+		Expect(status).To(Equal(0))
+		Expect(stdout).To(Equal("o1 HIGH\n"))
+
+		status, _, stderr = api.run("", "occurrences", "list", "--provider", "p", "-o", "xml")
+		Expect(status).To(Equal(2))
+		Expect(stderr).To(ContainSubstring("unsupported output format"))
+	})
+	It(`runs graph queries`, func() {
+		api.response = `{"data": {"findingCount": 3}}`
+		status, stdout, _ := api.run("", "graph", "--file", "../../testInput/json/findingCount.graphql.txt")
+		Expect(status).To(Equal(0))
+		Expect(api.requests[0].Path).To(Equal("/findings/v1/account/graph"))
+		Expect(api.requests[0].Header.Get("Content-Type")).To(Equal("application/graphql"))
+		Expect(string(api.requests[0].Body)).To(ContainSubstring("occurrenceCount"))
+		Expect(stdout).To(ContainSubstring(`"findingCount": 3`))
+
+		status, _, _ = api.run("", "graph", `query {findingCount: occurrenceCount(kind: "FINDING")}`)
+		Expect(status).To(Equal(0))
+		status, _, _ = api.run("", "graph")
+		Expect(status).To(Equal(2))
+	})
+})
@@ -0,0 +1,271 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command secadvisor calls the Findings and Notifications APIs of Security Advisor from a shell.
+//
+// Usage:
+//
+//	secadvisor [global flags] <command> [<action>] [flags]
+//
+// Every operation of both APIs has a command. Request bodies are read from a file, or from stdin with "--file -",
+// in the same JSON shape as the files under testInput/json, and results are printed as JSON. Run
+// "secadvisor help" for the list of commands and "secadvisor <command> <action> -h" for their flags.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/output"
+)
+
+const usage = `Usage: secadvisor [global flags] <command> [<action>] [flags]
+
+Commands:
+  notes        list|get|create|update|delete
+  occurrences  list|get|note|create|update|delete
+  providers    list
+  graph        run a GraphQL query
+  channels     list|get|create|update|delete
+  public-key   get the public key signing notifications
+  test-channel send a test notification to a channel
+
+Every action printing a result accepts the output flags -o table|json|ndjson|yaml|csv|template (default json),
+--columns, --template and --no-headers. Lists are rendered item by item, e.g.
+-o table --columns id,finding.severity,context.resource_name.
+
+Global flags:
+`
+
+// usageError reports invalid command-line arguments. It makes the command exit with status 2.
+type usageError struct {
+	message string
+}
+
+func (err *usageError) Error() string {
+	return err.message
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// command runs a command with the arguments following its name.
+type command func(cli *cli, args []string) error
+
+var commands = map[string]command{
+	"notes":        notesCommand,
+	"occurrences":  occurrencesCommand,
+	"providers":    providersCommand,
+	"graph":        graphCommand,
+	"channels":     channelsCommand,
+	"public-key":   publicKeyCommand,
+	"test-channel": testChannelCommand,
+}
+
+// cli carries the configuration, the streams and the output options of a run.
+type cli struct {
+	config  *config
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+	output  output.Options
+	columns string
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit status.
+func run(args []string, getenv func(string) string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	global := flag.NewFlagSet("secadvisor", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		global.PrintDefaults()
+	}
+	flags := registerConfigFlags(global)
+	if err := global.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	args = global.Args()
+	if len(args) == 0 || args[0] == "help" {
+		global.Usage()
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "secadvisor: unknown command %q\n", args[0])
+		global.Usage()
+		return 2
+	}
+
+	config, err := loadConfig(flags, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "secadvisor: %s\n", err.Error())
+		return 1
+	}
+	err = cmd(&cli{config: config, stdin: stdin, stdout: stdout, stderr: stderr}, args[1:])
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case err == flag.ErrHelp:
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "secadvisor %s: %s\n", args[0], err.Error())
+		return 2
+	default:
+		fmt.Fprintf(stderr, "secadvisor %s: %s\n", args[0], err.Error())
+		return 1
+	}
+}
+
+// dispatch runs the action named by the first argument.
+func dispatch(cli *cli, args []string, actions map[string]command) error {
+	names := make([]string, 0, len(actions))
+	for action := range actions {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+	if len(args) == 0 {
+		return usagef("an action is required: %s", strings.Join(names, "|"))
+	}
+	action, ok := actions[args[0]]
+	if !ok {
+		return usagef("unknown action %q, expected %s", args[0], strings.Join(names, "|"))
+	}
+	return action(cli, args[1:])
+}
+
+// flagSet returns a flag set for the command with the output flags, printing its errors to stderr.
+func (cli *cli) flagSet(name string) *flag.FlagSet {
+	flags := cli.silentFlagSet(name)
+	formats := "output format: " + strings.Join(output.Formats, ", ")
+	flags.StringVar(&cli.output.Format, "o", output.Format_JSON, formats)
+	flags.StringVar(&cli.output.Format, "output", output.Format_JSON, formats)
+	flags.StringVar(&cli.columns, "columns", "", "comma-separated columns, as dotted paths, e.g. id,finding.severity")
+	flags.StringVar(&cli.output.Template, "template", "", "Go template executed for each item, for -o template")
+	flags.BoolVar(&cli.output.NoHeaders, "no-headers", false, "omit the header of tables and CSV")
+	return flags
+}
+
+// silentFlagSet returns a flag set without the output flags, for the commands that print nothing.
+func (cli *cli) silentFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet("secadvisor "+name, flag.ContinueOnError)
+	flags.SetOutput(cli.stderr)
+	return flags
+}
+
+// parseFlags parses the flags of a command and applies the output flags.
+func (cli *cli) parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return &usageError{message: err.Error()}
+	}
+	if flags.Lookup("o") == nil {
+		return nil
+	}
+	cli.output.Columns = output.ParseColumns(cli.columns)
+	for _, format := range output.Formats {
+		if cli.output.Format == format {
+			return nil
+		}
+	}
+	return usagef("unsupported output format %q, expected one of %s", cli.output.Format, strings.Join(output.Formats, ", "))
+}
+
+// parse parses the flags of a command, which takes no positional argument.
+func (cli *cli) parse(flags *flag.FlagSet, args []string) error {
+	if err := cli.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return usagef("unexpected argument %q", flags.Arg(0))
+	}
+	return nil
+}
+
+// required checks that every named flag has a value.
+func required(values map[string]string) error {
+	names := make([]string, 0, len(values))
+	for name, value := range values {
+		if value == "" {
+			names = append(names, "--"+name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return usagef("missing %s", strings.Join(names, ", "))
+}
+
+// readInput reads a file, or stdin when path is "-".
+func (cli *cli) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(cli.stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// readBody decodes the JSON request body of a file, or of stdin when path is "-".
+func (cli *cli) readBody(path string, body interface{}) error {
+	if path == "" {
+		return usagef("missing --file")
+	}
+	data, err := cli.readInput(path)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, body); err != nil {
+		return fmt.Errorf("failed to decode the request body: %s", err.Error())
+	}
+	return nil
+}
+
+// print writes the result, as indented JSON unless another format or columns are selected.
+func (cli *cli) print(result interface{}) error {
+	return cli.printList(result, result)
+}
+
+// printList writes the result of an operation returning items. Plain JSON prints the whole result, including
+// pagination tokens; the other formats render the items.
+func (cli *cli) printList(result interface{}, items interface{}) error {
+	if cli.output.Format == output.Format_JSON && len(cli.output.Columns) == 0 {
+		encoder := json.NewEncoder(cli.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	return output.Write(cli.stdout, items, &cli.output)
+}
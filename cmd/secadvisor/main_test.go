@@ -0,0 +1,67 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`run`, func() {
+	var api *testAPI
+	BeforeEach(func() {
+		api = newTestAPI()
+	})
+	AfterEach(func() {
+		api.server.Close()
+	})
+
+	It(`prints the usage`, func() {
+		status, _, stderr := api.run("", "help")
+		Expect(status).To(Equal(0))
+		Expect(stderr).To(ContainSubstring("Usage: secadvisor"))
+		Expect(stderr).To(ContainSubstring("-account-id"))
+
+		status, _, _ = api.run("")
+		Expect(status).To(Equal(2))
+	})
+	It(`rejects invalid command lines`, func() {
+		status, _, stderr := api.run("", "findings")
+		Expect(status).To(Equal(2))
+		Expect(stderr).To(ContainSubstring(`unknown command "findings"`))
+
+		status, _, stderr = api.run("", "notes")
+		Expect(status).To(Equal(2))
+		Expect(stderr).To(ContainSubstring("an action is required: create|delete|get|list|update"))
+
+		status, _, stderr = api.run("", "notes", "get", "--provider", "p")
+		Expect(status).To(Equal(2))
+		Expect(stderr).To(ContainSubstring("missing --note"))
+
+		status, _, _ = api.run("", "notes", "get", "--unknown")
+		Expect(status).To(Equal(2))
+		Expect(api.requests).To(BeEmpty())
+	})
+	It(`reports failed operations`, func() {
+		status, _, stderr := runWithEnv(map[string]string{"SECADVISOR_CONFIG": "testdata/missing.yaml"}, "", "public-key")
+		Expect(status).To(Equal(1))
+		Expect(stderr).To(ContainSubstring("failed to read config file"))
+
+		status, _, stderr = api.run("", "notes", "create", "--file", "testdata/missing.json")
+		Expect(status).To(Equal(1))
+		Expect(stderr).To(HavePrefix("secadvisor notes: "))
+	})
+})
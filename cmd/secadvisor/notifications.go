@@ -0,0 +1,201 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+)
+
+// notifications returns the Notifications API client and the account ID.
+func (cli *cli) notifications() (*notificationsapiv1.NotificationsApiV1, string, error) {
+	accountID, err := cli.config.accountID()
+	if err != nil {
+		return nil, "", err
+	}
+	service, err := cli.config.notificationsService()
+	return service, accountID, err
+}
+
+func channelsCommand(cli *cli, args []string) error {
+	return dispatch(cli, args, map[string]command{
+		"list":   listChannels,
+		"get":    getChannel,
+		"create": createChannel,
+		"update": updateChannel,
+		"delete": deleteChannels,
+	})
+}
+
+func listChannels(cli *cli, args []string) error {
+	flags := cli.flagSet("channels list")
+	limit := flags.Int64("limit", 0, "number of channels to return")
+	skip := flags.Int64("skip", 0, "number of channels to skip")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	options := service.NewListAllChannelsOptions(accountID)
+	if *limit > 0 {
+		options.SetLimit(*limit)
+	}
+	if *skip > 0 {
+		options.SetSkip(*skip)
+	}
+	result, _, err := service.ListAllChannels(options)
+	if err != nil {
+		return err
+	}
+	return cli.printList(result, result.Channels)
+}
+
+func getChannel(cli *cli, args []string) error {
+	flags := cli.flagSet("channels get")
+	channelID := flags.String("channel", "", "channel ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"channel": *channelID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.GetNotificationChannel(service.NewGetNotificationChannelOptions(accountID, *channelID))
+	if err != nil {
+		return err
+	}
+	return cli.printList(result, result.Channel)
+}
+
+func createChannel(cli *cli, args []string) error {
+	flags := cli.flagSet("channels create")
+	file := flags.String("file", "", "JSON channel, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	options := &notificationsapiv1.CreateNotificationChannelOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	result, _, err := service.CreateNotificationChannel(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func updateChannel(cli *cli, args []string) error {
+	flags := cli.flagSet("channels update")
+	channelID := flags.String("channel", "", "ID of the channel to update")
+	file := flags.String("file", "", "JSON channel, - for stdin")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"channel": *channelID}); err != nil {
+		return err
+	}
+	options := &notificationsapiv1.UpdateNotificationChannelOptions{}
+	if err := cli.readBody(*file, options); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	options.SetAccountID(accountID)
+	options.ChannelID = core.StringPtr(*channelID)
+	result, _, err := service.UpdateNotificationChannel(options)
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+// deleteChannels deletes one channel, or several at once with a comma-separated list of IDs.
+func deleteChannels(cli *cli, args []string) error {
+	flags := cli.flagSet("channels delete")
+	channelIDs := flags.String("channel", "", "channel ID, or comma-separated channel IDs")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"channel": *channelIDs}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	ids := strings.Split(*channelIDs, ",")
+	var result interface{}
+	if len(ids) == 1 {
+		result, _, err = service.DeleteNotificationChannel(service.NewDeleteNotificationChannelOptions(accountID, ids[0]))
+	} else {
+		result, _, err = service.DeleteNotificationChannels(service.NewDeleteNotificationChannelsOptions(accountID, ids))
+	}
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func publicKeyCommand(cli *cli, args []string) error {
+	flags := cli.flagSet("public-key")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.GetPublicKey(service.NewGetPublicKeyOptions(accountID))
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
+
+func testChannelCommand(cli *cli, args []string) error {
+	flags := cli.flagSet("test-channel")
+	channelID := flags.String("channel", "", "channel ID")
+	if err := cli.parse(flags, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"channel": *channelID}); err != nil {
+		return err
+	}
+	service, accountID, err := cli.notifications()
+	if err != nil {
+		return err
+	}
+	result, _, err := service.TestNotificationChannel(service.NewTestNotificationChannelOptions(accountID, *channelID))
+	if err != nil {
+		return err
+	}
+	return cli.print(result)
+}
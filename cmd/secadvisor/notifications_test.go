@@ -0,0 +1,75 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`notifications commands`, func() {
+	var api *testAPI
+	BeforeEach(func() {
+		api = newTestAPI()
+	})
+	AfterEach(func() {
+		api.server.Close()
+	})
+
+	It(`creates and updates channels from the JSON of a file`, func() {
+		api.response = `{"channel_id": "1", "status_code": 200}`
+		status, stdout, stderr := api.run("", "channels", "create", "--file", "../../testInput/json/channel_with_alert_source.json")
+		Expect(stderr).To(BeEmpty())
+		Expect(status).To(Equal(0))
+		Expect(api.requests[0].Method).To(Equal("POST"))
+		Expect(api.requests[0].Path).To(Equal("/notifications/v1/account/notifications/channels"))
+		Expect(api.requests[0].JSON()).To(HaveKey("alert_source"))
+		Expect(stdout).To(ContainSubstring(`"channel_id": "1"`))
+
+		status, _, _ = api.run("", "channels", "update", "--channel", "1", "--file", "../../testInput/json/channel_with_severity.json")
+		Expect(status).To(Equal(0))
+		Expect(api.requests[1].Method).To(Equal("PUT"))
+		Expect(api.requests[1].Path).To(Equal("/notifications/v1/account/notifications/channels/1"))
+		Expect(api.requests[1].JSON()["severity"]).To(Equal([]interface{}{"low", "high", "critical"}))
+	})
+	It(`maps every channel operation`, func() {
+		for _, args := range [][]string{
+			{"channels", "list", "--limit", "10"},
+			{"channels", "get", "--channel", "1"},
+			{"channels", "delete", "--channel", "1"},
+			{"channels", "delete", "--channel", "1,2"},
+			{"public-key"},
+			{"test-channel", "--channel", "1"},
+		} {
+			status, _, stderr := api.run("", args...)
+			Expect(stderr).To(BeEmpty())
+			Expect(status).To(Equal(0))
+		}
+		var calls []string
+		for _, request := range api.requests {
+			calls = append(calls, request.Method+" "+request.Path+"?"+request.Query)
+		}
+		Expect(calls).To(Equal([]string{
+			"GET /notifications/v1/account/notifications/channels?limit=10",
+			"GET /notifications/v1/account/notifications/channels/1?",
+			"DELETE /notifications/v1/account/notifications/channels/1?",
+			"DELETE /notifications/v1/account/notifications/channels?",
+			"GET /notifications/v1/account/notifications/public_key?",
+			"GET /notifications/v1/account/notifications/channels/1/test?",
+		}))
+		Expect(string(api.requests[3].Body)).To(MatchJSON(`["1", "2"]`))
+	})
+})
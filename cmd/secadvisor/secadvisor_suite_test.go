@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSecadvisor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Secadvisor Suite")
+}
+
+// recordedRequest is a request received by the test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// JSON decodes the body of the request.
+func (request recordedRequest) JSON() map[string]interface{} {
+	var body map[string]interface{}
+	Expect(json.Unmarshal(request.Body, &body)).To(Succeed())
+	return body
+}
+
+// testAPI serves response to every request and records them.
+type testAPI struct {
+	server   *httptest.Server
+	response string
+	requests []recordedRequest
+}
+
+func newTestAPI() *testAPI {
+	api := &testAPI{response: `{}`}
+	api.server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		defer GinkgoRecover()
+
+		body, err := ioutil.ReadAll(req.Body)
+		Expect(err).To(BeNil())
+		api.requests = append(api.requests, recordedRequest{Method: req.Method, Path: req.URL.Path, Query: req.URL.RawQuery, Header: req.Header, Body: body})
+		res.Header().Set("Content-type", "application/json")
+		res.WriteHeader(200)
+		res.Write([]byte(api.response))
+	}))
+	return api
+}
+
+// run runs the command line against the test server, without any config file.
+func (api *testAPI) run(stdin string, args ...string) (status int, stdout string, stderr string) {
+	env := map[string]string{
+		"SECADVISOR_CONFIG":            "testdata/config.yaml",
+		"SECADVISOR_ACCOUNT_ID":        "account",
+		"SECADVISOR_AUTH_TYPE":         "noauth",
+		"SECADVISOR_FINDINGS_URL":      api.server.URL + "/findings",
+		"SECADVISOR_NOTIFICATIONS_URL": api.server.URL + "/notifications",
+	}
+	return runWithEnv(env, stdin, args...)
+}
+
+func runWithEnv(env map[string]string, stdin string, args ...string) (status int, stdout string, stderr string) {
+	var out, errOut bytes.Buffer
+	getenv := func(name string) string { return env[name] }
+	status = run(args, getenv, strings.NewReader(stdin), &out, &errOut)
+	return status, out.String(), errOut.String()
+}
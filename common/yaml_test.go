@@ -0,0 +1,36 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	data, err := YAMLToJSON([]byte("name: channel\nseverity: [low, high]\nalert_source:\n  - provider_name: VA\n"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"name": "channel", "severity": ["low", "high"], "alert_source": [{"provider_name": "VA"}]}`, string(data))
+
+	data, err = YAMLToJSON([]byte(`[{"name": "channel", "enabled": true}]`))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"name": "channel", "enabled": true}]`, string(data))
+
+	_, err = YAMLToJSON([]byte("name: [unterminated"))
+	assert.NotNil(t, err)
+}
@@ -173,7 +173,7 @@ func CreateCardNote() {
 	cardElementKind := "NUMERIC"
 	cardElementText := "text"
 	cardValueType := findingsapiv1.CardValueType{Kind: &cardValueKind, FindingNoteNames: []string{"providers/sdktest/notes/sdk_note_id1"}}
-	cardElement := []findingsapiv1.CardElement{{
+	cardElement := []findingsapiv1.CardElement{&findingsapiv1.NumericCardElement{
 		Kind:      &cardElementKind,
 		Text:      &cardElementText,
 		ValueType: &cardValueType, //ValueType required for kind NUMERIC
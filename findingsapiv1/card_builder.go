@@ -0,0 +1,338 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+)
+
+// CardBuilder : Assembles the note of a dashboard card, for example
+//
+//	options, err := findingsapiv1.NewCardBuilder("My Security Tools", "Vulnerabilities").
+//		Subtitle("Images scanned by my tool").
+//		Numeric("Vulnerable images", findingsapiv1.KPI("providers/my-tool/notes/vulnerable-images")).
+//		TimeSeries("Findings", findingsapiv1.FindingCount("providers/my-tool/notes/cve").WithText("CVEs")).
+//		Build(accountID, "my-tool", "vulnerabilities-card", reportedBy)
+//
+// The finding note names of the card are collected from the FINDING_COUNT value types of its elements. Problems
+// found while building, such as an unreadable badge image, are reported by Build together with the validation of
+// the resulting note.
+type CardBuilder struct {
+	card             Card
+	shortDescription *string
+	longDescription  *string
+	errs             ValidationErrors
+}
+
+// NewCardBuilder : Starts a card with the given section and title
+func NewCardBuilder(section string, title string) *CardBuilder {
+	return &CardBuilder{
+		card: Card{
+			Section:          core.StringPtr(section),
+			Title:            core.StringPtr(title),
+			Subtitle:         core.StringPtr(""),
+			FindingNoteNames: []string{},
+			Elements:         []CardElement{},
+		},
+	}
+}
+
+// KPI : Instantiate a CardValueType of kind KPI for the given KPI note name
+func KPI(kpiNoteName string) CardValueType {
+	return CardValueType{
+		Kind:        core.StringPtr(CardValueType_Kind_Kpi),
+		KpiNoteName: core.StringPtr(kpiNoteName),
+	}
+}
+
+// FindingCount : Instantiate a CardValueType of kind FINDING_COUNT counting the occurrences of the given finding notes
+func FindingCount(findingNoteNames ...string) CardValueType {
+	return CardValueType{
+		Kind:             core.StringPtr(CardValueType_Kind_FindingCount),
+		FindingNoteNames: findingNoteNames,
+	}
+}
+
+// WithText : Returns a copy of the value type with the given text, as required by BREAKDOWN and TIME_SERIES elements
+func (valueType CardValueType) WithText(text string) CardValueType {
+	valueType.Text = core.StringPtr(text)
+	return valueType
+}
+
+// Subtitle : Sets the subtitle of the card
+func (builder *CardBuilder) Subtitle(subtitle string) *CardBuilder {
+	builder.card.Subtitle = core.StringPtr(subtitle)
+	return builder
+}
+
+// Order : Sets the order of the card within its section
+func (builder *CardBuilder) Order(order int64) *CardBuilder {
+	builder.card.Order = core.Int64Ptr(order)
+	return builder
+}
+
+// RequiresConfiguration : Marks the card as requiring configuration
+func (builder *CardBuilder) RequiresConfiguration(requiresConfiguration bool) *CardBuilder {
+	builder.card.RequiresConfiguration = core.BoolPtr(requiresConfiguration)
+	return builder
+}
+
+// Description : Sets the short and long description of the note. They default to the title and subtitle of the card.
+func (builder *CardBuilder) Description(shortDescription string, longDescription string) *CardBuilder {
+	builder.shortDescription = core.StringPtr(shortDescription)
+	builder.longDescription = core.StringPtr(longDescription)
+	return builder
+}
+
+// FindingNotes : Associates finding notes to the card in addition to the ones referenced by its elements
+func (builder *CardBuilder) FindingNotes(findingNoteNames ...string) *CardBuilder {
+	builder.addFindingNoteNames(findingNoteNames)
+	return builder
+}
+
+// Numeric : Adds a NUMERIC element showing a single value
+func (builder *CardBuilder) Numeric(text string, valueType CardValueType) *CardBuilder {
+	builder.addFindingNoteNames(valueType.FindingNoteNames)
+	builder.card.Elements = append(builder.card.Elements, &NumericCardElement{
+		Kind:      core.StringPtr(CardElement_Kind_Numeric),
+		Text:      core.StringPtr(text),
+		ValueType: &valueType,
+	})
+	return builder
+}
+
+// Breakdown : Adds a BREAKDOWN element showing one value per value type. Each value type needs a text.
+func (builder *CardBuilder) Breakdown(text string, valueTypes ...CardValueType) *CardBuilder {
+	for _, valueType := range valueTypes {
+		builder.addFindingNoteNames(valueType.FindingNoteNames)
+	}
+	builder.card.Elements = append(builder.card.Elements, &BreakdownCardElement{
+		Kind:       core.StringPtr(CardElement_Kind_Breakdown),
+		Text:       core.StringPtr(text),
+		ValueTypes: valueTypes,
+	})
+	return builder
+}
+
+// TimeSeries : Adds a TIME_SERIES element charting one line per value type.
+// Each value type needs to be of kind FINDING_COUNT and have a text.
+func (builder *CardBuilder) TimeSeries(text string, valueTypes ...CardValueType) *CardBuilder {
+	findingCounts := make([]FindingCountValueType, len(valueTypes))
+	for i, valueType := range valueTypes {
+		builder.addFindingNoteNames(valueType.FindingNoteNames)
+		findingCounts[i] = FindingCountValueType{
+			Kind:             valueType.Kind,
+			FindingNoteNames: valueType.FindingNoteNames,
+			Text:             valueType.Text,
+		}
+	}
+	builder.card.Elements = append(builder.card.Elements, &TimeSeriesCardElement{
+		Kind:       core.StringPtr(CardElement_Kind_TimeSeries),
+		Text:       core.StringPtr(text),
+		ValueTypes: findingCounts,
+	})
+	return builder
+}
+
+// Badge : Sets the badge of the card. The image file is embedded in the card as base64; an empty imagePath sets the
+// badge text only.
+func (builder *CardBuilder) Badge(text string, imagePath string) *CardBuilder {
+	builder.card.BadgeText = core.StringPtr(text)
+	if imagePath == "" {
+		return builder
+	}
+	image, err := readImage("card.badge_image", imagePath)
+	if err != nil {
+		builder.errs = append(builder.errs, err)
+		return builder
+	}
+	builder.card.BadgeImage = image
+	return builder
+}
+
+// Card : Returns a copy of the validated card
+func (builder *CardBuilder) Card() (model *Card, err error) {
+	v := &validator{errs: append(ValidationErrors(nil), builder.errs...)}
+	card := builder.card
+	card.FindingNoteNames = append([]string{}, builder.card.FindingNoteNames...)
+	card.Elements = append([]CardElement{}, builder.card.Elements...)
+	v.card("card", &card, string(ApiNoteKind_Card))
+	err = v.result()
+	if err == nil {
+		model = &card
+	}
+	return
+}
+
+// Build : Returns validated CreateNoteOptions of kind CARD holding the card
+func (builder *CardBuilder) Build(accountID string, providerID string, noteID string, reportedBy *Reporter) (options *CreateNoteOptions, err error) {
+	card, err := builder.Card()
+	if err != nil {
+		return
+	}
+
+	shortDescription := builder.shortDescription
+	if shortDescription == nil {
+		shortDescription = card.Title
+	}
+	longDescription := builder.longDescription
+	if longDescription == nil {
+		longDescription = card.Subtitle
+	}
+
+	createNoteOptions := &CreateNoteOptions{
+		AccountID:        core.StringPtr(accountID),
+		ProviderID:       core.StringPtr(providerID),
+		ShortDescription: shortDescription,
+		LongDescription:  longDescription,
+		Kind:             core.StringPtr(string(ApiNoteKind_Card)),
+		ID:               core.StringPtr(noteID),
+		ReportedBy:       reportedBy,
+		Card:             card,
+	}
+	err = createNoteOptions.Validate()
+	if err == nil {
+		options = createNoteOptions
+	}
+	return
+}
+
+func (builder *CardBuilder) addFindingNoteNames(findingNoteNames []string) {
+	for _, noteName := range findingNoteNames {
+		found := false
+		for _, existing := range builder.card.FindingNoteNames {
+			if existing == noteName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			builder.card.FindingNoteNames = append(builder.card.FindingNoteNames, noteName)
+		}
+	}
+}
+
+// SectionBuilder : Assembles the note of a dashboard section and the cards shown in it, for example
+//
+//	section := findingsapiv1.NewSectionBuilder("My Security Tools").Image("my-tool.png")
+//	sectionOptions, err := section.Build(accountID, "my-tool", "my-tool-section", reportedBy)
+//	cardOptions, err := section.Card("Vulnerabilities").
+//		Subtitle("Images scanned by my tool").
+//		Numeric("Vulnerable images", findingsapiv1.KPI("providers/my-tool/notes/vulnerable-images")).
+//		Build(accountID, "my-tool", "vulnerabilities-card", reportedBy)
+//
+// Problems found while building, such as an unreadable image, are reported by Build together with the validation
+// of the resulting note.
+type SectionBuilder struct {
+	section          Section
+	shortDescription *string
+	longDescription  *string
+	errs             ValidationErrors
+}
+
+// NewSectionBuilder : Starts a section with the given title
+func NewSectionBuilder(title string) *SectionBuilder {
+	return &SectionBuilder{
+		section: Section{
+			Title: core.StringPtr(title),
+		},
+	}
+}
+
+// Image : Sets the image of the section. The image file is embedded in the section as base64.
+func (builder *SectionBuilder) Image(imagePath string) *SectionBuilder {
+	image, err := readImage("section.image", imagePath)
+	if err != nil {
+		builder.errs = append(builder.errs, err)
+		return builder
+	}
+	builder.section.Image = image
+	return builder
+}
+
+// Description : Sets the short and long description of the note. They both default to the title of the section.
+func (builder *SectionBuilder) Description(shortDescription string, longDescription string) *SectionBuilder {
+	builder.shortDescription = core.StringPtr(shortDescription)
+	builder.longDescription = core.StringPtr(longDescription)
+	return builder
+}
+
+// Card : Starts a card with the given title shown in the section
+func (builder *SectionBuilder) Card(title string) *CardBuilder {
+	return NewCardBuilder(stringValue(builder.section.Title), title)
+}
+
+// Section : Returns a copy of the validated section
+func (builder *SectionBuilder) Section() (model *Section, err error) {
+	v := &validator{errs: append(ValidationErrors(nil), builder.errs...)}
+	section := builder.section
+	v.required("section.title", section.Title)
+	if len(builder.errs) == 0 {
+		// An unreadable image has already been reported.
+		v.required("section.image", section.Image)
+	}
+	err = v.result()
+	if err == nil {
+		model = &section
+	}
+	return
+}
+
+// Build : Returns validated CreateNoteOptions of kind SECTION holding the section
+func (builder *SectionBuilder) Build(accountID string, providerID string, noteID string, reportedBy *Reporter) (options *CreateNoteOptions, err error) {
+	section, err := builder.Section()
+	if err != nil {
+		return
+	}
+
+	shortDescription := builder.shortDescription
+	if shortDescription == nil {
+		shortDescription = section.Title
+	}
+	longDescription := builder.longDescription
+	if longDescription == nil {
+		longDescription = section.Title
+	}
+
+	createNoteOptions := &CreateNoteOptions{
+		AccountID:        core.StringPtr(accountID),
+		ProviderID:       core.StringPtr(providerID),
+		ShortDescription: shortDescription,
+		LongDescription:  longDescription,
+		Kind:             core.StringPtr(string(ApiNoteKind_Section)),
+		ID:               core.StringPtr(noteID),
+		ReportedBy:       reportedBy,
+		Section:          section,
+	}
+	err = createNoteOptions.Validate()
+	if err == nil {
+		options = createNoteOptions
+	}
+	return
+}
+
+// readImage returns the content of the image file as base64.
+func readImage(field string, imagePath string) (*string, *ValidationError) {
+	image, err := ioutil.ReadFile(imagePath)
+	if err != nil {
+		return nil, &ValidationError{Field: field, Message: err.Error()}
+	}
+	return core.StringPtr(base64.StdEncoding.EncodeToString(image)), nil
+}
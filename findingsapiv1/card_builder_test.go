@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CardBuilder`, func() {
+	reportedBy := &findingsapiv1.Reporter{ID: core.StringPtr("id"), Title: core.StringPtr("title")}
+	vulnerabilities := "providers/my-tool/notes/vulnerabilities"
+	configIssues := "providers/my-tool/notes/config-issues"
+
+	It(`builds validated CreateNoteOptions of kind CARD`, func() {
+		dir, err := ioutil.TempDir("", "card")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		imagePath := filepath.Join(dir, "badge.png")
+		Expect(ioutil.WriteFile(imagePath, []byte("PNG"), 0600)).To(BeNil())
+
+		options, err := findingsapiv1.NewCardBuilder("My Security Tools", "My tool").
+			Subtitle("Findings of my tool").
+			Order(2).
+			Numeric("Scanned images", findingsapiv1.KPI("providers/my-tool/notes/scanned")).
+			Breakdown("Issues",
+				findingsapiv1.FindingCount(vulnerabilities).WithText("Vulnerabilities"),
+				findingsapiv1.FindingCount(configIssues).WithText("Config issues")).
+			TimeSeries("Issues over time", findingsapiv1.FindingCount(vulnerabilities, configIssues).WithText("Issues")).
+			Badge("New", imagePath).
+			Build("account", "my-tool", "my-tool-card", reportedBy)
+		Expect(err).To(BeNil())
+		Expect(*options.Kind).To(Equal("CARD"))
+		Expect(*options.ShortDescription).To(Equal("My tool"))
+		Expect(*options.LongDescription).To(Equal("Findings of my tool"))
+		Expect(options.Card.FindingNoteNames).To(Equal([]string{vulnerabilities, configIssues}))
+		Expect(options.Card.Elements).To(HaveLen(3))
+		Expect(options.Card.Elements[2].GetKind()).To(Equal("TIME_SERIES"))
+		Expect(*options.Card.BadgeImage).To(Equal("UE5H"))
+		Expect(*options.Card.Order).To(Equal(int64(2)))
+	})
+	It(`reports malformed note names and invalid value types`, func() {
+		_, err := findingsapiv1.NewCardBuilder("section", "title").
+			Subtitle("subtitle").
+			Description("short", "long").
+			Numeric("KPI", findingsapiv1.KPI("my-tool/kpi")).
+			TimeSeries("Over time", findingsapiv1.KPI("providers/my-tool/notes/kpi").WithText("KPI")).
+			Build("account", "my-tool", "card", reportedBy)
+		Expect(validationFields(err)).To(Equal([]string{
+			"card.elements[0].value_type.kpi_note_name",
+			"card.elements[1].value_types[0].kind",
+			"card.elements[1].value_types[0].finding_note_names",
+		}))
+	})
+	It(`reports an unreadable badge image and a missing subtitle`, func() {
+		_, err := findingsapiv1.NewCardBuilder("section", "title").
+			Numeric("Findings", findingsapiv1.FindingCount(vulnerabilities)).
+			Badge("badge", "does-not-exist.png").
+			Card()
+		Expect(validationFields(err)).To(Equal([]string{"card.badge_image", "card.subtitle"}))
+	})
+})
+
+var _ = Describe(`SectionBuilder`, func() {
+	reportedBy := &findingsapiv1.Reporter{ID: core.StringPtr("id"), Title: core.StringPtr("title")}
+
+	It(`builds validated CreateNoteOptions of kind SECTION and cards shown in the section`, func() {
+		dir, err := ioutil.TempDir("", "section")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		imagePath := filepath.Join(dir, "section.png")
+		Expect(ioutil.WriteFile(imagePath, []byte("PNG"), 0600)).To(BeNil())
+
+		section := findingsapiv1.NewSectionBuilder("My Security Tools").Image(imagePath)
+		options, err := section.Build("account", "my-tool", "my-tool-section", reportedBy)
+		Expect(err).To(BeNil())
+		Expect(*options.Kind).To(Equal("SECTION"))
+		Expect(*options.ShortDescription).To(Equal("My Security Tools"))
+		Expect(*options.Section.Title).To(Equal("My Security Tools"))
+		Expect(*options.Section.Image).To(Equal("UE5H"))
+
+		card, err := section.Card("My tool").
+			Subtitle("Findings of my tool").
+			Numeric("Findings", findingsapiv1.FindingCount("providers/my-tool/notes/finding")).
+			Card()
+		Expect(err).To(BeNil())
+		Expect(*card.Section).To(Equal("My Security Tools"))
+		Expect(*card.Title).To(Equal("My tool"))
+	})
+	It(`reports an unreadable image and a missing title`, func() {
+		_, err := findingsapiv1.NewSectionBuilder("").
+			Image("does-not-exist.png").
+			Description("short", "long").
+			Build("account", "my-tool", "section", reportedBy)
+		Expect(validationFields(err)).To(Equal([]string{"section.image", "section.title"}))
+		_, err = findingsapiv1.NewSectionBuilder("title").Section()
+		Expect(validationFields(err)).To(Equal([]string{"section.image"}))
+	})
+})
+
+var _ = Describe(`ParseNoteName`, func() {
+	It(`splits note names with and without account ID`, func() {
+		accountID, providerID, noteID, err := findingsapiv1.ParseNoteName("account/providers/provider/notes/note")
+		Expect(err).To(BeNil())
+		Expect([]string{accountID, providerID, noteID}).To(Equal([]string{"account", "provider", "note"}))
+		accountID, _, _, err = findingsapiv1.ParseNoteName("providers/provider/notes/note")
+		Expect(err).To(BeNil())
+		Expect(accountID).To(BeEmpty())
+		_, _, _, err = findingsapiv1.ParseNoteName("providers/provider/occurrences/note")
+		Expect(err).NotTo(BeNil())
+		Expect(findingsapiv1.FormatNoteName("account", "provider", "note")).To(Equal("account/providers/provider/notes/note"))
+	})
+})
@@ -0,0 +1,269 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Constants associated with the CardValueType.Kind property.
+// - KPI&#58; Kind of value derived from a KPI occurrence
+// - FINDING_COUNT&#58; Kind of value derived from a count of finding occurrences.
+const (
+	CardValueType_Kind_FindingCount = "FINDING_COUNT"
+	CardValueType_Kind_Kpi          = "KPI"
+)
+
+// UnmarshalCardElement : Decodes a card element into the concrete type matching its kind
+func UnmarshalCardElement(data []byte) (element CardElement, err error) {
+	var header struct {
+		Kind *string `json:"kind"`
+	}
+	err = json.Unmarshal(data, &header)
+	if err != nil {
+		return
+	}
+	if header.Kind == nil {
+		err = fmt.Errorf("card element kind is required")
+		return
+	}
+
+	switch *header.Kind {
+	case CardElement_Kind_Numeric:
+		element = new(NumericCardElement)
+	case CardElement_Kind_Breakdown:
+		element = new(BreakdownCardElement)
+	case CardElement_Kind_TimeSeries:
+		element = new(TimeSeriesCardElement)
+	default:
+		err = fmt.Errorf("unknown card element kind %q", *header.Kind)
+		return
+	}
+	err = json.Unmarshal(data, element)
+	if err != nil {
+		element = nil
+	}
+	return
+}
+
+// UnmarshalJSON : Decodes the card, dispatching each element on its kind
+func (card *Card) UnmarshalJSON(data []byte) error {
+	type cardAlias Card
+	var raw struct {
+		*cardAlias
+		Elements []json.RawMessage `json:"elements"`
+	}
+	raw.cardAlias = (*cardAlias)(card)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	card.Elements = nil
+	if raw.Elements == nil {
+		return nil
+	}
+	card.Elements = make([]CardElement, len(raw.Elements))
+	for i, rawElement := range raw.Elements {
+		element, err := UnmarshalCardElement(rawElement)
+		if err != nil {
+			return fmt.Errorf("card.elements[%d]: %s", i, err.Error())
+		}
+		card.Elements[i] = element
+	}
+	return nil
+}
+
+// Validate : Checks the card and the rules of each of its elements
+func (card *Card) Validate() error {
+	v := &validator{}
+	v.card("card", card, string(ApiNoteKind_Card))
+	return v.result()
+}
+
+// GetKind : Returns the kind of the element
+func (element *NumericCardElement) GetKind() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Kind)
+}
+
+// GetText : Returns the text of the element
+func (element *NumericCardElement) GetText() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Text)
+}
+
+// Validate : Checks that the element has exactly one valid value type
+func (element *NumericCardElement) Validate() error {
+	v := &validator{}
+	element.validate(v, "")
+	return v.result()
+}
+
+func (element *NumericCardElement) validate(v *validator, field string) {
+	if element == nil {
+		v.missingElement(field)
+		return
+	}
+	v.elementHeader(field, element.Kind, element.Text, CardElement_Kind_Numeric)
+	if element.ValueType == nil {
+		v.addf(joinField(field, "value_type"), "is required for kind %s", CardElement_Kind_Numeric)
+		return
+	}
+	v.cardValueType(joinField(field, "value_type"), element.ValueType, false)
+}
+
+// GetKind : Returns the kind of the element
+func (element *BreakdownCardElement) GetKind() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Kind)
+}
+
+// GetText : Returns the text of the element
+func (element *BreakdownCardElement) GetText() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Text)
+}
+
+// Validate : Checks that the element has value types, each of them with a text
+func (element *BreakdownCardElement) Validate() error {
+	v := &validator{}
+	element.validate(v, "")
+	return v.result()
+}
+
+func (element *BreakdownCardElement) validate(v *validator, field string) {
+	if element == nil {
+		v.missingElement(field)
+		return
+	}
+	v.elementHeader(field, element.Kind, element.Text, CardElement_Kind_Breakdown)
+	if len(element.ValueTypes) == 0 {
+		v.addf(joinField(field, "value_types"), "must contain at least one value type for kind %s", CardElement_Kind_Breakdown)
+	}
+	for i := range element.ValueTypes {
+		v.cardValueType(fmt.Sprintf("%s[%d]", joinField(field, "value_types"), i), &element.ValueTypes[i], true)
+	}
+}
+
+// GetKind : Returns the kind of the element
+func (element *TimeSeriesCardElement) GetKind() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Kind)
+}
+
+// GetText : Returns the text of the element
+func (element *TimeSeriesCardElement) GetText() string {
+	if element == nil {
+		return ""
+	}
+	return stringValue(element.Text)
+}
+
+// Validate : Checks that the element has FINDING_COUNT value types, each of them with a text
+func (element *TimeSeriesCardElement) Validate() error {
+	v := &validator{}
+	element.validate(v, "")
+	return v.result()
+}
+
+func (element *TimeSeriesCardElement) validate(v *validator, field string) {
+	if element == nil {
+		v.missingElement(field)
+		return
+	}
+	v.elementHeader(field, element.Kind, element.Text, CardElement_Kind_TimeSeries)
+	if len(element.ValueTypes) == 0 {
+		v.addf(joinField(field, "value_types"), "must contain at least one value type for kind %s", CardElement_Kind_TimeSeries)
+	}
+	for i, valueType := range element.ValueTypes {
+		valueTypeField := fmt.Sprintf("%s[%d]", joinField(field, "value_types"), i)
+		if v.required(valueTypeField+".kind", valueType.Kind) && *valueType.Kind != FindingCountValueType_Kind_FindingCount {
+			v.addf(valueTypeField+".kind", "must be %s for kind %s, got %q",
+				FindingCountValueType_Kind_FindingCount, CardElement_Kind_TimeSeries, *valueType.Kind)
+		}
+		v.findingNoteNames(valueTypeField+".finding_note_names", valueType.FindingNoteNames)
+		v.required(valueTypeField+".text", valueType.Text)
+	}
+}
+
+// missingElement reports a nil element, which an interface holding a typed nil pointer doesn't reveal.
+func (v *validator) missingElement(field string) {
+	if field == "" {
+		field = "element"
+	}
+	v.addf(field, "is required")
+}
+
+func (v *validator) elementHeader(field string, kind *string, text *string, expectedKind string) {
+	if v.required(joinField(field, "kind"), kind) && *kind != expectedKind {
+		v.addf(joinField(field, "kind"), "must be %s, got %q", expectedKind, *kind)
+	}
+	v.required(joinField(field, "text"), text)
+}
+
+func (v *validator) cardValueType(field string, valueType *CardValueType, textRequired bool) {
+	if textRequired {
+		v.required(field+".text", valueType.Text)
+	}
+	if !v.required(field+".kind", valueType.Kind) {
+		return
+	}
+	switch *valueType.Kind {
+	case CardValueType_Kind_Kpi:
+		if v.required(field+".kpi_note_name", valueType.KpiNoteName) {
+			v.noteName(field+".kpi_note_name", *valueType.KpiNoteName)
+		}
+	case CardValueType_Kind_FindingCount:
+		v.findingNoteNames(field+".finding_note_names", valueType.FindingNoteNames)
+	default:
+		v.addf(field+".kind", "must be one of %s, %s, got %q", CardValueType_Kind_Kpi, CardValueType_Kind_FindingCount, *valueType.Kind)
+	}
+}
+
+func (v *validator) findingNoteNames(field string, noteNames []string) {
+	if len(noteNames) == 0 {
+		v.addf(field, "must contain at least one note name")
+	}
+	for i, noteName := range noteNames {
+		v.noteName(fmt.Sprintf("%s[%d]", field, i), noteName)
+	}
+}
+
+func joinField(parent string, field string) string {
+	if parent == "" {
+		return field
+	}
+	return parent + "." + field
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,124 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CardElement`, func() {
+	Describe(`Card.UnmarshalJSON`, func() {
+		It(`dispatches the elements of testInput card.json on their kind`, func() {
+			data, err := ioutil.ReadFile("../testInput/json/card.json")
+			Expect(err).To(BeNil())
+			var options findingsapiv1.CreateNoteOptions
+			Expect(json.Unmarshal(data, &options)).To(BeNil())
+
+			elements := options.Card.Elements
+			Expect(elements).To(HaveLen(2))
+			numeric, ok := elements[0].(*findingsapiv1.NumericCardElement)
+			Expect(ok).To(BeTrue())
+			Expect(*numeric.ValueType.Kind).To(Equal("FINDING_COUNT"))
+			Expect(*numeric.DefaultTimeRange).To(Equal("1d"))
+			timeSeries, ok := elements[1].(*findingsapiv1.TimeSeriesCardElement)
+			Expect(ok).To(BeTrue())
+			Expect(timeSeries.ValueTypes).To(HaveLen(2))
+			Expect(*timeSeries.DefaultInterval).To(Equal("d"))
+			Expect(options.Card.Validate()).To(BeNil())
+		})
+		It(`round-trips the card`, func() {
+			card := &findingsapiv1.Card{
+				Section:          core.StringPtr("section"),
+				Title:            core.StringPtr("title"),
+				Subtitle:         core.StringPtr("subtitle"),
+				FindingNoteNames: []string{"providers/p/notes/n"},
+				Elements: []findingsapiv1.CardElement{&findingsapiv1.BreakdownCardElement{
+					Kind: core.StringPtr("BREAKDOWN"),
+					Text: core.StringPtr("text"),
+					ValueTypes: []findingsapiv1.CardValueType{
+						{Kind: core.StringPtr("KPI"), KpiNoteName: core.StringPtr("providers/p/notes/kpi"), Text: core.StringPtr("kpi")},
+					},
+				}},
+			}
+			data, err := json.Marshal(card)
+			Expect(err).To(BeNil())
+			var decoded findingsapiv1.Card
+			Expect(json.Unmarshal(data, &decoded)).To(BeNil())
+			Expect(decoded).To(Equal(*card))
+		})
+		It(`rejects unknown and missing kinds`, func() {
+			var card findingsapiv1.Card
+			err := json.Unmarshal([]byte(`{"elements": [{"kind": "NUMERIC", "text": "t"}, {"kind": "PIE"}]}`), &card)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring(`card.elements[1]: unknown card element kind "PIE"`))
+			_, err = findingsapiv1.UnmarshalCardElement([]byte(`{"text": "t"}`))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+	Describe(`Validate`, func() {
+		It(`requires a valid value type on NUMERIC elements`, func() {
+			element := &findingsapiv1.NumericCardElement{Kind: core.StringPtr("NUMERIC"), Text: core.StringPtr("text")}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"value_type"}))
+			element.ValueType = &findingsapiv1.CardValueType{Kind: core.StringPtr("KPI")}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"value_type.kpi_note_name"}))
+			element.ValueType.KpiNoteName = core.StringPtr("providers/p/notes/kpi")
+			Expect(element.Validate()).To(BeNil())
+		})
+		It(`requires value types with text on BREAKDOWN elements`, func() {
+			element := &findingsapiv1.BreakdownCardElement{Kind: core.StringPtr("BREAKDOWN"), Text: core.StringPtr("text")}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"value_types"}))
+			element.ValueTypes = []findingsapiv1.CardValueType{{Kind: core.StringPtr("FINDING_COUNT"), FindingNoteNames: []string{"providers/p/notes/n"}}}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"value_types[0].text"}))
+		})
+		It(`requires FINDING_COUNT value types on TIME_SERIES elements`, func() {
+			element := &findingsapiv1.TimeSeriesCardElement{
+				Kind:       core.StringPtr("TIME_SERIES"),
+				Text:       core.StringPtr("text"),
+				ValueTypes: []findingsapiv1.FindingCountValueType{{Kind: core.StringPtr("KPI"), Text: core.StringPtr("kpi")}},
+			}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"value_types[0].kind", "value_types[0].finding_note_names"}))
+		})
+		It(`rejects an element whose kind does not match its type`, func() {
+			element := &findingsapiv1.TimeSeriesCardElement{Kind: core.StringPtr("NUMERIC"), Text: core.StringPtr("text")}
+			Expect(validationFields(element.Validate())).To(Equal([]string{"kind", "value_types"}))
+		})
+		It(`reports typed nil elements instead of panicking`, func() {
+			var numeric *findingsapiv1.NumericCardElement
+			var breakdown *findingsapiv1.BreakdownCardElement
+			var timeSeries *findingsapiv1.TimeSeriesCardElement
+			card := &findingsapiv1.Card{
+				Section:          core.StringPtr("section"),
+				Title:            core.StringPtr("title"),
+				Subtitle:         core.StringPtr("subtitle"),
+				FindingNoteNames: []string{"providers/p/notes/n"},
+				Elements:         []findingsapiv1.CardElement{numeric, breakdown, timeSeries, nil},
+			}
+			Expect(validationFields(card.Validate())).To(Equal([]string{
+				"card.elements[0]", "card.elements[1]", "card.elements[2]", "card.elements[3]",
+			}))
+			Expect(validationFields(numeric.Validate())).To(Equal([]string{"element"}))
+			Expect(breakdown.GetKind()).To(BeEmpty())
+			Expect(timeSeries.GetText()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+)
+
+// ResolvedFinding : The details of a finding occurrence after applying its overrides to the defaults of its note.
+type ResolvedFinding struct {
+
+	// The finding occurrence.
+	Occurrence *ApiOccurrence
+
+	// The note of the occurrence.
+	Note *ApiNote
+
+	// The severity of the occurrence, or the default severity of the note when the occurrence has none.
+	Severity Severity
+
+	// True if Severity comes from the occurrence rather than from the note.
+	SeverityOverridden bool
+
+	// The certainty of the occurrence. Notes have no default certainty.
+	Certainty Certainty
+
+	// The remediation steps of the occurrence, or the common remediation steps of the note when the occurrence has none.
+	NextSteps []RemediationStep
+
+	// True if NextSteps come from the occurrence rather than from the note.
+	NextStepsOverridden bool
+
+	// The related URLs of the note.
+	RelatedURL []ApiNoteRelatedURL
+
+	// The reporter of the note.
+	ReportedBy *Reporter
+}
+
+// ResolveFinding : Merges a finding occurrence with its note.
+// The severity and next steps of the occurrence override the defaults of the note's finding type.
+func ResolveFinding(note *ApiNote, occurrence *ApiOccurrence) (result *ResolvedFinding, err error) {
+	err = core.ValidateNotNil(occurrence, "occurrence cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateNotNil(note, "note cannot be nil")
+	if err != nil {
+		return
+	}
+	if occurrence.GetKind() != ApiNoteKind_Finding {
+		err = fmt.Errorf("occurrence %s is of kind %q, not %s", stringValue(occurrence.ID), occurrence.GetKind(), ApiNoteKind_Finding)
+		return
+	}
+
+	result = &ResolvedFinding{
+		Occurrence: occurrence,
+		Note:       note,
+		Severity:   note.Finding.GetSeverity(),
+		RelatedURL: note.RelatedURL,
+		ReportedBy: note.ReportedBy,
+	}
+	if note.Finding != nil {
+		result.NextSteps = note.Finding.NextSteps
+	}
+	if finding := occurrence.Finding; finding != nil {
+		if finding.Severity != nil && *finding.Severity != "" {
+			result.Severity = finding.GetSeverity()
+			result.SeverityOverridden = true
+		}
+		if len(finding.NextSteps) > 0 {
+			result.NextSteps = finding.NextSteps
+			result.NextStepsOverridden = true
+		}
+		result.Certainty = finding.GetCertainty()
+	}
+	return
+}
+
+// EffectiveFinding : Fetches the note of a finding occurrence and merges both with ResolveFinding.
+// The note is looked up by the occurrence's note name; accountID is used when the note name doesn't include one.
+func (findingsApi *FindingsApiV1) EffectiveFinding(ctx context.Context, accountID string, occurrence *ApiOccurrence) (result *ResolvedFinding, err error) {
+	err = core.ValidateNotNil(occurrence, "occurrence cannot be nil")
+	if err != nil {
+		return
+	}
+	note, err := findingsApi.occurrenceNote(ctx, accountID, occurrence, nil)
+	if err != nil {
+		return
+	}
+	return ResolveFinding(note, occurrence)
+}
+
+// EffectiveFindings : Resolves a batch of occurrences, fetching each distinct note only once.
+// Occurrences that are not of kind FINDING are skipped.
+func (findingsApi *FindingsApiV1) EffectiveFindings(ctx context.Context, accountID string, occurrences []ApiOccurrence) (result []*ResolvedFinding, err error) {
+	notes := make(map[string]*ApiNote)
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		if occurrence.GetKind() != ApiNoteKind_Finding {
+			continue
+		}
+		var note *ApiNote
+		note, err = findingsApi.occurrenceNote(ctx, accountID, occurrence, notes)
+		if err != nil {
+			return nil, err
+		}
+		var resolved *ResolvedFinding
+		resolved, err = ResolveFinding(note, occurrence)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resolved)
+	}
+	return
+}
+
+// occurrenceNote fetches the note of the occurrence, going through the notes cache when it isn't nil.
+func (findingsApi *FindingsApiV1) occurrenceNote(ctx context.Context, accountID string, occurrence *ApiOccurrence, notes map[string]*ApiNote) (note *ApiNote, err error) {
+	noteName := stringValue(occurrence.NoteName)
+	if note, ok := notes[noteName]; ok {
+		return note, nil
+	}
+
+	noteAccountID, providerID, noteID, err := ParseNoteName(noteName)
+	if err != nil {
+		return
+	}
+	if noteAccountID == "" {
+		noteAccountID = accountID
+	}
+	note, _, err = findingsApi.GetNoteWithContext(ctx, findingsApi.NewGetNoteOptions(noteAccountID, providerID, noteID))
+	if err != nil {
+		err = fmt.Errorf("failed to get note %s of occurrence %s: %s", noteName, stringValue(occurrence.ID), err.Error())
+		return
+	}
+	if notes != nil {
+		notes[noteName] = note
+	}
+	return
+}
@@ -0,0 +1,124 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`EffectiveFinding`, func() {
+	notePath := "/v1/account/providers/my-tool/notes/vulnerability"
+	noteBody := `{"kind": "FINDING", "id": "vulnerability", "short_description": "s", "long_description": "l",
+		"related_url": [{"label": "docs", "url": "https://example.com/docs"}],
+		"reported_by": {"id": "my-tool", "title": "My tool"},
+		"finding": {"severity": "MEDIUM", "next_steps": [{"title": "Upgrade"}]}}`
+
+	newOccurrence := func(id string, kind string, finding *findingsapiv1.Finding) findingsapiv1.ApiOccurrence {
+		return findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr(id),
+			Kind:     core.StringPtr(kind),
+			NoteName: core.StringPtr("providers/my-tool/notes/vulnerability"),
+			Finding:  finding,
+		}
+	}
+
+	It(`falls back to the defaults of the note`, func() {
+		note := &findingsapiv1.ApiNote{
+			Finding: &findingsapiv1.FindingType{
+				Severity:  core.StringPtr("HIGH"),
+				NextSteps: []findingsapiv1.RemediationStep{{Title: core.StringPtr("Patch")}},
+			},
+		}
+		occurrence := newOccurrence("occurrence", "FINDING", &findingsapiv1.Finding{Certainty: core.StringPtr("LOW")})
+		result, err := findingsapiv1.ResolveFinding(note, &occurrence)
+		Expect(err).To(BeNil())
+		Expect(result.Severity).To(Equal(findingsapiv1.Severity_High))
+		Expect(result.SeverityOverridden).To(BeFalse())
+		Expect(result.Certainty).To(Equal(findingsapiv1.Certainty_Low))
+		Expect(*result.NextSteps[0].Title).To(Equal("Patch"))
+		Expect(result.NextStepsOverridden).To(BeFalse())
+
+		occurrence = newOccurrence("occurrence", "KPI", nil)
+		_, err = findingsapiv1.ResolveFinding(note, &occurrence)
+		Expect(err).NotTo(BeNil())
+	})
+	It(`applies the overrides of the occurrence and fetches each note once`, func() {
+		noteRequests := 0
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal(notePath))
+			noteRequests++
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, noteBody)
+		}))
+		defer testServer.Close()
+
+		testService, testServiceErr := findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(testServiceErr).To(BeNil())
+
+		occurrence := newOccurrence("single", "FINDING", &findingsapiv1.Finding{
+			Severity:  core.StringPtr("CRITICAL"),
+			NextSteps: []findingsapiv1.RemediationStep{{Title: core.StringPtr("Rotate keys")}},
+		})
+		result, err := testService.EffectiveFinding(context.Background(), "account", &occurrence)
+		Expect(err).To(BeNil())
+		Expect(result.Severity).To(Equal(findingsapiv1.Severity_Critical))
+		Expect(result.SeverityOverridden).To(BeTrue())
+		Expect(*result.NextSteps[0].Title).To(Equal("Rotate keys"))
+		Expect(result.NextStepsOverridden).To(BeTrue())
+		Expect(*result.RelatedURL[0].Label).To(Equal("docs"))
+		Expect(*result.ReportedBy.ID).To(Equal("my-tool"))
+		Expect(noteRequests).To(Equal(1))
+
+		noteRequests = 0
+		results, err := testService.EffectiveFindings(context.Background(), "account", []findingsapiv1.ApiOccurrence{
+			newOccurrence("first", "FINDING", nil),
+			newOccurrence("kpi", "KPI", nil),
+			newOccurrence("second", "FINDING", &findingsapiv1.Finding{Severity: core.StringPtr("LOW")}),
+		})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Severity).To(Equal(findingsapiv1.Severity_Medium))
+		Expect(*results[0].NextSteps[0].Title).To(Equal("Upgrade"))
+		Expect(results[1].Severity).To(Equal(findingsapiv1.Severity_Low))
+		Expect(noteRequests).To(Equal(1))
+	})
+	It(`reports occurrences with a malformed note name`, func() {
+		testService, _ := findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+			URL:           "http://localhost",
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		occurrence := newOccurrence("occurrence", "FINDING", nil)
+		occurrence.NoteName = core.StringPtr("vulnerability")
+		_, err := testService.EffectiveFinding(context.Background(), "account", &occurrence)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,226 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+)
+
+// severityRanks orders the severities from the lowest to the highest impact.
+var severityRanks = map[Severity]int{
+	Severity_Low:      1,
+	Severity_Medium:   2,
+	Severity_High:     3,
+	Severity_Critical: 4,
+}
+
+// certaintyRanks orders the certainties from the lowest to the highest confidence.
+var certaintyRanks = map[Certainty]int{
+	Certainty_Low:    1,
+	Certainty_Medium: 2,
+	Certainty_High:   3,
+}
+
+var noteKinds = []ApiNoteKind{
+	ApiNoteKind_Finding,
+	ApiNoteKind_Kpi,
+	ApiNoteKind_Card,
+	ApiNoteKind_CardConfigured,
+	ApiNoteKind_Section,
+}
+
+// Severities : Returns all severities, from the lowest to the highest impact
+func Severities() []Severity {
+	return []Severity{Severity_Low, Severity_Medium, Severity_High, Severity_Critical}
+}
+
+// ParseSeverity : Converts a case-insensitive severity name to a Severity
+func ParseSeverity(value string) (Severity, error) {
+	severity := Severity(strings.ToUpper(strings.TrimSpace(value)))
+	if !severity.IsValid() {
+		return "", fmt.Errorf("unknown severity %q", value)
+	}
+	return severity, nil
+}
+
+// IsValid : Reports whether the severity is one of the Severity constants
+func (severity Severity) IsValid() bool {
+	_, ok := severityRanks[severity]
+	return ok
+}
+
+// Rank : Returns the position of the severity from 1 (LOW) to 4 (CRITICAL), or 0 for an unknown severity
+func (severity Severity) Rank() int {
+	return severityRanks[severity]
+}
+
+// Compare : Returns -1, 0 or 1 when the severity is lower than, equal to or higher than other.
+// Unknown severities rank below LOW.
+func (severity Severity) Compare(other Severity) int {
+	return compareRanks(severity.Rank(), other.Rank())
+}
+
+// AtLeast : Reports whether the severity is the same as or higher than threshold
+func (severity Severity) AtLeast(threshold Severity) bool {
+	return severity.Compare(threshold) >= 0
+}
+
+// String : Returns the severity as sent to the service
+func (severity Severity) String() string {
+	return string(severity)
+}
+
+// Certainties : Returns all certainties, from the lowest to the highest confidence
+func Certainties() []Certainty {
+	return []Certainty{Certainty_Low, Certainty_Medium, Certainty_High}
+}
+
+// ParseCertainty : Converts a case-insensitive certainty name to a Certainty
+func ParseCertainty(value string) (Certainty, error) {
+	certainty := Certainty(strings.ToUpper(strings.TrimSpace(value)))
+	if !certainty.IsValid() {
+		return "", fmt.Errorf("unknown certainty %q", value)
+	}
+	return certainty, nil
+}
+
+// IsValid : Reports whether the certainty is one of the Certainty constants
+func (certainty Certainty) IsValid() bool {
+	_, ok := certaintyRanks[certainty]
+	return ok
+}
+
+// Rank : Returns the position of the certainty from 1 (LOW) to 3 (HIGH), or 0 for an unknown certainty
+func (certainty Certainty) Rank() int {
+	return certaintyRanks[certainty]
+}
+
+// Compare : Returns -1, 0 or 1 when the certainty is lower than, equal to or higher than other.
+// Unknown certainties rank below LOW.
+func (certainty Certainty) Compare(other Certainty) int {
+	return compareRanks(certainty.Rank(), other.Rank())
+}
+
+// AtLeast : Reports whether the certainty is the same as or higher than threshold
+func (certainty Certainty) AtLeast(threshold Certainty) bool {
+	return certainty.Compare(threshold) >= 0
+}
+
+// String : Returns the certainty as sent to the service
+func (certainty Certainty) String() string {
+	return string(certainty)
+}
+
+// ApiNoteKinds : Returns all note kinds
+func ApiNoteKinds() []ApiNoteKind {
+	return append([]ApiNoteKind(nil), noteKinds...)
+}
+
+// ParseApiNoteKind : Converts a case-insensitive kind name to an ApiNoteKind
+func ParseApiNoteKind(value string) (ApiNoteKind, error) {
+	kind := ApiNoteKind(strings.ToUpper(strings.TrimSpace(value)))
+	if !kind.IsValid() {
+		return "", fmt.Errorf("unknown note kind %q", value)
+	}
+	return kind, nil
+}
+
+// IsValid : Reports whether the kind is one of the ApiNoteKind constants
+func (kind ApiNoteKind) IsValid() bool {
+	for _, candidate := range noteKinds {
+		if kind == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// String : Returns the kind as sent to the service
+func (kind ApiNoteKind) String() string {
+	return string(kind)
+}
+
+// GetSeverity : Returns the severity of the finding occurrence, or "" when it is not set
+func (finding *Finding) GetSeverity() Severity {
+	if finding == nil || finding.Severity == nil {
+		return ""
+	}
+	return Severity(*finding.Severity)
+}
+
+// SetSeverity : Allow user to set Severity
+func (finding *Finding) SetSeverity(severity Severity) *Finding {
+	finding.Severity = core.StringPtr(string(severity))
+	return finding
+}
+
+// GetCertainty : Returns the certainty of the finding occurrence, or "" when it is not set
+func (finding *Finding) GetCertainty() Certainty {
+	if finding == nil || finding.Certainty == nil {
+		return ""
+	}
+	return Certainty(*finding.Certainty)
+}
+
+// SetCertainty : Allow user to set Certainty
+func (finding *Finding) SetCertainty(certainty Certainty) *Finding {
+	finding.Certainty = core.StringPtr(string(certainty))
+	return finding
+}
+
+// GetSeverity : Returns the default severity of the finding note, or "" when it is not set
+func (findingType *FindingType) GetSeverity() Severity {
+	if findingType == nil || findingType.Severity == nil {
+		return ""
+	}
+	return Severity(*findingType.Severity)
+}
+
+// SetSeverity : Allow user to set Severity
+func (findingType *FindingType) SetSeverity(severity Severity) *FindingType {
+	findingType.Severity = core.StringPtr(string(severity))
+	return findingType
+}
+
+// GetKind : Returns the kind of the note, or "" when it is not set
+func (note *ApiNote) GetKind() ApiNoteKind {
+	if note == nil || note.Kind == nil {
+		return ""
+	}
+	return ApiNoteKind(*note.Kind)
+}
+
+// GetKind : Returns the kind of the occurrence, or "" when it is not set
+func (occurrence *ApiOccurrence) GetKind() ApiNoteKind {
+	if occurrence == nil || occurrence.Kind == nil {
+		return ""
+	}
+	return ApiNoteKind(*occurrence.Kind)
+}
+
+func compareRanks(rank int, other int) int {
+	switch {
+	case rank < other:
+		return -1
+	case rank > other:
+		return 1
+	}
+	return 0
+}
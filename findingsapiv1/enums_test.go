@@ -0,0 +1,126 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Enums`, func() {
+	Describe(`Severity`, func() {
+		It(`parses case-insensitive names`, func() {
+			severity, err := findingsapiv1.ParseSeverity(" critical ")
+			Expect(err).To(BeNil())
+			Expect(severity).To(Equal(findingsapiv1.Severity_Critical))
+			_, err = findingsapiv1.ParseSeverity("SEVERE")
+			Expect(err).NotTo(BeNil())
+		})
+		It(`orders severities by impact`, func() {
+			Expect(findingsapiv1.Severity_High.Compare(findingsapiv1.Severity_Medium)).To(Equal(1))
+			Expect(findingsapiv1.Severity_Low.Compare(findingsapiv1.Severity_Critical)).To(Equal(-1))
+			Expect(findingsapiv1.Severity_High.AtLeast(findingsapiv1.Severity_High)).To(BeTrue())
+			Expect(findingsapiv1.Severity("Critical").AtLeast(findingsapiv1.Severity_Low)).To(BeFalse())
+			Expect(findingsapiv1.Severities()).To(HaveLen(4))
+		})
+		It(`marshals as a plain string`, func() {
+			var severity findingsapiv1.Severity
+			Expect(json.Unmarshal([]byte(`"Critical"`), &severity)).To(BeNil())
+			Expect(severity.IsValid()).To(BeFalse())
+			Expect(json.Unmarshal([]byte(`"CRITICAL"`), &severity)).To(BeNil())
+			Expect(severity.IsValid()).To(BeTrue())
+			data, err := json.Marshal(severity)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`"CRITICAL"`))
+		})
+	})
+	Describe(`Certainty`, func() {
+		It(`parses and orders certainties`, func() {
+			certainty, err := findingsapiv1.ParseCertainty("medium")
+			Expect(err).To(BeNil())
+			Expect(certainty.AtLeast(findingsapiv1.Certainty_Low)).To(BeTrue())
+			Expect(certainty.AtLeast(findingsapiv1.Certainty_High)).To(BeFalse())
+			_, err = findingsapiv1.ParseCertainty("CRITICAL")
+			Expect(err).NotTo(BeNil())
+		})
+		It(`validates certainties`, func() {
+			Expect(findingsapiv1.Certainty("SURE").IsValid()).To(BeFalse())
+			Expect(findingsapiv1.Certainty_High.IsValid()).To(BeTrue())
+			Expect(findingsapiv1.Certainties()).To(HaveLen(3))
+		})
+	})
+	Describe(`ApiNoteKind`, func() {
+		It(`parses and validates kinds`, func() {
+			kind, err := findingsapiv1.ParseApiNoteKind("card_configured")
+			Expect(err).To(BeNil())
+			Expect(kind).To(Equal(findingsapiv1.ApiNoteKind_CardConfigured))
+			Expect(findingsapiv1.ApiNoteKind("ALERT").IsValid()).To(BeFalse())
+			Expect(findingsapiv1.ApiNoteKinds()).To(HaveLen(5))
+		})
+	})
+	Describe(`DecodeStrict`, func() {
+		It(`decodes known values`, func() {
+			var notes []findingsapiv1.ApiNote
+			Expect(findingsapiv1.DecodeStrict([]byte(`[{"id": "n", "kind": "FINDING", "finding": {"severity": "HIGH"}}]`), &notes)).To(Succeed())
+			Expect(notes[0].GetKind()).To(Equal(findingsapiv1.ApiNoteKind_Finding))
+
+			var severity findingsapiv1.Severity
+			Expect(findingsapiv1.DecodeStrict([]byte(`"LOW"`), &severity)).To(Succeed())
+			Expect(severity).To(Equal(findingsapiv1.Severity_Low))
+		})
+		It(`rejects unknown values at any depth`, func() {
+			var notes []findingsapiv1.ApiNote
+			err := findingsapiv1.DecodeStrict([]byte(`[{"id": "n", "kind": "FINDING", "finding": {"severity": "HIGH"}},
+				{"id": "m", "kind": "ALERT", "finding": {"severity": "Critical"}}]`), &notes)
+			Expect(err).To(BeAssignableToTypeOf(findingsapiv1.ValidationErrors{}))
+			Expect(err.Error()).To(ContainSubstring(`[1].kind: unknown note kind "ALERT"`))
+			Expect(err.Error()).To(ContainSubstring(`[1].finding.severity: unknown severity "Critical"`))
+
+			var occurrences map[string]*findingsapiv1.ApiOccurrence
+			err = findingsapiv1.DecodeStrict([]byte(`{"o": {"kind": "FINDING", "finding": {"certainty": "SURE"}}}`), &occurrences)
+			Expect(err).To(MatchError(ContainSubstring(`o.finding.certainty: unknown certainty "SURE"`)))
+
+			var severity findingsapiv1.Severity
+			Expect(findingsapiv1.DecodeStrict([]byte(`"SEVERE"`), &severity)).To(MatchError(ContainSubstring(`unknown severity "SEVERE"`)))
+			Expect(findingsapiv1.DecodeStrict([]byte(`{`), &severity)).NotTo(Succeed())
+		})
+	})
+	Describe(`Accessors`, func() {
+		It(`reads and writes typed values on the models`, func() {
+			finding := &findingsapiv1.Finding{}
+			Expect(finding.GetSeverity()).To(BeEmpty())
+			finding.SetSeverity(findingsapiv1.Severity_High).SetCertainty(findingsapiv1.Certainty_Low)
+			Expect(*finding.Severity).To(Equal("HIGH"))
+			Expect(finding.GetCertainty()).To(Equal(findingsapiv1.Certainty_Low))
+
+			findingType := &findingsapiv1.FindingType{}
+			findingType.SetSeverity(findingsapiv1.Severity_Medium)
+			Expect(findingType.GetSeverity()).To(Equal(findingsapiv1.Severity_Medium))
+
+			note := &findingsapiv1.ApiNote{Kind: core.StringPtr("KPI")}
+			Expect(note.GetKind()).To(Equal(findingsapiv1.ApiNoteKind_Kpi))
+			occurrence := &findingsapiv1.ApiOccurrence{Kind: core.StringPtr("FINDING")}
+			Expect(occurrence.GetKind()).To(Equal(findingsapiv1.ApiNoteKind_Finding))
+			var nilNote *findingsapiv1.ApiNote
+			Expect(nilNote.GetKind()).To(BeEmpty())
+		})
+	})
+})
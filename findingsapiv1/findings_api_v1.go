@@ -18,6 +18,7 @@
 package findingsapiv1
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -109,6 +110,11 @@ func (findingsApi *FindingsApiV1) SetServiceURL(url string) error {
 // PostGraph : query findings
 // query findings.
 func (findingsApi *FindingsApiV1) PostGraph(postGraphOptions *PostGraphOptions) (response *core.DetailedResponse, err error) {
+	return findingsApi.PostGraphWithContext(context.Background(), postGraphOptions)
+}
+
+// PostGraphWithContext : query findings, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) PostGraphWithContext(ctx context.Context, postGraphOptions *PostGraphOptions) (response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(postGraphOptions, "postGraphOptions cannot be nil")
 	if err != nil {
 		return
@@ -149,6 +155,7 @@ func (findingsApi *FindingsApiV1) PostGraph(postGraphOptions *PostGraphOptions)
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, "{}")
 
@@ -157,6 +164,11 @@ func (findingsApi *FindingsApiV1) PostGraph(postGraphOptions *PostGraphOptions)
 
 // CreateNote : Creates a new `Note`
 func (findingsApi *FindingsApiV1) CreateNote(createNoteOptions *CreateNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
+	return findingsApi.CreateNoteWithContext(context.Background(), createNoteOptions)
+}
+
+// CreateNoteWithContext : Creates a new `Note`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) CreateNoteWithContext(ctx context.Context, createNoteOptions *CreateNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(createNoteOptions, "createNoteOptions cannot be nil")
 	if err != nil {
 		return
@@ -239,6 +251,7 @@ func (findingsApi *FindingsApiV1) CreateNote(createNoteOptions *CreateNoteOption
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiNote))
 	if err == nil {
@@ -254,6 +267,11 @@ func (findingsApi *FindingsApiV1) CreateNote(createNoteOptions *CreateNoteOption
 
 // ListNotes : Lists all `Notes` for a given provider
 func (findingsApi *FindingsApiV1) ListNotes(listNotesOptions *ListNotesOptions) (result *ApiListNotesResponse, response *core.DetailedResponse, err error) {
+	return findingsApi.ListNotesWithContext(context.Background(), listNotesOptions)
+}
+
+// ListNotesWithContext : Lists all `Notes` for a given provider, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) ListNotesWithContext(ctx context.Context, listNotesOptions *ListNotesOptions) (result *ApiListNotesResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(listNotesOptions, "listNotesOptions cannot be nil")
 	if err != nil {
 		return
@@ -294,6 +312,7 @@ func (findingsApi *FindingsApiV1) ListNotes(listNotesOptions *ListNotesOptions)
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiListNotesResponse))
 	if err == nil {
@@ -309,6 +328,11 @@ func (findingsApi *FindingsApiV1) ListNotes(listNotesOptions *ListNotesOptions)
 
 // GetNote : Returns the requested `Note`
 func (findingsApi *FindingsApiV1) GetNote(getNoteOptions *GetNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
+	return findingsApi.GetNoteWithContext(context.Background(), getNoteOptions)
+}
+
+// GetNoteWithContext : Returns the requested `Note`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) GetNoteWithContext(ctx context.Context, getNoteOptions *GetNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(getNoteOptions, "getNoteOptions cannot be nil")
 	if err != nil {
 		return
@@ -342,6 +366,7 @@ func (findingsApi *FindingsApiV1) GetNote(getNoteOptions *GetNoteOptions) (resul
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiNote))
 	if err == nil {
@@ -357,6 +382,11 @@ func (findingsApi *FindingsApiV1) GetNote(getNoteOptions *GetNoteOptions) (resul
 
 // UpdateNote : Updates an existing `Note`
 func (findingsApi *FindingsApiV1) UpdateNote(updateNoteOptions *UpdateNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
+	return findingsApi.UpdateNoteWithContext(context.Background(), updateNoteOptions)
+}
+
+// UpdateNoteWithContext : Updates an existing `Note`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) UpdateNoteWithContext(ctx context.Context, updateNoteOptions *UpdateNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(updateNoteOptions, "updateNoteOptions cannot be nil")
 	if err != nil {
 		return
@@ -439,6 +469,7 @@ func (findingsApi *FindingsApiV1) UpdateNote(updateNoteOptions *UpdateNoteOption
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiNote))
 	if err == nil {
@@ -454,6 +485,11 @@ func (findingsApi *FindingsApiV1) UpdateNote(updateNoteOptions *UpdateNoteOption
 
 // DeleteNote : Deletes the given `Note` from the system
 func (findingsApi *FindingsApiV1) DeleteNote(deleteNoteOptions *DeleteNoteOptions) (response *core.DetailedResponse, err error) {
+	return findingsApi.DeleteNoteWithContext(context.Background(), deleteNoteOptions)
+}
+
+// DeleteNoteWithContext : Deletes the given `Note` from the system, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) DeleteNoteWithContext(ctx context.Context, deleteNoteOptions *DeleteNoteOptions) (response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(deleteNoteOptions, "deleteNoteOptions cannot be nil")
 	if err != nil {
 		return
@@ -487,6 +523,7 @@ func (findingsApi *FindingsApiV1) DeleteNote(deleteNoteOptions *DeleteNoteOption
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, nil)
 
@@ -495,6 +532,11 @@ func (findingsApi *FindingsApiV1) DeleteNote(deleteNoteOptions *DeleteNoteOption
 
 // GetOccurrenceNote : Gets the `Note` attached to the given `Occurrence`
 func (findingsApi *FindingsApiV1) GetOccurrenceNote(getOccurrenceNoteOptions *GetOccurrenceNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
+	return findingsApi.GetOccurrenceNoteWithContext(context.Background(), getOccurrenceNoteOptions)
+}
+
+// GetOccurrenceNoteWithContext : Gets the `Note` attached to the given `Occurrence`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) GetOccurrenceNoteWithContext(ctx context.Context, getOccurrenceNoteOptions *GetOccurrenceNoteOptions) (result *ApiNote, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(getOccurrenceNoteOptions, "getOccurrenceNoteOptions cannot be nil")
 	if err != nil {
 		return
@@ -528,6 +570,7 @@ func (findingsApi *FindingsApiV1) GetOccurrenceNote(getOccurrenceNoteOptions *Ge
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiNote))
 	if err == nil {
@@ -543,6 +586,11 @@ func (findingsApi *FindingsApiV1) GetOccurrenceNote(getOccurrenceNoteOptions *Ge
 
 // CreateOccurrence : Creates a new `Occurrence`. Use this method to create `Occurrences` for a resource
 func (findingsApi *FindingsApiV1) CreateOccurrence(createOccurrenceOptions *CreateOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
+	return findingsApi.CreateOccurrenceWithContext(context.Background(), createOccurrenceOptions)
+}
+
+// CreateOccurrenceWithContext : Creates a new `Occurrence`. Use this method to create `Occurrences` for a resource, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) CreateOccurrenceWithContext(ctx context.Context, createOccurrenceOptions *CreateOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(createOccurrenceOptions, "createOccurrenceOptions cannot be nil")
 	if err != nil {
 		return
@@ -616,6 +664,7 @@ func (findingsApi *FindingsApiV1) CreateOccurrence(createOccurrenceOptions *Crea
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiOccurrence))
 	if err == nil {
@@ -631,6 +680,11 @@ func (findingsApi *FindingsApiV1) CreateOccurrence(createOccurrenceOptions *Crea
 
 // ListOccurrences : Lists active `Occurrences` for a given provider matching the filters
 func (findingsApi *FindingsApiV1) ListOccurrences(listOccurrencesOptions *ListOccurrencesOptions) (result *ApiListOccurrencesResponse, response *core.DetailedResponse, err error) {
+	return findingsApi.ListOccurrencesWithContext(context.Background(), listOccurrencesOptions)
+}
+
+// ListOccurrencesWithContext : Lists active `Occurrences` for a given provider matching the filters, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) ListOccurrencesWithContext(ctx context.Context, listOccurrencesOptions *ListOccurrencesOptions) (result *ApiListOccurrencesResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(listOccurrencesOptions, "listOccurrencesOptions cannot be nil")
 	if err != nil {
 		return
@@ -671,6 +725,7 @@ func (findingsApi *FindingsApiV1) ListOccurrences(listOccurrencesOptions *ListOc
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiListOccurrencesResponse))
 	if err == nil {
@@ -686,6 +741,11 @@ func (findingsApi *FindingsApiV1) ListOccurrences(listOccurrencesOptions *ListOc
 
 // ListNoteOccurrences : Lists `Occurrences` referencing the specified `Note`. Use this method to get all occurrences referencing your `Note` across all your customer providers
 func (findingsApi *FindingsApiV1) ListNoteOccurrences(listNoteOccurrencesOptions *ListNoteOccurrencesOptions) (result *ApiListNoteOccurrencesResponse, response *core.DetailedResponse, err error) {
+	return findingsApi.ListNoteOccurrencesWithContext(context.Background(), listNoteOccurrencesOptions)
+}
+
+// ListNoteOccurrencesWithContext : Lists `Occurrences` referencing the specified `Note`. Use this method to get all occurrences referencing your `Note` across all your customer providers, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) ListNoteOccurrencesWithContext(ctx context.Context, listNoteOccurrencesOptions *ListNoteOccurrencesOptions) (result *ApiListNoteOccurrencesResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(listNoteOccurrencesOptions, "listNoteOccurrencesOptions cannot be nil")
 	if err != nil {
 		return
@@ -726,6 +786,7 @@ func (findingsApi *FindingsApiV1) ListNoteOccurrences(listNoteOccurrencesOptions
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiListNoteOccurrencesResponse))
 	if err == nil {
@@ -741,6 +802,11 @@ func (findingsApi *FindingsApiV1) ListNoteOccurrences(listNoteOccurrencesOptions
 
 // GetOccurrence : Returns the requested `Occurrence`
 func (findingsApi *FindingsApiV1) GetOccurrence(getOccurrenceOptions *GetOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
+	return findingsApi.GetOccurrenceWithContext(context.Background(), getOccurrenceOptions)
+}
+
+// GetOccurrenceWithContext : Returns the requested `Occurrence`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) GetOccurrenceWithContext(ctx context.Context, getOccurrenceOptions *GetOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(getOccurrenceOptions, "getOccurrenceOptions cannot be nil")
 	if err != nil {
 		return
@@ -774,6 +840,7 @@ func (findingsApi *FindingsApiV1) GetOccurrence(getOccurrenceOptions *GetOccurre
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiOccurrence))
 	if err == nil {
@@ -789,6 +856,11 @@ func (findingsApi *FindingsApiV1) GetOccurrence(getOccurrenceOptions *GetOccurre
 
 // UpdateOccurrence : Updates an existing `Occurrence`
 func (findingsApi *FindingsApiV1) UpdateOccurrence(updateOccurrenceOptions *UpdateOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
+	return findingsApi.UpdateOccurrenceWithContext(context.Background(), updateOccurrenceOptions)
+}
+
+// UpdateOccurrenceWithContext : Updates an existing `Occurrence`, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) UpdateOccurrenceWithContext(ctx context.Context, updateOccurrenceOptions *UpdateOccurrenceOptions) (result *ApiOccurrence, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(updateOccurrenceOptions, "updateOccurrenceOptions cannot be nil")
 	if err != nil {
 		return
@@ -859,6 +931,7 @@ func (findingsApi *FindingsApiV1) UpdateOccurrence(updateOccurrenceOptions *Upda
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiOccurrence))
 	if err == nil {
@@ -874,6 +947,11 @@ func (findingsApi *FindingsApiV1) UpdateOccurrence(updateOccurrenceOptions *Upda
 
 // DeleteOccurrence : Deletes the given `Occurrence` from the system
 func (findingsApi *FindingsApiV1) DeleteOccurrence(deleteOccurrenceOptions *DeleteOccurrenceOptions) (response *core.DetailedResponse, err error) {
+	return findingsApi.DeleteOccurrenceWithContext(context.Background(), deleteOccurrenceOptions)
+}
+
+// DeleteOccurrenceWithContext : Deletes the given `Occurrence` from the system, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) DeleteOccurrenceWithContext(ctx context.Context, deleteOccurrenceOptions *DeleteOccurrenceOptions) (response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(deleteOccurrenceOptions, "deleteOccurrenceOptions cannot be nil")
 	if err != nil {
 		return
@@ -907,6 +985,7 @@ func (findingsApi *FindingsApiV1) DeleteOccurrence(deleteOccurrenceOptions *Dele
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, nil)
 
@@ -915,6 +994,11 @@ func (findingsApi *FindingsApiV1) DeleteOccurrence(deleteOccurrenceOptions *Dele
 
 // ListProviders : Lists all `Providers` for a given account id
 func (findingsApi *FindingsApiV1) ListProviders(listProvidersOptions *ListProvidersOptions) (result *ApiListProvidersResponse, response *core.DetailedResponse, err error) {
+	return findingsApi.ListProvidersWithContext(context.Background(), listProvidersOptions)
+}
+
+// ListProvidersWithContext : Lists all `Providers` for a given account id, aborting the request when ctx is done
+func (findingsApi *FindingsApiV1) ListProvidersWithContext(ctx context.Context, listProvidersOptions *ListProvidersOptions) (result *ApiListProvidersResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(listProvidersOptions, "listProvidersOptions cannot be nil")
 	if err != nil {
 		return
@@ -961,6 +1045,7 @@ func (findingsApi *FindingsApiV1) ListProviders(listProvidersOptions *ListProvid
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = findingsApi.Service.Request(request, new(ApiListProvidersResponse))
 	if err == nil {
@@ -1037,28 +1122,21 @@ type CardValueType struct {
 }
 
 // CardElement : CardElement provides details about the elements of a Card.
-type CardElement struct {
-
-	// Kind of element
-	// - NUMERIC&#58; Single numeric value
-	// - BREAKDOWN&#58; Breakdown of numeric values
-	// - TIME_SERIES&#58; Time-series of numeric values.
-	Kind *string `json:"kind" validate:"required"`
+// It is implemented by NumericCardElement, BreakdownCardElement and TimeSeriesCardElement, one for each kind of
+// element. Before this interface was introduced CardElement was a struct holding the fields of every kind; code
+// building card elements as struct literals needs to use the type of the element kind instead.
+type CardElement interface {
 
-	//Text to display on the card
-	Text *string `json:"text" validate:"required"`
-
-	//ValueType the type of an element in a card
-	ValueType *CardValueType `json:"value_type,omitempty"`
+	// GetKind returns the kind of the element.
+	GetKind() string
 
-	//ValueTypes the type of an element in a card
-	ValueTypes []CardValueType `json:"value_types,omitempty"`
+	// GetText returns the text displayed on the card.
+	GetText() string
 
-	// The default time range of this card element.
-	DefaultTimeRange *string `json:"default_time_range,omitempty"`
+	// Validate checks the rules of the element kind.
+	Validate() error
 
-	// The default interval of the time series.
-	DefaultInterval *string `json:"default_interval,omitempty"`
+	validate(v *validator, field string)
 }
 
 // Constants associated with the CardElement.Kind property.
@@ -1072,13 +1150,20 @@ const (
 	CardElement_Kind_TimeSeries = "TIME_SERIES"
 )
 
-// NewCardElement : Instantiate CardElement (Generic Model Constructor)
-func (findingsApi *FindingsApiV1) NewCardElement(kind string, text string) (model *CardElement, err error) {
-	model = &CardElement{
-		Kind: core.StringPtr(kind),
-		Text: core.StringPtr(text),
+// NewCardElement : Instantiate the CardElement of the given kind (Generic Model Constructor).
+// Unlike the former struct constructor it returns an error for an unknown kind. The other rules, e.g. a required
+// text, are checked by Validate.
+func (findingsApi *FindingsApiV1) NewCardElement(kind string, text string) (model CardElement, err error) {
+	switch kind {
+	case CardElement_Kind_Numeric:
+		model = &NumericCardElement{Kind: core.StringPtr(kind), Text: core.StringPtr(text)}
+	case CardElement_Kind_Breakdown:
+		model = &BreakdownCardElement{Kind: core.StringPtr(kind), Text: core.StringPtr(text)}
+	case CardElement_Kind_TimeSeries:
+		model = &TimeSeriesCardElement{Kind: core.StringPtr(kind), Text: core.StringPtr(text)}
+	default:
+		err = fmt.Errorf("unknown card element kind %q", kind)
 	}
-	err = core.ValidateStruct(model, "required parameters")
 	return
 }
 
@@ -1086,8 +1171,14 @@ func (findingsApi *FindingsApiV1) NewCardElement(kind string, text string) (mode
 // - LOW&#58; Low Certainty
 // - MEDIUM&#58; Medium Certainty
 // - HIGH&#58; High Certainty.
-type Certainty struct {
-}
+type Certainty string
+
+// Constants associated with the Certainty type.
+const (
+	Certainty_Low    Certainty = "LOW"
+	Certainty_Medium Certainty = "MEDIUM"
+	Certainty_High   Certainty = "HIGH"
+)
 
 // Context : Context struct
 type Context struct {
@@ -2183,8 +2274,15 @@ func (findingsApi *FindingsApiV1) NewSection(title string, image string) (model
 // - MEDIUM&#58; Medium Impact
 // - HIGH&#58; High Impact.
 // - CRITICAL&#58; Critical Impact.
-type Severity struct {
-}
+type Severity string
+
+// Constants associated with the Severity type.
+const (
+	Severity_Low      Severity = "LOW"
+	Severity_Medium   Severity = "MEDIUM"
+	Severity_High     Severity = "HIGH"
+	Severity_Critical Severity = "CRITICAL"
+)
 
 // SocketAddress : It provides details about a socket address.
 type SocketAddress struct {
@@ -2657,8 +2755,16 @@ func (findingsApi *FindingsApiV1) NewApiNote(shortDescription string, longDescri
 //  - CARD&#58; The note represents a card showing findings and related metric values.
 //  - CARD_CONFIGURED&#58; The note represents a card configured for a user account.
 //  - SECTION&#58; The note represents a section in a dashboard.
-type ApiNoteKind struct {
-}
+type ApiNoteKind string
+
+// Constants associated with the ApiNoteKind type.
+const (
+	ApiNoteKind_Finding        ApiNoteKind = "FINDING"
+	ApiNoteKind_Kpi            ApiNoteKind = "KPI"
+	ApiNoteKind_Card           ApiNoteKind = "CARD"
+	ApiNoteKind_CardConfigured ApiNoteKind = "CARD_CONFIGURED"
+	ApiNoteKind_Section        ApiNoteKind = "SECTION"
+)
 
 // ApiNoteRelatedURL : Metadata for any related URL information.
 type ApiNoteRelatedURL struct {
@@ -2737,8 +2843,11 @@ type BreakdownCardElement struct {
 	// The text of this card element.
 	Text *string `json:"text" validate:"required"`
 
-	// the value types associated to this card element.
-	ValueTypes []ValueType `json:"value_types" validate:"required"`
+	// the value types associated to this card element. Each of them needs a text.
+	ValueTypes []CardValueType `json:"value_types" validate:"required"`
+
+	// The default time range of this card element.
+	DefaultTimeRange *string `json:"default_time_range,omitempty"`
 }
 
 // Constants associated with the BreakdownCardElement.Kind property.
@@ -2753,7 +2862,7 @@ const (
 )
 
 // NewBreakdownCardElement : Instantiate BreakdownCardElement (Generic Model Constructor)
-func (findingsApi *FindingsApiV1) NewBreakdownCardElement(kind string, text string, valueTypes []ValueType) (model *BreakdownCardElement, err error) {
+func (findingsApi *FindingsApiV1) NewBreakdownCardElement(kind string, text string, valueTypes []CardValueType) (model *BreakdownCardElement, err error) {
 	model = &BreakdownCardElement{
 		Kind:       core.StringPtr(kind),
 		Text:       core.StringPtr(text),
@@ -2771,7 +2880,11 @@ type NumericCardElement struct {
 	// The text of this card element.
 	Text *string `json:"text" validate:"required"`
 
-	ValueType interface{} `json:"value_type" validate:"required"`
+	// The value type associated to this card element.
+	ValueType *CardValueType `json:"value_type" validate:"required"`
+
+	// The default time range of this card element.
+	DefaultTimeRange *string `json:"default_time_range,omitempty"`
 }
 
 // Constants associated with the NumericCardElement.Kind property.
@@ -2786,7 +2899,7 @@ const (
 )
 
 // NewNumericCardElement : Instantiate NumericCardElement (Generic Model Constructor)
-func (findingsApi *FindingsApiV1) NewNumericCardElement(kind string, text string, valueType interface{}) (model *NumericCardElement, err error) {
+func (findingsApi *FindingsApiV1) NewNumericCardElement(kind string, text string, valueType *CardValueType) (model *NumericCardElement, err error) {
 	model = &NumericCardElement{
 		Kind:      core.StringPtr(kind),
 		Text:      core.StringPtr(text),
@@ -2808,6 +2921,9 @@ type TimeSeriesCardElement struct {
 	// The default interval of the time series.
 	DefaultInterval *string `json:"default_interval,omitempty"`
 
+	// The default time range of this card element.
+	DefaultTimeRange *string `json:"default_time_range,omitempty"`
+
 	// the value types associated to this card element.
 	ValueTypes []FindingCountValueType `json:"value_types" validate:"required"`
 }
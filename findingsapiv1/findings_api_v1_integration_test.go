@@ -828,8 +828,8 @@ func TestPostCard(t *testing.T) {
 		assert.Equal(t, *(result.ID), *(createNoteOptions.ID))
 		assert.Equal(t, *(result.ShortDescription), *(createNoteOptions.ShortDescription))
 		assert.Equal(t, *result.Card.Title, *createNoteOptions.Card.Title)
-		assert.Equal(t, *result.Card.Elements[0].Kind, *createNoteOptions.Card.Elements[0].Kind)
-		assert.Equal(t, *result.Card.Elements[1].Kind, *createNoteOptions.Card.Elements[1].Kind)
+		assert.Equal(t, result.Card.Elements[0].GetKind(), createNoteOptions.Card.Elements[0].GetKind())
+		assert.Equal(t, result.Card.Elements[1].GetKind(), createNoteOptions.Card.Elements[1].GetKind())
 
 		fmt.Println("Cleaning up note....")
 		deleteNoteHelper(t, createNoteOptions)
@@ -850,7 +850,7 @@ func TestPostCardUsingSetters(t *testing.T) {
 	cardElementKind := findingsapiv1.CardElement_Kind_Numeric
 	cardElementText := "text"
 	cardValueType := findingsapiv1.CardValueType{Kind: &cardValueKind, FindingNoteNames: []string{"providers/sdktest/notes/sdk_note_id1"}}
-	cardElement := []findingsapiv1.CardElement{{
+	cardElement := []findingsapiv1.CardElement{&findingsapiv1.NumericCardElement{
 		Kind:      &cardElementKind,
 		Text:      &cardElementText,
 		ValueType: &cardValueType, //ValueType required for kind NUMERIC
@@ -18,6 +18,7 @@ package findingsapiv1_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -138,7 +139,7 @@ var _ = Describe(`FindingsApiV1`, func() {
 				reporter, _ := testService.NewReporter("exampleString", "exampleString")
 				kpi, _ := testService.NewKpiType("SUM")
 				section, _ := testService.NewSection("test", "test")
-				card, _ := testService.NewCard("exampleString", "exampleString", "exampleString", []string{"exampleString"}, []findingsapiv1.CardElement{{Kind: core.StringPtr("exampleString"), Text: core.StringPtr("exampleString")}})
+				card, _ := testService.NewCard("exampleString", "exampleString", "exampleString", []string{"exampleString"}, []findingsapiv1.CardElement{&findingsapiv1.NumericCardElement{Kind: core.StringPtr("NUMERIC"), Text: core.StringPtr("exampleString")}})
 				createNoteOptions.SetKpi(kpi)
 				createNoteOptions.SetCard(card)
 				createNoteOptions.SetSection(section)
@@ -339,7 +340,7 @@ var _ = Describe(`FindingsApiV1`, func() {
 				reporter, _ := testService.NewReporter("exampleString", "exampleString")
 				kpi, _ := testService.NewKpiType("SUM")
 				section, _ := testService.NewSection("test", "test")
-				card, _ := testService.NewCard("exampleString", "exampleString", "exampleString", []string{"exampleString"}, []findingsapiv1.CardElement{{Kind: core.StringPtr("exampleString"), Text: core.StringPtr("exampleString")}})
+				card, _ := testService.NewCard("exampleString", "exampleString", "exampleString", []string{"exampleString"}, []findingsapiv1.CardElement{&findingsapiv1.NumericCardElement{Kind: core.StringPtr("NUMERIC"), Text: core.StringPtr("exampleString")}})
 				updateNoteOptions.SetKpi(kpi)
 				updateNoteOptions.SetNoteID("note")
 				updateNoteOptions.SetCard(card)
@@ -722,6 +723,13 @@ var _ = Describe(`FindingsApiV1`, func() {
 				Expect(operationErr).To(BeNil())
 				Expect(response).ToNot(BeNil())
 				Expect(result).ToNot(BeNil())
+
+				// Abort the request with the context
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				result, response, operationErr = testService.GetOccurrenceWithContext(ctx, getOccurrenceOptions)
+				Expect(operationErr).NotTo(BeNil())
+				Expect(result).To(BeNil())
 			})
 		})
 	})
@@ -928,12 +936,18 @@ var _ = Describe(`FindingsApiV1`, func() {
 				Expect(err).To(BeNil())
 			})
 			It("should call NewCardElement successfully", func() {
-				kind := "exampleString"
+				kind := "TIME_SERIES"
 				text := "exampleString"
 				model, err := testService.NewCardElement(kind, text)
 				Expect(model).ToNot(BeNil())
+				Expect(model.GetKind()).To(Equal(kind))
 				Expect(err).To(BeNil())
 			})
+			It("should fail to call NewCardElement with an unknown kind", func() {
+				model, err := testService.NewCardElement("exampleString", "exampleString")
+				Expect(model).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
 			It("should call NewFindingCountValueType successfully", func() {
 				kind := "exampleString"
 				findingNoteNames := []string{}
@@ -1008,7 +1022,7 @@ var _ = Describe(`FindingsApiV1`, func() {
 			It("should call NewBreakdownCardElement successfully", func() {
 				kind := "exampleString"
 				text := "exampleString"
-				valueTypes := []findingsapiv1.ValueType{}
+				valueTypes := []findingsapiv1.CardValueType{}
 				model, err := testService.NewBreakdownCardElement(kind, text, valueTypes)
 				Expect(model).ToNot(BeNil())
 				Expect(err).To(BeNil())
@@ -1016,7 +1030,7 @@ var _ = Describe(`FindingsApiV1`, func() {
 			It("should call NewNumericCardElement successfully", func() {
 				kind := "exampleString"
 				text := "exampleString"
-				valueType := make(map[string]interface{})
+				valueType := &findingsapiv1.CardValueType{Kind: core.StringPtr("KPI"), KpiNoteName: core.StringPtr("exampleString")}
 				model, err := testService.NewNumericCardElement(kind, text, valueType)
 				Expect(model).ToNot(BeNil())
 				Expect(err).To(BeNil())
@@ -0,0 +1,51 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// noteNamePattern matches "providers/{provider_id}/notes/{note_id}" with an optional "{account_id}/" prefix.
+var noteNamePattern = regexp.MustCompile(`^(?:([^/\s]+)/)?providers/([^/\s]+)/notes/([^/\s]+)$`)
+
+// ParseNoteName : Splits a note name of the form "{account_id}/providers/{provider_id}/notes/{note_id}" or
+// "providers/{provider_id}/notes/{note_id}" into its parts. The account ID is empty for the second form.
+func ParseNoteName(noteName string) (accountID string, providerID string, noteID string, err error) {
+	parts := noteNamePattern.FindStringSubmatch(noteName)
+	if parts == nil {
+		err = fmt.Errorf("note name %q is not of the form [{account_id}/]providers/{provider_id}/notes/{note_id}", noteName)
+		return
+	}
+	return parts[1], parts[2], parts[3], nil
+}
+
+// FormatNoteName : Builds the name of a note, omitting the account ID when it is empty
+func FormatNoteName(accountID string, providerID string, noteID string) string {
+	noteName := fmt.Sprintf("providers/%s/notes/%s", providerID, noteID)
+	if accountID != "" {
+		noteName = accountID + "/" + noteName
+	}
+	return noteName
+}
+
+func (v *validator) noteName(field string, noteName string) {
+	if _, _, _, err := ParseNoteName(noteName); err != nil {
+		v.addf(field, "must be of the form [{account_id}/]providers/{provider_id}/notes/{note_id}, got %q", noteName)
+	}
+}
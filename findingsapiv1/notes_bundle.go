@@ -0,0 +1,431 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	common "github.com/ibm-cloud-security/security-advisor-sdk-go/common"
+)
+
+// notesPageSize is the number of notes listed per request while planning.
+const notesPageSize = 100
+
+// Constants associated with the NoteChange.Action property.
+// - CREATE&#58; The note is declared but doesn't exist.
+// - UPDATE&#58; The note exists and differs from its declaration.
+// - DELETE&#58; The note exists but isn't declared. Only planned when pruning.
+// - UNCHANGED&#58; The note exists as declared.
+const (
+	NoteChange_Action_Create    = "CREATE"
+	NoteChange_Action_Update    = "UPDATE"
+	NoteChange_Action_Delete    = "DELETE"
+	NoteChange_Action_Unchanged = "UNCHANGED"
+)
+
+// NotesBundle : The notes of a provider, declared in a YAML or JSON file such as
+//
+//	provider_id: my-tool
+//	reported_by: {id: my-tool, title: My tool}
+//	notes:
+//	  - id: my-section
+//	    kind: SECTION
+//	    short_description: My Security Tools
+//	    long_description: Findings of my security tools
+//	    section: {title: My Security Tools, image: my-tools.png}
+//	  - id: vulnerability
+//	    kind: FINDING
+//	    ...
+//
+// The notes have the format of ApiNote.
+type NotesBundle struct {
+
+	// The provider the notes belong to.
+	ProviderID string `json:"provider_id"`
+
+	// The reporter of the notes that don't declare one.
+	ReportedBy *Reporter `json:"reported_by,omitempty"`
+
+	// The notes of the provider.
+	Notes []ApiNote `json:"notes"`
+}
+
+// NotesPlanOptions : The PlanNotes options.
+type NotesPlanOptions struct {
+
+	// Delete the notes of the provider that aren't in the bundle.
+	Prune bool
+}
+
+// NoteChange : A change to a note computed by PlanNotes
+type NoteChange struct {
+
+	// The change, one of the NoteChange_Action constants.
+	Action string
+
+	// The ID of the note.
+	NoteID string
+
+	// The kind of the note.
+	Kind string
+
+	// The existing note, nil for creations.
+	Current *ApiNote
+
+	// The declared note, nil for deletions.
+	Desired *ApiNote
+
+	// The fields that differ for updates.
+	Diff []string
+}
+
+// NotesPlan : The changes computed by PlanNotes, in the order ApplyNotes performs them: creations and updates of
+// sections, then of findings and KPIs, then of cards, followed by deletions in the reverse order
+type NotesPlan struct {
+
+	// The provider the notes belong to.
+	ProviderID string
+
+	// The changes.
+	Changes []NoteChange
+}
+
+// LoadNotesBundle : Reads a bundle from a YAML or JSON file
+func LoadNotesBundle(path string) (bundle *NotesBundle, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	bundle, err = ParseNotesBundle(data)
+	if err != nil {
+		err = fmt.Errorf("%s: %s", filepath.Base(path), err.Error())
+	}
+	return
+}
+
+// ParseNotesBundle : Parses a YAML or JSON bundle
+func ParseNotesBundle(data []byte) (bundle *NotesBundle, err error) {
+	jsonData, err := common.YAMLToJSON(data)
+	if err != nil {
+		return
+	}
+	bundle = new(NotesBundle)
+	if err = json.Unmarshal(jsonData, bundle); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Validate : Checks each note of the bundle with CreateNoteOptions.Validate, and that note IDs are unique
+func (bundle *NotesBundle) Validate() error {
+	v := &validator{}
+	v.required("provider_id", &bundle.ProviderID)
+	seen := make(map[string]bool, len(bundle.Notes))
+	for i := range bundle.Notes {
+		field := fmt.Sprintf("notes[%d]", i)
+		note := bundle.note(i)
+		noteID := stringValue(note.ID)
+		if noteID != "" && seen[noteID] {
+			v.addf(joinField(field, "id"), "duplicates the ID %q", noteID)
+		}
+		seen[noteID] = true
+
+		err := bundle.createNoteOptions("account", note).Validate()
+		if errs, ok := err.(ValidationErrors); ok {
+			for _, noteErr := range errs {
+				v.addf(joinField(field, noteErr.Field), "%s", noteErr.Message)
+			}
+		}
+	}
+	return v.result()
+}
+
+// note returns the i-th note, with the reporter of the bundle when it declares none.
+func (bundle *NotesBundle) note(i int) *ApiNote {
+	note := bundle.Notes[i]
+	if note.ReportedBy == nil {
+		note.ReportedBy = bundle.ReportedBy
+	}
+	return &note
+}
+
+func (bundle *NotesBundle) createNoteOptions(accountID string, note *ApiNote) *CreateNoteOptions {
+	return &CreateNoteOptions{
+		AccountID:        core.StringPtr(accountID),
+		ProviderID:       core.StringPtr(bundle.ProviderID),
+		ShortDescription: note.ShortDescription,
+		LongDescription:  note.LongDescription,
+		Kind:             note.Kind,
+		ID:               note.ID,
+		ReportedBy:       note.ReportedBy,
+		RelatedURL:       note.RelatedURL,
+		ExpirationTime:   note.ExpirationTime,
+		Shared:           note.Shared,
+		Finding:          note.Finding,
+		Kpi:              note.Kpi,
+		Card:             note.Card,
+		Section:          note.Section,
+	}
+}
+
+// PlanNotes : Validates the bundle and diffs it against the notes of its provider listed with ListNotes
+func (findingsApi *FindingsApiV1) PlanNotes(ctx context.Context, accountID string, bundle *NotesBundle, opts *NotesPlanOptions) (plan *NotesPlan, err error) {
+	err = core.ValidateNotNil(bundle, "bundle cannot be nil")
+	if err != nil {
+		return
+	}
+	if err = bundle.Validate(); err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &NotesPlanOptions{}
+	}
+
+	current, err := findingsApi.listNotes(ctx, accountID, bundle.ProviderID)
+	if err != nil {
+		return
+	}
+	existing := make(map[string]*ApiNote, len(current))
+	for i := range current {
+		existing[stringValue(current[i].ID)] = &current[i]
+	}
+
+	plan = &NotesPlan{ProviderID: bundle.ProviderID}
+	declared := make(map[string]bool, len(bundle.Notes))
+	for i := range bundle.Notes {
+		desired := bundle.note(i)
+		noteID := stringValue(desired.ID)
+		declared[noteID] = true
+		change := NoteChange{NoteID: noteID, Kind: stringValue(desired.Kind), Current: existing[noteID], Desired: desired}
+		switch {
+		case change.Current == nil:
+			change.Action = NoteChange_Action_Create
+		default:
+			change.Diff = diffNote(change.Current, desired)
+			change.Action = NoteChange_Action_Unchanged
+			if len(change.Diff) > 0 {
+				change.Action = NoteChange_Action_Update
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return noteKindRank(plan.Changes[i].Kind) < noteKindRank(plan.Changes[j].Kind)
+	})
+
+	if opts.Prune {
+		var deletions []NoteChange
+		for i := range current {
+			noteID := stringValue(current[i].ID)
+			if !declared[noteID] {
+				deletions = append(deletions, NoteChange{Action: NoteChange_Action_Delete, NoteID: noteID, Kind: stringValue(current[i].Kind), Current: &current[i]})
+			}
+		}
+		sort.SliceStable(deletions, func(i, j int) bool {
+			return noteKindRank(deletions[i].Kind) > noteKindRank(deletions[j].Kind)
+		})
+		plan.Changes = append(plan.Changes, deletions...)
+	}
+	return
+}
+
+// ApplyNotes : Performs the changes of the plan in order, stopping at the first failure
+func (findingsApi *FindingsApiV1) ApplyNotes(ctx context.Context, accountID string, plan *NotesPlan) (err error) {
+	err = core.ValidateNotNil(plan, "plan cannot be nil")
+	if err != nil {
+		return
+	}
+	bundle := &NotesBundle{ProviderID: plan.ProviderID}
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case NoteChange_Action_Create:
+			_, _, err = findingsApi.CreateNoteWithContext(ctx, bundle.createNoteOptions(accountID, change.Desired))
+		case NoteChange_Action_Update:
+			options := bundle.createNoteOptions(accountID, change.Desired)
+			_, _, err = findingsApi.UpdateNoteWithContext(ctx, &UpdateNoteOptions{
+				AccountID:        options.AccountID,
+				ProviderID:       options.ProviderID,
+				NoteID:           options.ID,
+				ShortDescription: options.ShortDescription,
+				LongDescription:  options.LongDescription,
+				Kind:             options.Kind,
+				ID:               options.ID,
+				ReportedBy:       options.ReportedBy,
+				RelatedURL:       options.RelatedURL,
+				ExpirationTime:   options.ExpirationTime,
+				Shared:           options.Shared,
+				Finding:          options.Finding,
+				Kpi:              options.Kpi,
+				Card:             options.Card,
+				Section:          options.Section,
+			})
+		case NoteChange_Action_Delete:
+			_, err = findingsApi.DeleteNoteWithContext(ctx, findingsApi.NewDeleteNoteOptions(accountID, plan.ProviderID, change.NoteID))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s note %s: %s", change.Action, change.NoteID, err.Error())
+		}
+	}
+	return
+}
+
+// HasChanges : Reports whether applying the plan changes any note
+func (plan *NotesPlan) HasChanges() bool {
+	for _, change := range plan.Changes {
+		if change.Action != NoteChange_Action_Unchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteDiff : Prints the plan, one line per changed note followed by the changed fields of updates
+func (plan *NotesPlan) WriteDiff(w io.Writer) error {
+	for _, change := range plan.Changes {
+		var line string
+		switch change.Action {
+		case NoteChange_Action_Create:
+			line = fmt.Sprintf("+ %s note %q\n", change.Kind, change.NoteID)
+		case NoteChange_Action_Update:
+			line = fmt.Sprintf("~ %s note %q\n", change.Kind, change.NoteID)
+			for _, field := range change.Diff {
+				line += "    " + field + "\n"
+			}
+		case NoteChange_Action_Delete:
+			line = fmt.Sprintf("- %s note %q\n", change.Kind, change.NoteID)
+		default:
+			continue
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listNotes lists all the notes of the provider, page by page.
+func (findingsApi *FindingsApiV1) listNotes(ctx context.Context, accountID string, providerID string) (notes []ApiNote, err error) {
+	options := &ListNotesOptions{
+		AccountID:  core.StringPtr(accountID),
+		ProviderID: core.StringPtr(providerID),
+		PageSize:   core.Int64Ptr(notesPageSize),
+	}
+	for {
+		result, _, listErr := findingsApi.ListNotesWithContext(ctx, options)
+		if listErr != nil {
+			return nil, fmt.Errorf("failed to list the notes of provider %s: %s", providerID, listErr.Error())
+		}
+		notes = append(notes, result.Notes...)
+		if stringValue(result.NextPageToken) == "" {
+			return
+		}
+		options.PageToken = result.NextPageToken
+	}
+}
+
+// noteKindRank orders the kinds by dependency: cards reference findings and KPIs, which are shown in sections.
+func noteKindRank(kind string) int {
+	switch ApiNoteKind(kind) {
+	case ApiNoteKind_Section:
+		return 0
+	case ApiNoteKind_Finding, ApiNoteKind_Kpi:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// diffNote lists the fields of the declared note that differ from the existing one, as dotted paths. Output only
+// fields, and fields the bundle leaves unset at any depth, are ignored: the service fills in defaults such as nested
+// URLs or flags, and a note is only updated when a declared value changed.
+func diffNote(current *ApiNote, desired *ApiNote) (diff []string) {
+	currentFields, err := noteFields(current)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	desiredFields, err := noteFields(desired)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	diffJSON("", currentFields, desiredFields, &diff)
+	return
+}
+
+// diffJSON compares the values declared in desired with current, recursing into objects and into lists of the same
+// length.
+func diffJSON(path string, current interface{}, desired interface{}, diff *[]string) {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		if currentValue, ok := current.(map[string]interface{}); ok {
+			names := make([]string, 0, len(desiredValue))
+			for name := range desiredValue {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				diffJSON(joinField(path, name), currentValue[name], desiredValue[name], diff)
+			}
+			return
+		}
+	case []interface{}:
+		if currentValue, ok := current.([]interface{}); ok && len(currentValue) == len(desiredValue) {
+			for i := range desiredValue {
+				diffJSON(fmt.Sprintf("%s[%d]", path, i), currentValue[i], desiredValue[i], diff)
+			}
+			return
+		}
+	default:
+		if reflect.DeepEqual(current, desired) {
+			return
+		}
+	}
+	*diff = append(*diff, fmt.Sprintf("%s: %s -> %s", path, compactJSON(current), compactJSON(desired)))
+}
+
+func noteFields(note *ApiNote) (fields map[string]interface{}, err error) {
+	data, err := json.Marshal(note)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+	delete(fields, "create_time")
+	delete(fields, "update_time")
+	return
+}
+
+func compactJSON(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,152 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`NotesBundle`, func() {
+	notesPath := "/v1/account/providers/my-tool/notes"
+	bundleData := []byte(`
+provider_id: my-tool
+reported_by: {id: my-tool, title: My tool}
+notes:
+  - id: card
+    kind: CARD
+    short_description: My tool
+    long_description: Findings of my tool
+    card:
+      section: My Security Tools
+      title: My tool
+      subtitle: Findings of my tool
+      finding_note_names: [providers/my-tool/notes/vulnerability]
+      elements:
+        - kind: NUMERIC
+          text: Vulnerabilities
+          value_type: {kind: FINDING_COUNT, finding_note_names: [providers/my-tool/notes/vulnerability]}
+  - id: vulnerability
+    kind: FINDING
+    short_description: Vulnerability
+    long_description: A vulnerability was found
+    finding: {severity: HIGH}
+  - id: section
+    kind: SECTION
+    short_description: My Security Tools
+    long_description: Findings of my security tools
+    section: {title: My Security Tools, image: tools.png}
+`)
+	existing := `{"notes": [
+		{"id": "section", "kind": "SECTION", "short_description": "My Security Tools",
+		 "long_description": "Findings of my security tools", "shared": false, "create_time": "2020-01-01T00:00:00Z",
+		 "reported_by": {"id": "my-tool", "title": "My tool", "url": "https://example.com/my-tool"},
+		 "section": {"title": "My Security Tools", "image": "tools.png"}},
+		{"id": "vulnerability", "kind": "FINDING", "short_description": "Vulnerability",
+		 "long_description": "A vulnerability was found", "reported_by": {"id": "my-tool", "title": "My tool"},
+		 "finding": {"severity": "LOW", "next_steps": [{"title": "Upgrade"}]}},
+		{"id": "old-card", "kind": "CARD", "short_description": "Old", "long_description": "Old",
+		 "reported_by": {"id": "my-tool", "title": "My tool"}},
+		{"id": "old-kpi", "kind": "KPI", "short_description": "Old", "long_description": "Old",
+		 "reported_by": {"id": "my-tool", "title": "My tool"}, "kpi": {"aggregation_type": "SUM"}}
+	]}`
+
+	var requests []string
+	var testService *findingsapiv1.FindingsApiV1
+	var testServer *httptest.Server
+	BeforeEach(func() {
+		requests = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			requests = append(requests, req.Method+" "+strings.TrimPrefix(req.URL.Path, notesPath))
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			switch req.Method {
+			case "GET":
+				res.Write([]byte(existing))
+			case "DELETE":
+			default:
+				var body map[string]interface{}
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				res.Write([]byte(`{}`))
+			}
+		}))
+		var err error
+		testService, err = findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`plans changes in dependency order`, func() {
+		bundle, err := findingsapiv1.ParseNotesBundle(bundleData)
+		Expect(err).To(BeNil())
+
+		plan, err := testService.PlanNotes(context.Background(), "account", bundle, nil)
+		Expect(err).To(BeNil())
+		var out bytes.Buffer
+		Expect(plan.WriteDiff(&out)).To(Succeed())
+		Expect(out.String()).To(Equal(strings.Join([]string{
+			`~ FINDING note "vulnerability"`,
+			`    finding.severity: "LOW" -> "HIGH"`,
+			`+ CARD note "card"`,
+			``,
+		}, "\n")))
+		Expect(plan.Changes[0].Action).To(Equal(findingsapiv1.NoteChange_Action_Unchanged))
+
+		plan, err = testService.PlanNotes(context.Background(), "account", bundle, &findingsapiv1.NotesPlanOptions{Prune: true})
+		Expect(err).To(BeNil())
+		Expect(testService.ApplyNotes(context.Background(), "account", plan)).To(Succeed())
+		Expect(requests).To(Equal([]string{
+			"GET ", "GET ",
+			"PUT /vulnerability",
+			"POST ",
+			"DELETE /old-card",
+			"DELETE /old-kpi",
+		}))
+	})
+	It(`validates the notes of the bundle`, func() {
+		bundle, err := findingsapiv1.ParseNotesBundle([]byte(`
+provider_id: my-tool
+notes:
+  - {id: finding, kind: FINDING, short_description: s, long_description: l, finding: {severity: HIGH}}
+  - {id: finding, kind: KPI, short_description: s, long_description: l, reported_by: {id: i, title: t}}
+`))
+		Expect(err).To(BeNil())
+		_, err = testService.PlanNotes(context.Background(), "account", bundle, nil)
+		Expect(validationFields(err)).To(Equal([]string{
+			"notes[0].reported_by",
+			"notes[1].id",
+			"notes[1].kpi",
+		}))
+		Expect(requests).To(BeEmpty())
+	})
+})
@@ -0,0 +1,123 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// enumField is a *string field of a model holding an enum value.
+type enumField struct {
+	name  string
+	valid func(value string) bool
+}
+
+var (
+	severityField  = enumField{"severity", func(value string) bool { return Severity(value).IsValid() }}
+	certaintyField = enumField{"certainty", func(value string) bool { return Certainty(value).IsValid() }}
+	kindField      = enumField{"note kind", func(value string) bool { return ApiNoteKind(value).IsValid() }}
+)
+
+// enumFields lists the enum fields of the models, by Go field name.
+var enumFields = map[reflect.Type]map[string]enumField{
+	reflect.TypeOf(Finding{}):       {"Severity": severityField, "Certainty": certaintyField},
+	reflect.TypeOf(FindingType{}):   {"Severity": severityField},
+	reflect.TypeOf(ApiNote{}):       {"Kind": kindField},
+	reflect.TypeOf(ApiOccurrence{}): {"Kind": kindField},
+}
+
+// enumTypes maps the enum types to their field, for values typed as Severity, Certainty or ApiNoteKind.
+var enumTypes = map[reflect.Type]enumField{
+	reflect.TypeOf(Severity("")):    severityField,
+	reflect.TypeOf(Certainty("")):   certaintyField,
+	reflect.TypeOf(ApiNoteKind("")): kindField,
+}
+
+// DecodeStrict : Decodes the JSON data into value like json.Unmarshal, then rejects the unknown severities,
+// certainties and note kinds found in value, e.g. "Critical" instead of "CRITICAL". The models, such as ApiNote,
+// ApiOccurrence or a list of them, are checked at any depth. Returns ValidationErrors naming the field of each
+// unknown value.
+//
+// json.Unmarshal passes unknown values through unchanged, so that values added by the service later on don't break
+// existing clients; DecodeStrict is meant for input written by hand, such as notes bundles or test fixtures.
+func DecodeStrict(data []byte, value interface{}) error {
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+	v := &validator{}
+	v.enums("", reflect.ValueOf(value))
+	return v.result()
+}
+
+// enums checks the enum values of value, reporting them under field.
+func (v *validator) enums(field string, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			v.enums(field, value.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			v.enums(fmt.Sprintf("%s[%d]", field, i), value.Index(i))
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			v.enums(joinField(field, fmt.Sprint(iter.Key().Interface())), iter.Value())
+		}
+	case reflect.String:
+		if enum, ok := enumTypes[value.Type()]; ok && !enum.valid(value.String()) {
+			v.addf(field, "unknown %s %q", enum.name, value.String())
+		}
+	case reflect.Struct:
+		fields := enumFields[value.Type()]
+		for i := 0; i < value.NumField(); i++ {
+			structField := value.Type().Field(i)
+			if structField.PkgPath != "" {
+				continue
+			}
+			name := jsonName(structField)
+			if name == "-" {
+				continue
+			}
+			fieldValue := value.Field(i)
+			if enum, ok := fields[structField.Name]; ok {
+				if text, isString := fieldValue.Interface().(*string); isString && text != nil && !enum.valid(*text) {
+					v.addf(joinField(field, name), "unknown %s %q", enum.name, *text)
+				}
+				continue
+			}
+			if structField.Anonymous {
+				v.enums(field, fieldValue)
+				continue
+			}
+			v.enums(joinField(field, name), fieldValue)
+		}
+	}
+}
+
+// jsonName returns the name of the field in JSON documents.
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,291 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidationError : A client-side validation failure of a single field.
+type ValidationError struct {
+
+	// Path of the offending field, using the JSON field names, e.g. "finding.next_steps[0].url".
+	Field string
+
+	// Description of the violation.
+	Message string
+}
+
+// Error : Formats the violation as "field: message"
+func (validationError *ValidationError) Error() string {
+	return validationError.Field + ": " + validationError.Message
+}
+
+// ValidationErrors : Every violation found by a Validate call.
+type ValidationErrors []*ValidationError
+
+// Error : Joins all violations into a single message
+func (validationErrors ValidationErrors) Error() string {
+	messages := make([]string, len(validationErrors))
+	for i, validationError := range validationErrors {
+		messages[i] = validationError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Allowed values of the enumerated properties, in the order they are listed in violation messages.
+var (
+	allowedNoteKinds = []string{
+		string(ApiNoteKind_Finding),
+		string(ApiNoteKind_Kpi),
+		string(ApiNoteKind_Card),
+		string(ApiNoteKind_CardConfigured),
+		string(ApiNoteKind_Section),
+	}
+	allowedOccurrenceKinds = []string{string(ApiNoteKind_Finding), string(ApiNoteKind_Kpi)}
+	allowedSeverities      = []string{string(Severity_Low), string(Severity_Medium), string(Severity_High), string(Severity_Critical)}
+	allowedCertainties     = []string{string(Certainty_Low), string(Certainty_Medium), string(Certainty_High)}
+)
+
+// Validate : Checks the options against the rules of their note kind.
+// In addition to the fields required by every note, a FINDING note needs `finding`, a KPI note needs `kpi`,
+// a CARD or CARD_CONFIGURED note needs `card` and a SECTION note needs `section`. Severities and URLs are
+// checked as well. The returned error is a ValidationErrors listing every violation, or nil.
+func (options *CreateNoteOptions) Validate() error {
+	v := &validator{}
+	v.required("account_id", options.AccountID)
+	v.required("provider_id", options.ProviderID)
+	v.required("id", options.ID)
+	v.note(options.ShortDescription, options.LongDescription, options.Kind, options.ReportedBy, options.RelatedURL,
+		options.Finding, options.Kpi, options.Card, options.Section)
+	return v.result()
+}
+
+// Validate : Checks the options against the rules of their note kind.
+// See CreateNoteOptions.Validate for the rules.
+func (options *UpdateNoteOptions) Validate() error {
+	v := &validator{}
+	v.required("account_id", options.AccountID)
+	v.required("provider_id", options.ProviderID)
+	v.required("note_id", options.NoteID)
+	v.required("id", options.ID)
+	v.note(options.ShortDescription, options.LongDescription, options.Kind, options.ReportedBy, options.RelatedURL,
+		options.Finding, options.Kpi, options.Card, options.Section)
+	return v.result()
+}
+
+// Validate : Checks the options against the rules of their occurrence kind.
+// A FINDING occurrence needs `finding` and a KPI occurrence needs `kpi` with a value. Severity, certainty and
+// URLs are checked as well. The returned error is a ValidationErrors listing every violation, or nil.
+func (options *CreateOccurrenceOptions) Validate() error {
+	v := &validator{}
+	v.required("account_id", options.AccountID)
+	v.required("provider_id", options.ProviderID)
+	v.required("id", options.ID)
+	v.occurrence(options.NoteName, options.Kind, options.ResourceURL, options.Finding, options.Kpi)
+	return v.result()
+}
+
+// Validate : Checks the options against the rules of their occurrence kind.
+// See CreateOccurrenceOptions.Validate for the rules.
+func (options *UpdateOccurrenceOptions) Validate() error {
+	v := &validator{}
+	v.required("account_id", options.AccountID)
+	v.required("provider_id", options.ProviderID)
+	v.required("occurrence_id", options.OccurrenceID)
+	v.required("id", options.ID)
+	v.occurrence(options.NoteName, options.Kind, options.ResourceURL, options.Finding, options.Kpi)
+	return v.result()
+}
+
+// validator collects violations so that a single Validate call reports all of them.
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) addf(field string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) required(field string, value *string) bool {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		v.addf(field, "is required")
+		return false
+	}
+	return true
+}
+
+func (v *validator) oneOf(field string, value *string, allowed []string) {
+	if value == nil {
+		return
+	}
+	for _, candidate := range allowed {
+		if *value == candidate {
+			return
+		}
+	}
+	v.addf(field, "must be one of %s, got %q", strings.Join(allowed, ", "), *value)
+}
+
+func (v *validator) url(field string, value *string) {
+	if value == nil {
+		return
+	}
+	parsed, err := url.Parse(*value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		v.addf(field, "must be an absolute http or https URL, got %q", *value)
+	}
+}
+
+func (v *validator) absent(field string, present bool, kind string) {
+	if present {
+		v.addf(field, "must not be set for kind %s", kind)
+	}
+}
+
+func (v *validator) note(shortDescription *string, longDescription *string, kind *string, reportedBy *Reporter,
+	relatedURL []ApiNoteRelatedURL, finding *FindingType, kpi *KpiType, card *Card, section *Section) {
+	v.required("short_description", shortDescription)
+	v.required("long_description", longDescription)
+	if reportedBy == nil {
+		v.addf("reported_by", "is required")
+	} else {
+		v.required("reported_by.id", reportedBy.ID)
+		v.required("reported_by.title", reportedBy.Title)
+		v.url("reported_by.url", reportedBy.URL)
+	}
+	for i, related := range relatedURL {
+		v.url(fmt.Sprintf("related_url[%d].url", i), related.URL)
+	}
+
+	if !v.required("kind", kind) {
+		return
+	}
+	v.oneOf("kind", kind, allowedNoteKinds)
+	switch ApiNoteKind(*kind) {
+	case ApiNoteKind_Finding:
+		v.findingType("finding", finding)
+		v.absent("kpi", kpi != nil, *kind)
+		v.absent("card", card != nil, *kind)
+		v.absent("section", section != nil, *kind)
+	case ApiNoteKind_Kpi:
+		if kpi == nil {
+			v.addf("kpi", "is required for kind KPI")
+		} else if v.required("kpi.aggregation_type", kpi.AggregationType) {
+			v.oneOf("kpi.aggregation_type", kpi.AggregationType, []string{KpiType_AggregationType_Sum})
+		}
+		v.absent("finding", finding != nil, *kind)
+		v.absent("card", card != nil, *kind)
+		v.absent("section", section != nil, *kind)
+	case ApiNoteKind_Card, ApiNoteKind_CardConfigured:
+		v.card("card", card, *kind)
+		v.absent("finding", finding != nil, *kind)
+		v.absent("kpi", kpi != nil, *kind)
+		v.absent("section", section != nil, *kind)
+	case ApiNoteKind_Section:
+		if section == nil {
+			v.addf("section", "is required for kind SECTION")
+		} else {
+			v.required("section.title", section.Title)
+			v.required("section.image", section.Image)
+		}
+		v.absent("finding", finding != nil, *kind)
+		v.absent("kpi", kpi != nil, *kind)
+		v.absent("card", card != nil, *kind)
+	}
+}
+
+func (v *validator) findingType(field string, finding *FindingType) {
+	if finding == nil {
+		v.addf(field, "is required for kind FINDING")
+		return
+	}
+	if v.required(field+".severity", finding.Severity) {
+		v.oneOf(field+".severity", finding.Severity, allowedSeverities)
+	}
+	v.nextSteps(field+".next_steps", finding.NextSteps)
+}
+
+func (v *validator) nextSteps(field string, nextSteps []RemediationStep) {
+	for i, step := range nextSteps {
+		v.url(fmt.Sprintf("%s[%d].url", field, i), step.URL)
+	}
+}
+
+func (v *validator) card(field string, card *Card, kind string) {
+	if card == nil {
+		v.addf(field, "is required for kind %s", kind)
+		return
+	}
+	v.required(field+".section", card.Section)
+	v.required(field+".title", card.Title)
+	v.required(field+".subtitle", card.Subtitle)
+	if card.FindingNoteNames == nil {
+		v.addf(field+".finding_note_names", "is required")
+	}
+	for i, noteName := range card.FindingNoteNames {
+		v.noteName(fmt.Sprintf("%s.finding_note_names[%d]", field, i), noteName)
+	}
+	if len(card.Elements) == 0 {
+		v.addf(field+".elements", "must contain at least one element")
+	}
+	for i, element := range card.Elements {
+		elementField := fmt.Sprintf("%s.elements[%d]", field, i)
+		if element == nil {
+			v.addf(elementField, "is required")
+			continue
+		}
+		element.validate(v, elementField)
+	}
+}
+
+func (v *validator) occurrence(noteName *string, kind *string, resourceURL *string, finding *Finding, kpi *Kpi) {
+	v.required("note_name", noteName)
+	v.url("resource_url", resourceURL)
+
+	if !v.required("kind", kind) {
+		return
+	}
+	v.oneOf("kind", kind, allowedOccurrenceKinds)
+	switch ApiNoteKind(*kind) {
+	case ApiNoteKind_Finding:
+		if finding == nil {
+			v.addf("finding", "is required for kind FINDING")
+		} else {
+			v.oneOf("finding.severity", finding.Severity, allowedSeverities)
+			v.oneOf("finding.certainty", finding.Certainty, allowedCertainties)
+			v.nextSteps("finding.next_steps", finding.NextSteps)
+		}
+		v.absent("kpi", kpi != nil, *kind)
+	case ApiNoteKind_Kpi:
+		if kpi == nil {
+			v.addf("kpi", "is required for kind KPI")
+		} else if kpi.Value == nil {
+			v.addf("kpi.value", "is required")
+		}
+		v.absent("finding", finding != nil, *kind)
+	}
+}
@@ -0,0 +1,118 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package findingsapiv1_test
+
+import (
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func validationFields(err error) []string {
+	validationErrors, ok := err.(findingsapiv1.ValidationErrors)
+	Expect(ok).To(BeTrue())
+	fields := []string{}
+	for _, validationError := range validationErrors {
+		fields = append(fields, validationError.Field)
+	}
+	return fields
+}
+
+var _ = Describe(`Validate`, func() {
+	testService, _ := findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+		URL:           "http://findingsapiv1modelgenerator.com",
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	reportedBy := &findingsapiv1.Reporter{ID: core.StringPtr("id"), Title: core.StringPtr("title")}
+
+	Describe(`CreateNoteOptions`, func() {
+		It(`accepts a complete FINDING note`, func() {
+			options := testService.NewCreateNoteOptions("account", "provider", "short", "long", "FINDING", "note", reportedBy)
+			options.SetFinding(&findingsapiv1.FindingType{
+				Severity:  core.StringPtr("HIGH"),
+				NextSteps: []findingsapiv1.RemediationStep{{Title: core.StringPtr("fix"), URL: core.StringPtr("https://fix.me")}},
+			})
+			options.SetRelatedURL([]findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr("docs"), URL: core.StringPtr("https://docs")}})
+			Expect(options.Validate()).To(BeNil())
+		})
+		It(`reports a FINDING note without finding`, func() {
+			options := testService.NewCreateNoteOptions("account", "provider", "short", "long", "FINDING", "note", reportedBy)
+			Expect(validationFields(options.Validate())).To(Equal([]string{"finding"}))
+		})
+		It(`reports every violation with its field path`, func() {
+			options := testService.NewCreateNoteOptions("account", "", "short", "long", "FINDING", "note", reportedBy)
+			options.SetFinding(&findingsapiv1.FindingType{
+				Severity:  core.StringPtr("Critical"),
+				NextSteps: []findingsapiv1.RemediationStep{{URL: core.StringPtr("string")}},
+			})
+			options.SetKpi(&findingsapiv1.KpiType{AggregationType: core.StringPtr("SUM")})
+			err := options.Validate()
+			Expect(validationFields(err)).To(Equal([]string{"provider_id", "finding.severity", "finding.next_steps[0].url", "kpi"}))
+			Expect(err.Error()).To(ContainSubstring(`finding.severity: must be one of LOW, MEDIUM, HIGH, CRITICAL, got "Critical"`))
+		})
+		It(`reports a CARD note without card and an unknown kind`, func() {
+			options := testService.NewCreateNoteOptions("account", "provider", "short", "long", "CARD", "note", reportedBy)
+			Expect(validationFields(options.Validate())).To(Equal([]string{"card"}))
+			options.SetKind(core.StringPtr("ALERT"))
+			Expect(validationFields(options.Validate())).To(Equal([]string{"kind"}))
+		})
+		It(`reports incomplete card elements`, func() {
+			options := testService.NewCreateNoteOptions("account", "provider", "short", "long", "CARD", "note", reportedBy)
+			options.SetCard(&findingsapiv1.Card{
+				Section:          core.StringPtr("section"),
+				Title:            core.StringPtr("title"),
+				Subtitle:         core.StringPtr("subtitle"),
+				FindingNoteNames: []string{},
+				Elements:         []findingsapiv1.CardElement{&findingsapiv1.NumericCardElement{Kind: core.StringPtr("PIE")}},
+			})
+			Expect(validationFields(options.Validate())).To(Equal([]string{"card.elements[0].kind", "card.elements[0].text", "card.elements[0].value_type"}))
+		})
+	})
+	Describe(`UpdateNoteOptions`, func() {
+		It(`reports a KPI note without kpi and a SECTION note without section`, func() {
+			options := testService.NewUpdateNoteOptions("account", "provider", "note", "short", "long", "KPI", "note", reportedBy)
+			Expect(validationFields(options.Validate())).To(Equal([]string{"kpi"}))
+			options.SetKind("SECTION")
+			Expect(validationFields(options.Validate())).To(Equal([]string{"section"}))
+			options.SetSection(&findingsapiv1.Section{Title: core.StringPtr("title"), Image: core.StringPtr("image")})
+			Expect(options.Validate()).To(BeNil())
+		})
+	})
+	Describe(`CreateOccurrenceOptions`, func() {
+		It(`reports a KPI occurrence without kpi`, func() {
+			options := testService.NewCreateOccurrenceOptions("account", "provider", "providers/provider/notes/kpi", "KPI", "occ")
+			Expect(validationFields(options.Validate())).To(Equal([]string{"kpi"}))
+			options.SetKpi(&findingsapiv1.Kpi{Value: core.Float64Ptr(1)})
+			Expect(options.Validate()).To(BeNil())
+		})
+		It(`checks severity, certainty and resource URL`, func() {
+			options := testService.NewCreateOccurrenceOptions("account", "provider", "providers/provider/notes/finding", "FINDING", "occ")
+			options.SetResourceURL("gcr.io/provider/image")
+			options.SetFinding(&findingsapiv1.Finding{Severity: core.StringPtr("SEVERE"), Certainty: core.StringPtr("CRITICAL")})
+			Expect(validationFields(options.Validate())).To(Equal([]string{"resource_url", "finding.severity", "finding.certainty"}))
+		})
+	})
+	Describe(`UpdateOccurrenceOptions`, func() {
+		It(`reports a FINDING occurrence without finding`, func() {
+			options := testService.NewUpdateOccurrenceOptions("account", "provider", "occ", "providers/provider/notes/finding", "FINDING", "occ")
+			Expect(validationFields(options.Validate())).To(Equal([]string{"finding"}))
+			options.SetFinding(&findingsapiv1.Finding{Severity: core.StringPtr("LOW")})
+			Expect(options.Validate()).To(BeNil())
+		})
+	})
+})
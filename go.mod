@@ -1,11 +1,14 @@
 module github.com/ibm-cloud-security/security-advisor-sdk-go
+
 go 1.14
 
 require (
 	github.com/IBM/go-sdk-core/v3 v3.3.1
 	github.com/go-openapi/strfmt v0.19.4
+	github.com/golang-jwt/jwt/v4 v4.5.2
 	github.com/joho/godotenv v1.3.0
 	github.com/onsi/ginkgo v1.12.1
 	github.com/onsi/gomega v1.10.0
 	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.2.4
 )
@@ -0,0 +1,156 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package checkov converts the JSON reports of checkov, i.e. of "checkov -o json", to notes and occurrences of the
+// misconfigurations of infrastructure as code, e.g. Terraform resources or Kubernetes objects. Each check becomes a
+// FINDING note, and each failed check of a resource an occurrence of it.
+package checkov
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// Constants associated with the Report.CheckType property, the frameworks whose resource addresses have a type.
+// - terraform&#58; Terraform resources, e.g. aws_s3_bucket.logs.
+// - terraform_plan&#58; The resources of a Terraform plan.
+// - kubernetes&#58; Kubernetes objects, e.g. Deployment.default.my-app.
+// - helm&#58; The Kubernetes objects of a Helm chart.
+// - kustomize&#58; The Kubernetes objects of a Kustomize overlay.
+// - cloudformation&#58; CloudFormation resources, e.g. AWS::S3::Bucket.Logs.
+const (
+	CheckType_Terraform      = "terraform"
+	CheckType_TerraformPlan  = "terraform_plan"
+	CheckType_Kubernetes     = "kubernetes"
+	CheckType_Helm           = "helm"
+	CheckType_Kustomize      = "kustomize"
+	CheckType_CloudFormation = "cloudformation"
+)
+
+// Output : The JSON report of checkov, with a report per framework
+type Output struct {
+	Reports []Report
+}
+
+// Report : The results of the checks of a framework
+type Report struct {
+
+	// The framework, e.g. one of the CheckType constants.
+	CheckType string `json:"check_type"`
+
+	Results Results `json:"results"`
+
+	Summary *Summary `json:"summary,omitempty"`
+}
+
+// Results : The checks of the resources of a framework
+type Results struct {
+	FailedChecks []Check `json:"failed_checks"`
+
+	PassedChecks []Check `json:"passed_checks,omitempty"`
+
+	SkippedChecks []Check `json:"skipped_checks,omitempty"`
+}
+
+// Check : The result of a check of a resource
+type Check struct {
+
+	// The ID of the check, e.g. CKV_AWS_20.
+	CheckID string `json:"check_id"`
+
+	// The ID of the check in the Bridgecrew platform, e.g. BC_AWS_S3_1.
+	BCCheckID string `json:"bc_check_id,omitempty"`
+
+	CheckName string `json:"check_name"`
+
+	CheckResult CheckResult `json:"check_result"`
+
+	// The path of the file relative to the scanned directory, starting with "/".
+	FilePath string `json:"file_path"`
+
+	FileAbsPath string `json:"file_abs_path,omitempty"`
+
+	// The path of the file relative to the repository, starting with "/".
+	RepoFilePath string `json:"repo_file_path,omitempty"`
+
+	// The first and last lines of the resource.
+	FileLineRange []int `json:"file_line_range,omitempty"`
+
+	// The address of the resource, e.g. aws_s3_bucket.logs or Deployment.default.my-app.
+	Resource string `json:"resource"`
+
+	// The full address of Terraform resources, including their module.
+	ResourceAddress string `json:"resource_address,omitempty"`
+
+	// The severity of the check, only reported with a Bridgecrew API key.
+	Severity string `json:"severity,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	ShortDescription string `json:"short_description,omitempty"`
+
+	// The URL of the guideline fixing the misconfiguration.
+	Guideline string `json:"guideline,omitempty"`
+}
+
+// CheckResult : The outcome of a check
+type CheckResult struct {
+
+	// PASSED, FAILED or SKIPPED.
+	Result string `json:"result"`
+
+	EvaluatedKeys []string `json:"evaluated_keys,omitempty"`
+}
+
+// Summary : The counts of the checks of a framework
+type Summary struct {
+	Passed int `json:"passed"`
+
+	Failed int `json:"failed"`
+
+	Skipped int `json:"skipped"`
+
+	ParsingErrors int `json:"parsing_errors"`
+
+	ResourceCount int `json:"resource_count"`
+
+	CheckovVersion string `json:"checkov_version,omitempty"`
+}
+
+// Parse : Reads the JSON report of checkov, i.e. a report or a list of reports when checkov scanned several
+// frameworks. A summary without report, written when checkov found nothing to scan, has no report.
+func Parse(r io.Reader) (output *Output, err error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkov report: %s", err.Error())
+	}
+	output = new(Output)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &output.Reports)
+	} else {
+		var report Report
+		if err = json.Unmarshal(data, &report); err == nil && report.CheckType != "" {
+			output.Reports = []Report{report}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checkov report: %s", err.Error())
+	}
+	return
+}
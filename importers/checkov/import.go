@@ -0,0 +1,177 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkov
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL the file paths are resolved against to build the resource URL of the occurrences, e.g.
+	// https://github.com/my-org/my-repo/blob/main/.
+	BaseURL string
+
+	// The directory checkov scanned, removed from the absolute file paths of the report. Defaults to the paths
+	// relative to the repository, or to the scanned directory.
+	SourceRoot string
+}
+
+// Import : Reads the JSON report of checkov and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the failed checks to FINDING notes, and the failed checks of each resource to occurrences.
+//
+// Notes are identified by the check ID, e.g. CKV_AWS_20. Their severity is the highest severity of the failures of
+// the check, MEDIUM when checkov doesn't report severities, and their related URL and next step the guideline of the
+// check.
+//
+// Occurrences are identified by the check, file and resource address, so that moving the resource in its file keeps
+// the occurrence. Their context names the resource and its type: the Terraform resource type, the kind of
+// Kubernetes objects, the CloudFormation resource type, or else the framework. Their resource URL is the first line
+// of the resource when the options have a base URL. Passed and skipped checks are skipped. The provider defaults to
+// checkov.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("checkov")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "checkov", "https://www.checkov.io"))
+
+	for i := range output.Reports {
+		checkType := output.Reports[i].CheckType
+		for j := range output.Reports[i].Results.FailedChecks {
+			check := &output.Reports[i].Results.FailedChecks[j]
+			if check.CheckID == "" {
+				return nil, fmt.Errorf("reports[%d].results.failed_checks[%d]: the check has no ID", i, j)
+			}
+			checkNote := newNote(check)
+			report.AddNote(checkNote)
+			note := report.Note(*checkNote.ID)
+
+			path := check.path(opts.SourceRoot)
+			resource := check.address()
+			occurrence := findingsapiv1.ApiOccurrence{
+				ID:       core.StringPtr(importers.StableID(report.ProviderID, check.CheckID, path, resource)),
+				NoteName: core.StringPtr(report.NoteName(*note.ID)),
+				Kind:     core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+				Finding:  &findingsapiv1.Finding{},
+			}
+			occurrence.Finding.Severity = importers.SeverityPtr(checkSeverity(check))
+			line := 0
+			if len(check.FileLineRange) > 0 {
+				line = check.FileLineRange[0]
+			}
+			var context *findingsapiv1.Context
+			occurrence.ResourceURL, context = importers.ResourceLocation(opts.BaseURL, path, line, resource, ResourceType(checkType, resource))
+			occurrence.Context = opts.OccurrenceContext(context)
+			report.AddOccurrence(occurrence)
+		}
+	}
+	report.RaiseNoteSeverities()
+	return
+}
+
+// ResourceType : Returns the type of a resource of the given framework: the type of Terraform resources, the kind of
+// Kubernetes objects or the type of CloudFormation resources, else the framework
+func ResourceType(checkType string, resource string) string {
+	switch checkType {
+	case CheckType_Terraform, CheckType_TerraformPlan:
+		if resourceType := importers.TerraformResourceType(resource); resourceType != "" {
+			return resourceType
+		}
+	case CheckType_Kubernetes, CheckType_Helm, CheckType_Kustomize, CheckType_CloudFormation:
+		if i := strings.Index(resource, "."); i > 0 {
+			return resource[:i]
+		}
+	}
+	return checkType
+}
+
+// path returns the path of the file of the check, relative to the source root when it has an absolute path, else
+// to the repository or to the scanned directory.
+func (check *Check) path(sourceRoot string) string {
+	if sourceRoot != "" && check.FileAbsPath != "" {
+		return importers.RelativePath(sourceRoot, check.FileAbsPath)
+	}
+	if check.RepoFilePath != "" {
+		return strings.TrimPrefix(check.RepoFilePath, "/")
+	}
+	return strings.TrimPrefix(check.FilePath, "/")
+}
+
+// address returns the address of the resource of the check, including its module.
+func (check *Check) address() string {
+	if check.ResourceAddress != "" {
+		return check.ResourceAddress
+	}
+	return check.Resource
+}
+
+// newNote converts the check to a FINDING note.
+func newNote(check *Check) findingsapiv1.ApiNote {
+	shortDescription := check.CheckName
+	if shortDescription == "" {
+		shortDescription = check.ShortDescription
+	}
+	if shortDescription == "" {
+		shortDescription = check.CheckID
+	}
+	longDescription := check.Description
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(importers.SanitizeID(check.CheckID)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(checkSeverity(check))},
+	}
+	if importers.IsWebURL(check.Guideline) {
+		note.RelatedURL = []findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr(check.CheckID), URL: core.StringPtr(check.Guideline)}}
+		note.Finding.NextSteps = []findingsapiv1.RemediationStep{{
+			Title: core.StringPtr(fmt.Sprintf("Follow the guideline of %s", check.CheckID)),
+			URL:   core.StringPtr(check.Guideline),
+		}}
+	}
+	return note
+}
+
+// checkSeverity returns the severity of the check, MEDIUM when unknown.
+func checkSeverity(check *Check) findingsapiv1.Severity {
+	return importers.SeverityFromName(check.Severity, findingsapiv1.Severity_Medium)
+}
@@ -0,0 +1,116 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkov_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/checkov"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *checkov.Options) *importers.Report {
+		file, err := os.Open("testdata/checkov.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := checkov.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`creates one note per failed check`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("checkov"))
+		Expect(*report.ReportedBy.Title).To(Equal("checkov"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(2))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("CKV_AWS_20"))
+		Expect(*note.ShortDescription).To(Equal("S3 Bucket has an ACL defined which allows public READ access."))
+		Expect(*note.LongDescription).To(Equal(*note.ShortDescription))
+		Expect(*note.Finding.Severity).To(Equal("HIGH"))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://docs.bridgecrew.io/docs/s3_1-acl-read-permissions-everyone"))
+		Expect(*note.Finding.NextSteps[0].Title).To(Equal("Follow the guideline of CKV_AWS_20"))
+		Expect(*note.Finding.NextSteps[0].URL).To(Equal("https://docs.bridgecrew.io/docs/s3_1-acl-read-permissions-everyone"))
+
+		Expect(*report.Notes[1].ID).To(Equal("CKV_K8S_22"))
+	})
+	It(`creates occurrences per resource`, func() {
+		report := importFile(&checkov.Options{BaseURL: "https://github.com/my-org/my-repo/blob/main/"})
+		Expect(report.Occurrences).To(HaveLen(3))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/checkov/notes/CKV_AWS_20"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/infra/s3.tf#L1"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("aws_s3_bucket.logs"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("aws_s3_bucket"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("infra/s3.tf:1"))
+		Expect(*occurrence.Finding.Severity).To(Equal("MEDIUM"))
+
+		occurrence = report.Occurrences[1]
+		Expect(*occurrence.Context.ResourceName).To(Equal("module.storage.aws_s3_bucket.data"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("aws_s3_bucket"))
+		Expect(occurrence.Finding.Severity).To(BeNil())
+
+		occurrence = report.Occurrences[2]
+		Expect(*occurrence.Context.ResourceName).To(Equal("Deployment.default.my-app"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("Deployment"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/deploy/app.yaml#L1"))
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`resolves absolute paths against the source root`, func() {
+		report := importFile(&checkov.Options{SourceRoot: "/src/infra"})
+		Expect(*report.Occurrences[0].Context.ResourceID).To(Equal("s3.tf:1"))
+		Expect(report.Occurrences[0].ResourceURL).To(BeNil())
+	})
+	It(`reads single reports and summaries`, func() {
+		report, err := checkov.Import(strings.NewReader(`{"check_type": "dockerfile", "results": {"failed_checks": [
+			{"check_id": "CKV_DOCKER_2", "check_name": "Ensure that HEALTHCHECK instructions have been added to container images",
+			"file_path": "/Dockerfile", "resource": "/Dockerfile.", "severity": "LOW"}]}}`), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Occurrences).To(HaveLen(1))
+		Expect(*report.Occurrences[0].Context.ResourceType).To(Equal("dockerfile"))
+		Expect(*report.Occurrences[0].Context.ResourceID).To(Equal("Dockerfile"))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Notes[0].RelatedURL).To(BeEmpty())
+
+		report, err = checkov.Import(strings.NewReader(`{"passed": 0, "failed": 0, "skipped": 0, "parsing_errors": 0, "resource_count": 0}`), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Occurrences).To(BeEmpty())
+	})
+	It(`returns the type of resources`, func() {
+		Expect(checkov.ResourceType(checkov.CheckType_TerraformPlan, "module.a.google_storage_bucket.b")).To(Equal("google_storage_bucket"))
+		Expect(checkov.ResourceType(checkov.CheckType_Terraform, "locals")).To(Equal("terraform"))
+		Expect(checkov.ResourceType(checkov.CheckType_CloudFormation, "AWS::S3::Bucket.Logs")).To(Equal("AWS::S3::Bucket"))
+		Expect(checkov.ResourceType(checkov.CheckType_Helm, "Service.default.web")).To(Equal("Service"))
+		Expect(checkov.ResourceType("github_actions", "jobs(build)")).To(Equal("github_actions"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := checkov.Import(strings.NewReader(`[{"check_type": "terraform", "results": []}]`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = checkov.Import(strings.NewReader(`{"check_type": "terraform", "results": {"failed_checks": [{"resource": "a.b"}]}}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("reports[0].results.failed_checks[0]: the check has no ID"))
+	})
+})
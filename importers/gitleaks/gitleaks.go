@@ -0,0 +1,107 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gitleaks converts the JSON reports of gitleaks, i.e. of "gitleaks detect --report-format json", to notes
+// and occurrences of leaked secrets. Each rule becomes a FINDING note, and each leak an occurrence of it. Secrets
+// are redacted: the notes and occurrences never contain the secret or the line it was found in.
+package gitleaks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RedactedSecret is the secret of the leaks of the reports of "gitleaks detect --redact".
+const RedactedSecret = "REDACTED"
+
+// Output : The JSON report of gitleaks, i.e. its leaks
+type Output struct {
+	Leaks []Leak
+}
+
+// Leak : A secret found in a file of a commit
+type Leak struct {
+
+	// The description of the rule, e.g. AWS Access Key.
+	Description string `json:"Description"`
+
+	StartLine int `json:"StartLine"`
+
+	EndLine int `json:"EndLine"`
+
+	StartColumn int `json:"StartColumn"`
+
+	EndColumn int `json:"EndColumn"`
+
+	// The text matching the rule, including the secret.
+	Match string `json:"Match"`
+
+	Secret string `json:"Secret"`
+
+	File string `json:"File"`
+
+	SymlinkFile string `json:"SymlinkFile,omitempty"`
+
+	// The commit the secret was added in, empty for scans with --no-git.
+	Commit string `json:"Commit,omitempty"`
+
+	Entropy float64 `json:"Entropy,omitempty"`
+
+	Author string `json:"Author,omitempty"`
+
+	Email string `json:"Email,omitempty"`
+
+	Date string `json:"Date,omitempty"`
+
+	Message string `json:"Message,omitempty"`
+
+	Tags []string `json:"Tags,omitempty"`
+
+	// The ID of the rule, e.g. aws-access-token.
+	RuleID string `json:"RuleID"`
+
+	// The identity of the leak, used in .gitleaksignore files, e.g. commit:file:rule:line.
+	Fingerprint string `json:"Fingerprint,omitempty"`
+}
+
+// Parse : Reads the JSON report of gitleaks
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(&output.Leaks); err != nil {
+		return nil, fmt.Errorf("failed to parse gitleaks report: %s", err.Error())
+	}
+	return
+}
+
+// Redact : Masks a secret. The first 4 characters of secrets of 16 characters or more are kept so that the secret can
+// be recognized, e.g. the AKIA prefix of AWS access keys; the mask hides the length of the secret.
+func Redact(secret string) string {
+	const mask = "********"
+	if runes := []rune(secret); len(runes) >= 16 {
+		return string(runes[:4]) + mask
+	}
+	return mask
+}
+
+// redact replaces the secret of the leak in text.
+func (leak *Leak) redact(text string) string {
+	if leak.Secret == "" || leak.Secret == RedactedSecret {
+		return text
+	}
+	return strings.Replace(text, leak.Secret, Redact(leak.Secret), -1)
+}
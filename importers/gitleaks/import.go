@@ -0,0 +1,191 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gitleaks
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// RemovalGuideURL is the URL of the guide to removing secrets from the history of a repository.
+const RemovalGuideURL = "https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/removing-sensitive-data-from-a-repository"
+
+// criticalRules are the gitleaks rules of secrets granting access to cloud accounts, source code or signing keys,
+// whose leaks are CRITICAL.
+var criticalRules = map[string]bool{
+	"aws-access-token":        true,
+	"gcp-api-key":             true,
+	"azure-ad-client-secret":  true,
+	"alibaba-secret-key":      true,
+	"private-key":             true,
+	"github-pat":              true,
+	"github-fine-grained-pat": true,
+	"github-app-token":        true,
+	"github-oauth":            true,
+	"gitlab-pat":              true,
+}
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL of the scanned repository, e.g. https://github.com/my-org/my-repo. The resource URL of a leak is the
+	// line of the file in the commit of the leak, e.g. https://github.com/my-org/my-repo/blob/<commit>/<file>#L3.
+	RepositoryURL string
+
+	// The URL the file paths of leaks without commit, i.e. of scans with --no-git, are resolved against to build
+	// their resource URL, e.g. https://github.com/my-org/my-repo/blob/main/.
+	BaseURL string
+
+	// The directory gitleaks scanned, removed from the absolute file paths of the report.
+	SourceRoot string
+
+	// The severity of the leaks. Defaults to CRITICAL for secrets granting access to cloud accounts, source code or
+	// signing keys, e.g. the aws-access-token and private-key rules, and to HIGH for the other rules.
+	Severity findingsapiv1.Severity
+
+	// The severities of the leaks of specific rules, by rule ID, overriding Severity.
+	Severities map[string]findingsapiv1.Severity
+}
+
+// Import : Reads the JSON report of gitleaks and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the rules of the leaks to FINDING notes, and the leaks to occurrences.
+//
+// Notes are identified by the rule ID, e.g. aws-access-token. Their severity is the one of the options, and their
+// next step the removal of the secret from the history of the repository.
+//
+// Occurrences are identified by the fingerprint of the leak, i.e. its commit, file, rule and line. Their context
+// names the file, with the commit, file and line as resource ID, and their resource URL is the line of the file in
+// the commit when the options have a repository URL. Their remediation asks to revoke the secret, identified by its
+// redacted value. The secret and the line it was found in are never converted, and the secret is redacted from the
+// descriptions and paths of the leaks. The provider defaults to gitleaks.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("gitleaks")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "gitleaks", "https://github.com/gitleaks/gitleaks"))
+
+	for i := range output.Leaks {
+		leak := &output.Leaks[i]
+		if leak.RuleID == "" {
+			return nil, fmt.Errorf("leaks[%d]: the leak has no rule", i)
+		}
+		note := newNote(leak, opts.severity(leak.RuleID))
+		report.AddNote(note)
+
+		path := leak.redact(importers.RelativePath(opts.SourceRoot, leak.File))
+		identity := leak.Fingerprint
+		if identity == "" {
+			identity = strings.Join([]string{leak.Commit, path, leak.RuleID, strconv.Itoa(leak.StartLine)}, ":")
+		}
+		occurrence := findingsapiv1.ApiOccurrence{
+			ID:          core.StringPtr(importers.StableID(report.ProviderID, leak.redact(identity))),
+			NoteName:    core.StringPtr(report.NoteName(*note.ID)),
+			Kind:        core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+			Remediation: core.StringPtr(leak.remediation(path)),
+			Finding:     &findingsapiv1.Finding{},
+		}
+		var context *findingsapiv1.Context
+		occurrence.ResourceURL, context = importers.FileLocation(opts.baseURL(leak.Commit), path, leak.StartLine)
+		if leak.Commit != "" {
+			context.ResourceID = core.StringPtr(fmt.Sprintf("%s:%s:%d", leak.Commit, path, leak.StartLine))
+		}
+		occurrence.Context = opts.OccurrenceContext(context)
+		report.AddOccurrence(occurrence)
+	}
+	return
+}
+
+// severity returns the severity of the leaks of the rule.
+func (options *Options) severity(ruleID string) findingsapiv1.Severity {
+	if severity, ok := options.Severities[ruleID]; ok {
+		return severity
+	}
+	switch {
+	case options.Severity != "":
+		return options.Severity
+	case criticalRules[ruleID]:
+		return findingsapiv1.Severity_Critical
+	default:
+		return findingsapiv1.Severity_High
+	}
+}
+
+// baseURL returns the URL the file paths of the leaks of the commit are resolved against.
+func (options *Options) baseURL(commit string) string {
+	if commit != "" && options.RepositoryURL != "" {
+		return strings.TrimSuffix(options.RepositoryURL, "/") + "/blob/" + commit + "/"
+	}
+	return options.BaseURL
+}
+
+// remediation returns the remediation of the leak, identifying the secret by its redacted value.
+func (leak *Leak) remediation(path string) string {
+	secret := "the secret"
+	if leak.Secret != "" && leak.Secret != RedactedSecret {
+		secret += " " + Redact(leak.Secret)
+	}
+	if leak.Commit == "" {
+		return fmt.Sprintf("Revoke %s, then remove it from %s", secret, path)
+	}
+	commit := leak.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return fmt.Sprintf("Revoke %s, then remove it from the history of the repository, starting at commit %s", secret, commit)
+}
+
+// newNote converts the rule of the leak to a FINDING note.
+func newNote(leak *Leak, severity findingsapiv1.Severity) findingsapiv1.ApiNote {
+	shortDescription := leak.redact(leak.Description)
+	if shortDescription == "" {
+		shortDescription = leak.RuleID
+	}
+	return findingsapiv1.ApiNote{
+		ID:               core.StringPtr(importers.SanitizeID(leak.RuleID)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription: core.StringPtr(fmt.Sprintf("gitleaks found a secret matching the %s rule. Anyone with access "+
+			"to the repository or to its history can use it until it is revoked.", leak.RuleID)),
+		Finding: &findingsapiv1.FindingType{
+			Severity: importers.SeverityPtr(severity),
+			NextSteps: []findingsapiv1.RemediationStep{{
+				Title: core.StringPtr("Revoke the secret, then remove it from the history of the repository"),
+				URL:   core.StringPtr(RemovalGuideURL),
+			}},
+		},
+	}
+}
@@ -0,0 +1,139 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gosec converts the JSON report of gosec, i.e. of "gosec -fmt=json ./...", to notes and occurrences. Each
+// rule becomes a FINDING note, and each issue an occurrence of it.
+package gosec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ruleTitles are the descriptions of the gosec rules, the report only has the details of each issue.
+var ruleTitles = map[string]string{
+	"G101": "Look for hard coded credentials",
+	"G102": "Bind to all interfaces",
+	"G103": "Audit the use of unsafe block",
+	"G104": "Audit errors not checked",
+	"G106": "Audit the use of ssh.InsecureIgnoreHostKey",
+	"G107": "Url provided to HTTP request as taint input",
+	"G108": "Profiling endpoint automatically exposed on /debug/pprof",
+	"G109": "Potential Integer overflow made by strconv.Atoi result conversion to int16/32",
+	"G110": "Potential DoS vulnerability via decompression bomb",
+	"G111": "Potential directory traversal",
+	"G112": "Potential slowloris attack",
+	"G114": "Use of net/http serve function that has no support for setting timeouts",
+	"G201": "SQL query construction using format string",
+	"G202": "SQL query construction using string concatenation",
+	"G203": "Use of unescaped data in HTML templates",
+	"G204": "Audit use of command execution",
+	"G301": "Poor file permissions used when creating a directory",
+	"G302": "Poor file permissions used with chmod",
+	"G303": "Creating tempfile using a predictable path",
+	"G304": "File path provided as taint input",
+	"G305": "File traversal when extracting zip/tar archive",
+	"G306": "Poor file permissions used when writing to a new file",
+	"G307": "Poor file permissions used when creating a file with os.Create",
+	"G401": "Detect the usage of DES, RC4, MD5 or SHA1",
+	"G402": "Look for bad TLS connection settings",
+	"G403": "Ensure minimum RSA key length of 2048 bits",
+	"G404": "Insecure random number source (rand)",
+	"G501": "Import blocklist: crypto/md5",
+	"G502": "Import blocklist: crypto/des",
+	"G503": "Import blocklist: crypto/rc4",
+	"G504": "Import blocklist: net/http/cgi",
+	"G505": "Import blocklist: crypto/sha1",
+	"G601": "Implicit memory aliasing of items from a range statement",
+}
+
+// Output : The JSON report of gosec
+type Output struct {
+	Issues []Issue `json:"Issues"`
+
+	Stats *Stats `json:"Stats,omitempty"`
+
+	GosecVersion string `json:"GosecVersion,omitempty"`
+}
+
+// Issue : A problem found by a rule
+type Issue struct {
+	Severity string `json:"severity"`
+
+	Confidence string `json:"confidence"`
+
+	CWE *CWE `json:"cwe,omitempty"`
+
+	RuleID string `json:"rule_id"`
+
+	Details string `json:"details"`
+
+	// The absolute path of the file.
+	File string `json:"file"`
+
+	// The source code around the issue. It isn't imported, as it may contain the secrets found by G101.
+	Code string `json:"code"`
+
+	// The line, or the range of lines such as "12-14".
+	Line string `json:"line"`
+
+	Column string `json:"column"`
+
+	// True when the issue is excluded with a #nosec comment.
+	NoSec bool `json:"nosec"`
+
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+}
+
+// CWE : The weakness of an issue
+type CWE struct {
+	ID string `json:"id"`
+
+	URL string `json:"url"`
+}
+
+// Suppression : A suppression of an issue
+type Suppression struct {
+	Kind string `json:"kind"`
+
+	Justification string `json:"justification"`
+}
+
+// Stats : The statistics of the scan
+type Stats struct {
+	Files int `json:"files"`
+
+	Lines int `json:"lines"`
+
+	Nosec int `json:"nosec"`
+
+	Found int `json:"found"`
+}
+
+// Parse : Reads the JSON report of gosec
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(output); err != nil {
+		return nil, fmt.Errorf("failed to parse gosec report: %s", err.Error())
+	}
+	return
+}
+
+// RuleTitle : Returns the description of a gosec rule, or "" for unknown rules
+func RuleTitle(ruleID string) string {
+	return ruleTitles[ruleID]
+}
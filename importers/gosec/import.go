@@ -0,0 +1,150 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gosec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL the file paths are resolved against to build the resource URL of the occurrences, e.g.
+	// https://github.com/my-org/my-repo/blob/main/.
+	BaseURL string
+
+	// The directory gosec scanned, removed from the absolute file paths of the report, e.g. the root of the
+	// repository.
+	SourceRoot string
+}
+
+// Import : Reads the JSON report of gosec and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the rules of the issues to FINDING notes, and the issues to occurrences.
+//
+// Notes are identified by the rule ID, e.g. G101. Their severity is the highest severity of the issues of the rule,
+// and their related URL and next step the CWE of the rule.
+//
+// Occurrences are identified by the rule, file, line and column of the issue. Their certainty is the confidence of
+// the issue, and their severity the severity of the issue when it differs from the one of the note. Their context
+// names the file relative to the source root of the options, and their resource URL is the line of the file when
+// the options have a base URL. Issues excluded with #nosec or suppressed are skipped. The provider defaults to
+// gosec.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("gosec")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "gosec", "https://github.com/securego/gosec"))
+
+	for i := range output.Issues {
+		issue := &output.Issues[i]
+		if issue.NoSec || len(issue.Suppressions) > 0 {
+			continue
+		}
+		if issue.RuleID == "" {
+			return nil, fmt.Errorf("issues[%d]: the issue has no rule", i)
+		}
+		issueNote := newNote(issue)
+		report.AddNote(issueNote)
+		note := report.Note(*issueNote.ID)
+
+		path := importers.RelativePath(opts.SourceRoot, issue.File)
+		line := issue.StartLine()
+		occurrence := findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr(importers.StableID(report.ProviderID, issue.RuleID, path, strconv.Itoa(line), issue.Column)),
+			NoteName: core.StringPtr(report.NoteName(*note.ID)),
+			Kind:     core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+			Finding:  &findingsapiv1.Finding{},
+		}
+		occurrence.Finding.Severity = importers.SeverityPtr(issueSeverity(issue))
+		if certainty, err := findingsapiv1.ParseCertainty(issue.Confidence); err == nil {
+			occurrence.Finding.Certainty = core.StringPtr(string(certainty))
+		}
+		var context *findingsapiv1.Context
+		occurrence.ResourceURL, context = importers.FileLocation(opts.BaseURL, path, line)
+		occurrence.Context = opts.OccurrenceContext(context)
+		report.AddOccurrence(occurrence)
+	}
+	report.RaiseNoteSeverities()
+	return
+}
+
+// StartLine : Returns the first line of the issue, 0 when unknown
+func (issue *Issue) StartLine() int {
+	line, err := strconv.Atoi(strings.SplitN(issue.Line, "-", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+// newNote converts the rule of the issue to a FINDING note.
+func newNote(issue *Issue) findingsapiv1.ApiNote {
+	shortDescription := RuleTitle(issue.RuleID)
+	if shortDescription == "" {
+		shortDescription = issue.Details
+	}
+	if shortDescription == "" {
+		shortDescription = issue.RuleID
+	}
+	longDescription := issue.Details
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(importers.SanitizeID(issue.RuleID)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(issueSeverity(issue))},
+	}
+	if issue.CWE != nil && importers.IsWebURL(issue.CWE.URL) {
+		cwe := "CWE-" + issue.CWE.ID
+		note.RelatedURL = []findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr(cwe), URL: core.StringPtr(issue.CWE.URL)}}
+		note.Finding.NextSteps = []findingsapiv1.RemediationStep{{
+			Title: core.StringPtr(fmt.Sprintf("Apply the mitigations of %s", cwe)),
+			URL:   core.StringPtr(issue.CWE.URL),
+		}}
+	}
+	return note
+}
+
+// issueSeverity returns the severity of the issue, MEDIUM when unknown.
+func issueSeverity(issue *Issue) findingsapiv1.Severity {
+	return importers.SeverityFromName(issue.Severity, findingsapiv1.Severity_Medium)
+}
@@ -0,0 +1,127 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gosec_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/gosec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *gosec.Options) *importers.Report {
+		file, err := os.Open("testdata/gosec.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := gosec.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+	options := &gosec.Options{
+		Options: importers.Options{Context: &findingsapiv1.Context{
+			ComponentName: core.StringPtr("my-app"),
+			ToolchainID:   core.StringPtr("toolchain"),
+		}},
+		BaseURL:    "https://github.com/my-org/my-repo/blob/main/",
+		SourceRoot: "/home/ci/my-repo",
+	}
+
+	It(`converts rules to notes`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("gosec"))
+		Expect(*report.ReportedBy.Title).To(Equal("gosec"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(2))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("G101"))
+		Expect(*note.ShortDescription).To(Equal("Look for hard coded credentials"))
+		Expect(*note.LongDescription).To(Equal("Potential hardcoded credentials"))
+		Expect(*note.Finding.Severity).To(Equal("HIGH"))
+		Expect(*note.RelatedURL[0].Label).To(Equal("CWE-798"))
+		Expect(*note.Finding.NextSteps[0].Title).To(Equal("Apply the mitigations of CWE-798"))
+		Expect(*note.Finding.NextSteps[0].URL).To(Equal("https://cwe.mitre.org/data/definitions/798.html"))
+
+		Expect(*report.Notes[1].ID).To(Equal("G201"))
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("MEDIUM"))
+	})
+	It(`converts issues to occurrences`, func() {
+		report := importFile(options)
+		Expect(report.Occurrences).To(HaveLen(3))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/gosec/notes/G101"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/config/config.go#L12"))
+		Expect(occurrence.Finding.Severity).To(BeNil())
+		Expect(*occurrence.Finding.Certainty).To(Equal("LOW"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("config/config.go"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("config/config.go:12"))
+		Expect(*occurrence.Context.ComponentName).To(Equal("my-app"))
+		Expect(*occurrence.Context.ToolchainID).To(Equal("toolchain"))
+		Expect(occurrence.Remediation).To(BeNil())
+
+		occurrence = report.Occurrences[1]
+		Expect(*occurrence.NoteName).To(Equal("providers/gosec/notes/G201"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("store/query.go:40"))
+
+		occurrence = report.Occurrences[2]
+		Expect(*occurrence.Finding.Severity).To(Equal("LOW"))
+		Expect(*occurrence.Finding.Certainty).To(Equal("MEDIUM"))
+
+		Expect(report.Validate()).To(Succeed())
+		for _, occurrence := range report.Occurrences {
+			Expect(*occurrence.Context.ResourceName).ToNot(ContainSubstring("hunter2"))
+		}
+	})
+	It(`derives the same occurrence IDs on every import`, func() {
+		first, second := importFile(options), importFile(options)
+		for i := range first.Occurrences {
+			Expect(*first.Occurrences[i].ID).To(Equal(*second.Occurrences[i].ID))
+		}
+		Expect(*first.Occurrences[0].ID).ToNot(Equal(*first.Occurrences[2].ID))
+	})
+	It(`gives notes the highest severity of the issues of their rule`, func() {
+		report, err := gosec.Import(strings.NewReader(`{"Issues": [
+			{"severity": "LOW", "rule_id": "G104", "details": "Errors unhandled.", "file": "a.go", "line": "3"},
+			{"severity": "HIGH", "rule_id": "G104", "details": "Errors unhandled.", "file": "b.go", "line": "5"}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("HIGH"))
+		Expect(*report.Occurrences[0].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Occurrences[1].Finding.Severity).To(BeNil())
+	})
+	It(`reads line ranges`, func() {
+		Expect((&gosec.Issue{Line: "40-41"}).StartLine()).To(Equal(40))
+		Expect((&gosec.Issue{Line: "7"}).StartLine()).To(Equal(7))
+		Expect((&gosec.Issue{}).StartLine()).To(Equal(0))
+		Expect(gosec.RuleTitle("G404")).To(Equal("Insecure random number source (rand)"))
+		Expect(gosec.RuleTitle("G999")).To(BeEmpty())
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := gosec.Import(strings.NewReader(`{"Issues": [{"details": "d", "file": "f.go", "line": "1"}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("issues[0]: the issue has no rule"))
+
+		_, err = gosec.Import(strings.NewReader(`[`), nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,188 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package govulncheck converts the JSON output of govulncheck, i.e. of "govulncheck -json ./...", to notes and
+// occurrences. Each OSV entry becomes a FINDING note, and each vulnerable module an occurrence of it.
+package govulncheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ResourceType_Module is the resource type of the context of the occurrences.
+const ResourceType_Module = "Go module"
+
+// stdlibModules are the module names govulncheck uses for the standard library and the toolchain.
+var stdlibModules = map[string]bool{"stdlib": true, "toolchain": true}
+
+// Output : The messages of govulncheck, in the order it wrote them
+type Output struct {
+
+	// The configuration of the scan.
+	Config *Config
+
+	// The OSV entries of the vulnerabilities of the findings.
+	Entries []Entry
+
+	// The findings.
+	Findings []Finding
+}
+
+// Message : A message of the JSON stream of govulncheck, of which exactly one field is set. Progress messages are
+// ignored.
+type Message struct {
+	Config *Config `json:"config,omitempty"`
+
+	OSV *Entry `json:"osv,omitempty"`
+
+	Finding *Finding `json:"finding,omitempty"`
+}
+
+// Config : The configuration of the scan
+type Config struct {
+	ProtocolVersion string `json:"protocol_version"`
+
+	ScannerName string `json:"scanner_name"`
+
+	ScannerVersion string `json:"scanner_version"`
+
+	DB string `json:"db"`
+
+	GoVersion string `json:"go_version"`
+
+	ScanLevel string `json:"scan_level"`
+}
+
+// Entry : An OSV entry of the Go vulnerability database
+type Entry struct {
+	ID string `json:"id"`
+
+	Aliases []string `json:"aliases,omitempty"`
+
+	Summary string `json:"summary,omitempty"`
+
+	Details string `json:"details,omitempty"`
+
+	Affected []Affected `json:"affected,omitempty"`
+
+	References []Reference `json:"references,omitempty"`
+
+	DatabaseSpecific *DatabaseSpecific `json:"database_specific,omitempty"`
+}
+
+// Affected : A module affected by a vulnerability
+type Affected struct {
+	Package Package `json:"package"`
+
+	Ranges []Range `json:"ranges,omitempty"`
+}
+
+// Package : The module of an affected entry
+type Package struct {
+	Name string `json:"name"`
+
+	Ecosystem string `json:"ecosystem"`
+}
+
+// Range : The affected versions of a module
+type Range struct {
+	Type string `json:"type"`
+
+	Events []RangeEvent `json:"events"`
+}
+
+// RangeEvent : A version introducing or fixing a vulnerability
+type RangeEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// Reference : A link to more information about a vulnerability
+type Reference struct {
+	Type string `json:"type"`
+
+	URL string `json:"url"`
+}
+
+// DatabaseSpecific : The fields specific to the vulnerability database
+type DatabaseSpecific struct {
+	URL string `json:"url,omitempty"`
+
+	// The severity, set by databases such as GitHub's.
+	Severity string `json:"severity,omitempty"`
+}
+
+// Finding : A vulnerability found in a module, package or function
+type Finding struct {
+	OSV string `json:"osv"`
+
+	FixedVersion string `json:"fixed_version,omitempty"`
+
+	// The path to the vulnerable code, from the vulnerable symbol to the entry point of the program. Only the module
+	// is set in the first frame of module-level findings, and only the module and package of package-level ones.
+	Trace []Frame `json:"trace"`
+}
+
+// Frame : A frame of the trace of a finding
+type Frame struct {
+	Module string `json:"module"`
+
+	Version string `json:"version,omitempty"`
+
+	Package string `json:"package,omitempty"`
+
+	Function string `json:"function,omitempty"`
+
+	Receiver string `json:"receiver,omitempty"`
+
+	Position *Position `json:"position,omitempty"`
+}
+
+// Position : A position in a source file
+type Position struct {
+	Filename string `json:"filename,omitempty"`
+
+	Offset int `json:"offset"`
+
+	Line int `json:"line"`
+
+	Column int `json:"column"`
+}
+
+// Parse : Reads the JSON stream of govulncheck
+func Parse(r io.Reader) (output *Output, err error) {
+	output = &Output{}
+	decoder := json.NewDecoder(r)
+	for {
+		var message Message
+		if err = decoder.Decode(&message); err == io.EOF {
+			return output, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse govulncheck output: %s", err.Error())
+		}
+		switch {
+		case message.Config != nil:
+			output.Config = message.Config
+		case message.OSV != nil:
+			output.Entries = append(output.Entries, *message.OSV)
+		case message.Finding != nil:
+			output.Findings = append(output.Findings, *message.Finding)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package govulncheck
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The severity of the vulnerabilities whose OSV entry has none, which is the case of the entries of the Go
+	// vulnerability database. Defaults to HIGH.
+	Severity findingsapiv1.Severity
+}
+
+// Import : Reads the JSON stream of govulncheck and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the OSV entries of the findings to FINDING notes, and the findings to occurrences.
+//
+// Notes are identified by the OSV ID, e.g. GO-2023-1571. Their severity is the one of the database when the entry
+// has one, else the one of the options, and their next steps upgrade the affected modules to their fixed versions.
+//
+// There is one occurrence per vulnerability, module and component name of the options, with the certainty of the
+// most precise finding: HIGH when a vulnerable function is called, MEDIUM when a vulnerable package is imported,
+// and LOW when the vulnerable module is only required. Its resource URL is the vulnerable module version on
+// pkg.go.dev, and its next step the upgrade to the fixed version. The component name of its context defaults to the
+// module calling the vulnerable function.
+// The provider defaults to govulncheck.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	severity := opts.Severity
+	if severity == "" {
+		severity = findingsapiv1.Severity_High
+	}
+	providerID := opts.Provider("govulncheck")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "govulncheck", "https://go.dev/security/vuln"))
+
+	entries := make(map[string]*Entry, len(output.Entries))
+	for i := range output.Entries {
+		entries[output.Entries[i].ID] = &output.Entries[i]
+	}
+	indexes := make(map[string]int)
+	for i := range output.Findings {
+		finding := &output.Findings[i]
+		if len(finding.Trace) == 0 {
+			return nil, fmt.Errorf("findings[%d]: the finding has no trace", i)
+		}
+		entry, ok := entries[finding.OSV]
+		if !ok {
+			return nil, fmt.Errorf("findings[%d]: unknown OSV entry %s", i, finding.OSV)
+		}
+		note := entryNote(entry, severity)
+		report.AddNote(note)
+
+		occurrence := findingOccurrence(report, *note.ID, finding, opts)
+		if index, ok := indexes[*occurrence.ID]; ok {
+			existing := findingsapiv1.Certainty(*report.Occurrences[index].Finding.Certainty)
+			if findingsapiv1.Certainty(*occurrence.Finding.Certainty).Compare(existing) > 0 {
+				report.Occurrences[index] = occurrence
+			}
+			continue
+		}
+		indexes[*occurrence.ID] = len(report.Occurrences)
+		report.AddOccurrence(occurrence)
+	}
+	return
+}
+
+// Certainty : Returns the certainty of a finding: HIGH when its trace starts with a function, MEDIUM with a package
+// and LOW with a module only
+func (finding *Finding) Certainty() findingsapiv1.Certainty {
+	switch {
+	case len(finding.Trace) > 0 && finding.Trace[0].Function != "":
+		return findingsapiv1.Certainty_High
+	case len(finding.Trace) > 0 && finding.Trace[0].Package != "":
+		return findingsapiv1.Certainty_Medium
+	default:
+		return findingsapiv1.Certainty_Low
+	}
+}
+
+// entryNote converts the OSV entry to a FINDING note.
+func entryNote(entry *Entry, severity findingsapiv1.Severity) findingsapiv1.ApiNote {
+	noteID := importers.SanitizeID(entry.ID)
+	shortDescription := entry.Summary
+	if shortDescription == "" {
+		shortDescription = entry.ID
+	}
+	longDescription := entry.Details
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+	if len(entry.Aliases) > 0 {
+		longDescription += "\n\nAliases: " + strings.Join(entry.Aliases, ", ")
+	}
+	if entry.DatabaseSpecific != nil {
+		severity = importers.SeverityFromName(entry.DatabaseSpecific.Severity, severity)
+	}
+
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(noteID),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(severity)},
+	}
+	if entry.DatabaseSpecific != nil && importers.IsWebURL(entry.DatabaseSpecific.URL) {
+		note.RelatedURL = append(note.RelatedURL, findingsapiv1.ApiNoteRelatedURL{Label: core.StringPtr(entry.ID), URL: core.StringPtr(entry.DatabaseSpecific.URL)})
+	}
+	for _, reference := range entry.References {
+		if importers.IsWebURL(reference.URL) {
+			note.RelatedURL = append(note.RelatedURL, findingsapiv1.ApiNoteRelatedURL{Label: core.StringPtr(reference.Type), URL: core.StringPtr(reference.URL)})
+		}
+	}
+	for _, affected := range entry.Affected {
+		for _, versionRange := range affected.Ranges {
+			for _, event := range versionRange.Events {
+				if event.Fixed != "" {
+					note.Finding.NextSteps = append(note.Finding.NextSteps, upgradeStep(affected.Package.Name, event.Fixed))
+				}
+			}
+		}
+	}
+	return note
+}
+
+// findingOccurrence converts the finding to an occurrence of the note.
+func findingOccurrence(report *importers.Report, noteID string, finding *Finding, opts *Options) findingsapiv1.ApiOccurrence {
+	vulnerable := finding.Trace[0]
+	component := ""
+	if opts.Context != nil && opts.Context.ComponentName != nil {
+		component = *opts.Context.ComponentName
+	}
+	occurrenceID := importers.StableID(report.ProviderID, finding.OSV, vulnerable.Module, component)
+	if caller := finding.Trace[len(finding.Trace)-1]; component == "" && caller.Module != vulnerable.Module {
+		component = caller.Module
+	}
+
+	occurrence := findingsapiv1.ApiOccurrence{
+		ID:          core.StringPtr(occurrenceID),
+		NoteName:    core.StringPtr(report.NoteName(noteID)),
+		Kind:        core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ResourceURL: core.StringPtr(moduleURL(vulnerable.Module, vulnerable.Version)),
+		Finding:     &findingsapiv1.Finding{Certainty: core.StringPtr(string(finding.Certainty()))},
+	}
+	current := vulnerable.Module
+	if vulnerable.Version != "" {
+		current += "@" + vulnerable.Version
+	}
+	if finding.FixedVersion != "" {
+		occurrence.Remediation = core.StringPtr(fmt.Sprintf("Upgrade %s to %s", current, finding.FixedVersion))
+		occurrence.Finding.NextSteps = []findingsapiv1.RemediationStep{upgradeStep(vulnerable.Module, finding.FixedVersion)}
+	} else {
+		occurrence.Remediation = core.StringPtr(fmt.Sprintf("No fixed version of %s is available", vulnerable.Module))
+	}
+	occurrence.Context = opts.OccurrenceContext(&findingsapiv1.Context{
+		ResourceName:  core.StringPtr(vulnerable.Module),
+		ResourceType:  core.StringPtr(ResourceType_Module),
+		ResourceID:    core.StringPtr(current),
+		ComponentName: importers.StringPtr(component),
+	})
+	return occurrence
+}
+
+// upgradeStep returns the step upgrading the module to the fixed version.
+func upgradeStep(module string, fixed string) findingsapiv1.RemediationStep {
+	title := fmt.Sprintf("Upgrade %s to %s", module, goVersion(module, fixed))
+	if stdlibModules[module] {
+		title = fmt.Sprintf("Upgrade Go to %s", goVersion(module, fixed))
+	}
+	return findingsapiv1.RemediationStep{Title: core.StringPtr(title), URL: core.StringPtr(moduleURL(module, fixed))}
+}
+
+// moduleURL returns the page of the module version on pkg.go.dev, or the Go release history for the standard library.
+func moduleURL(module string, version string) string {
+	if stdlibModules[module] {
+		return "https://go.dev/doc/devel/release"
+	}
+	if version == "" {
+		return "https://pkg.go.dev/" + module
+	}
+	return "https://pkg.go.dev/" + module + "@" + goVersion(module, version)
+}
+
+// goVersion returns the version in the form of the go command: "v1.2.3" for modules and "go1.2.3" for the standard
+// library. OSV entries omit the prefix.
+func goVersion(module string, version string) string {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "go"), "v")
+	if stdlibModules[module] {
+		return "go" + version
+	}
+	return "v" + version
+}
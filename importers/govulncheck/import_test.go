@@ -0,0 +1,127 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package govulncheck_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/govulncheck"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *govulncheck.Options) *importers.Report {
+		file, err := os.Open("testdata/govulncheck.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := govulncheck.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`parses the JSON stream`, func() {
+		file, err := os.Open("testdata/govulncheck.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		output, err := govulncheck.Parse(file)
+		Expect(err).To(BeNil())
+		Expect(output.Config.ScannerVersion).To(Equal("v1.0.1"))
+		Expect(output.Entries).To(HaveLen(2))
+		Expect(output.Findings).To(HaveLen(5))
+		Expect(output.Findings[0].Certainty()).To(Equal(findingsapiv1.Certainty_Low))
+		Expect(output.Findings[1].Certainty()).To(Equal(findingsapiv1.Certainty_Medium))
+		Expect(output.Findings[2].Certainty()).To(Equal(findingsapiv1.Certainty_High))
+	})
+	It(`converts OSV entries to notes`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("govulncheck"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(2))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("GO-2023-1571"))
+		Expect(*note.ShortDescription).To(Equal("Denial of service via crafted HTTP/2 stream in net/http and golang.org/x/net"))
+		Expect(*note.LongDescription).To(HaveSuffix("\n\nAliases: CVE-2022-41723, GHSA-vvpx-j8f3-3w6h"))
+		Expect(*note.Finding.Severity).To(Equal("HIGH"))
+		Expect(note.RelatedURL).To(HaveLen(3))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://pkg.go.dev/vuln/GO-2023-1571"))
+		Expect(*note.RelatedURL[2].Label).To(Equal("FIX"))
+		Expect(note.Finding.NextSteps).To(Equal([]findingsapiv1.RemediationStep{
+			{Title: core.StringPtr("Upgrade Go to go1.19.6"), URL: core.StringPtr("https://go.dev/doc/devel/release")},
+			{Title: core.StringPtr("Upgrade Go to go1.20.1"), URL: core.StringPtr("https://go.dev/doc/devel/release")},
+			{Title: core.StringPtr("Upgrade golang.org/x/net to v0.7.0"), URL: core.StringPtr("https://pkg.go.dev/golang.org/x/net@v0.7.0")},
+		}))
+
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("MEDIUM"))
+	})
+	It(`converts findings to one occurrence per vulnerable module`, func() {
+		report := importFile(&govulncheck.Options{Options: importers.Options{
+			Context: &findingsapiv1.Context{ToolchainID: core.StringPtr("toolchain")},
+		}})
+		Expect(report.Occurrences).To(HaveLen(3))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/govulncheck/notes/GO-2023-1571"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://pkg.go.dev/golang.org/x/net@v0.5.0"))
+		Expect(*occurrence.Remediation).To(Equal("Upgrade golang.org/x/net@v0.5.0 to v0.7.0"))
+		Expect(*occurrence.Finding.Certainty).To(Equal("HIGH"))
+		Expect(*occurrence.Finding.NextSteps[0].URL).To(Equal("https://pkg.go.dev/golang.org/x/net@v0.7.0"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("golang.org/x/net"))
+		Expect(*occurrence.Context.ResourceType).To(Equal(govulncheck.ResourceType_Module))
+		Expect(*occurrence.Context.ResourceID).To(Equal("golang.org/x/net@v0.5.0"))
+		Expect(*occurrence.Context.ComponentName).To(Equal("example.com/my-app"))
+		Expect(*occurrence.Context.ToolchainID).To(Equal("toolchain"))
+
+		occurrence = report.Occurrences[1]
+		Expect(*occurrence.Finding.Certainty).To(Equal("MEDIUM"))
+		Expect(*occurrence.Finding.NextSteps[0].Title).To(Equal("Upgrade Go to go1.20.1"))
+		Expect(occurrence.Context.ComponentName).To(BeNil())
+
+		occurrence = report.Occurrences[2]
+		Expect(*occurrence.NoteName).To(Equal("providers/govulncheck/notes/GO-2022-0969"))
+		Expect(*occurrence.Finding.Certainty).To(Equal("LOW"))
+		Expect(*occurrence.Remediation).To(Equal("No fixed version of golang.org/x/net is available"))
+		Expect(occurrence.Finding.NextSteps).To(BeNil())
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`identifies occurrences by vulnerability, module and component`, func() {
+		first := importFile(nil)
+		Expect(*importFile(nil).Occurrences[0].ID).To(Equal(*first.Occurrences[0].ID))
+
+		other := importFile(&govulncheck.Options{
+			Options:  importers.Options{Context: &findingsapiv1.Context{ComponentName: core.StringPtr("other-app")}},
+			Severity: findingsapiv1.Severity_Critical,
+		})
+		Expect(*other.Occurrences[0].ID).ToNot(Equal(*first.Occurrences[0].ID))
+		Expect(*other.Occurrences[0].Context.ComponentName).To(Equal("other-app"))
+		Expect(*other.Notes[0].Finding.Severity).To(Equal("CRITICAL"))
+	})
+	It(`rejects findings of unknown entries`, func() {
+		_, err := govulncheck.Import(strings.NewReader(`{"finding": {"osv": "GO-1", "trace": [{"module": "m"}]}}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("findings[0]: unknown OSV entry GO-1"))
+
+		_, err = govulncheck.Import(strings.NewReader(`{"finding": `), nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
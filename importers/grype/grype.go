@@ -0,0 +1,160 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package grype converts the JSON reports of Grype, i.e. of "grype -o json", to notes and occurrences of the
+// vulnerabilities of container images.
+package grype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SourceType_Image is the source type of the reports of image scans.
+const SourceType_Image = "image"
+
+// Output : The JSON report of Grype
+type Output struct {
+	Matches []Match `json:"matches"`
+
+	Source *Source `json:"source,omitempty"`
+
+	Descriptor *Descriptor `json:"descriptor,omitempty"`
+}
+
+// Match : A vulnerability matching a package
+type Match struct {
+	Vulnerability Vulnerability `json:"vulnerability"`
+
+	// The vulnerabilities the match relates to, e.g. the NVD record of a distribution advisory.
+	RelatedVulnerabilities []VulnerabilityMetadata `json:"relatedVulnerabilities,omitempty"`
+
+	Artifact Artifact `json:"artifact"`
+}
+
+// VulnerabilityMetadata : The description of a vulnerability
+type VulnerabilityMetadata struct {
+	ID string `json:"id"`
+
+	DataSource string `json:"dataSource,omitempty"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	Severity string `json:"severity,omitempty"`
+
+	URLs []string `json:"urls,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	CVSS []CVSS `json:"cvss,omitempty"`
+}
+
+// Vulnerability : The matched vulnerability, with its fix
+type Vulnerability struct {
+	VulnerabilityMetadata
+
+	Fix Fix `json:"fix"`
+}
+
+// CVSS : A CVSS score
+type CVSS struct {
+	Version string `json:"version"`
+
+	Vector string `json:"vector,omitempty"`
+
+	Metrics CVSSMetrics `json:"metrics"`
+}
+
+// CVSSMetrics : The scores of a CVSS vector
+type CVSSMetrics struct {
+	BaseScore float64 `json:"baseScore"`
+}
+
+// Fix : The versions fixing a vulnerability
+type Fix struct {
+	Versions []string `json:"versions"`
+
+	// One of fixed, not-fixed, wont-fix or unknown.
+	State string `json:"state"`
+}
+
+// Artifact : The vulnerable package
+type Artifact struct {
+	Name string `json:"name"`
+
+	Version string `json:"version"`
+
+	Type string `json:"type"`
+
+	PURL string `json:"purl,omitempty"`
+
+	Locations []ArtifactLocation `json:"locations,omitempty"`
+}
+
+// ArtifactLocation : A file the package was found in
+type ArtifactLocation struct {
+	Path string `json:"path"`
+}
+
+// Source : The scanned artifact
+type Source struct {
+	Type string `json:"type"`
+
+	// The scanned image for image sources, else the scanned path.
+	Target json.RawMessage `json:"target"`
+}
+
+// ImageTarget : The scanned image
+type ImageTarget struct {
+	UserInput string `json:"userInput"`
+
+	ImageID string `json:"imageID,omitempty"`
+
+	ManifestDigest string `json:"manifestDigest,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+
+	RepoDigests []string `json:"repoDigests,omitempty"`
+}
+
+// Descriptor : The version of Grype
+type Descriptor struct {
+	Name string `json:"name"`
+
+	Version string `json:"version"`
+}
+
+// Parse : Reads the JSON report of Grype
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(output); err != nil {
+		return nil, fmt.Errorf("failed to parse Grype report: %s", err.Error())
+	}
+	return
+}
+
+// Image : Returns the scanned image, nil when the source isn't an image
+func (source *Source) Image() (target *ImageTarget, err error) {
+	if source == nil || source.Type != SourceType_Image {
+		return nil, nil
+	}
+	target = new(ImageTarget)
+	if err = json.Unmarshal(source.Target, target); err != nil {
+		return nil, fmt.Errorf("failed to parse Grype image source: %s", err.Error())
+	}
+	return
+}
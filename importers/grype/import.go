@@ -0,0 +1,153 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// One of the importers.NoteMode constants. Defaults to one note per vulnerability.
+	NoteMode string
+}
+
+// Import : Reads the JSON report of Grype and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the matches of the report to occurrences, and to one note per vulnerability or a single note,
+// following the note mode of the options.
+//
+// The severity is the qualitative rating of the highest CVSS v3 score of the vulnerability or, when it has none, of
+// its related vulnerabilities, else the severity of Grype. There is one occurrence per image digest, package and
+// vulnerability. Its resource URL is the image reference by digest, and its remediation the upgrade to the fixed
+// versions. The provider defaults to grype.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("grype")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "Grype", "https://github.com/anchore/grype"))
+
+	resourceURL, context, err := output.resource()
+	if err != nil {
+		return nil, err
+	}
+	for i := range output.Matches {
+		match := &output.Matches[i]
+		occurrenceID := importers.StableID(providerID, importers.StringValue(context.ResourceName), importers.StringValue(context.ResourceID),
+			match.Artifact.Type, match.Artifact.Name, match.Vulnerability.ID)
+		err = report.AddVulnerability(match.toVulnerability(), opts.NoteMode, "Grype", occurrenceID, resourceURL, opts.OccurrenceContext(context))
+		if err != nil {
+			return nil, fmt.Errorf("matches[%d]: %s", i, err.Error())
+		}
+	}
+	return
+}
+
+// resource returns the resource URL and the context of the scanned source. Images are referenced by digest when
+// Grype reports one.
+func (output *Output) resource() (resourceURL *string, context *findingsapiv1.Context, err error) {
+	target, err := output.Source.Image()
+	if err != nil {
+		return
+	}
+	if target == nil {
+		context = &findingsapiv1.Context{}
+		if output.Source != nil {
+			var path string
+			if json.Unmarshal(output.Source.Target, &path) == nil {
+				context.ResourceName = importers.StringPtr(path)
+			}
+			context.ResourceType = importers.StringPtr(output.Source.Type)
+		}
+		return
+	}
+
+	image := importers.ParseImage(target.UserInput)
+	if len(target.RepoDigests) > 0 {
+		image.Digest = importers.ParseImage(target.RepoDigests[0]).Digest
+	} else if target.ManifestDigest != "" {
+		image.Digest = target.ManifestDigest
+	}
+	resourceID := image.Digest
+	if resourceID == "" {
+		resourceID = target.ImageID
+	}
+	context = &findingsapiv1.Context{
+		ResourceName: core.StringPtr(image.Repository),
+		ResourceType: core.StringPtr(importers.ResourceType_Image),
+		ResourceID:   importers.StringPtr(resourceID),
+	}
+	return core.StringPtr(image.URL()), context, nil
+}
+
+// toVulnerability converts the match to the model shared by the importers.
+func (match *Match) toVulnerability() *importers.Vulnerability {
+	metadata := match.Vulnerability.VulnerabilityMetadata
+	vulnerability := &importers.Vulnerability{
+		ID:               metadata.ID,
+		Description:      metadata.Description,
+		Severity:         importers.SeverityFromName(metadata.Severity, ""),
+		CVSSScore:        cvssScore(metadata.CVSS),
+		URLs:             append([]string{metadata.DataSource}, metadata.URLs...),
+		Package:          match.Artifact.Name,
+		InstalledVersion: match.Artifact.Version,
+	}
+	for _, related := range match.RelatedVulnerabilities {
+		if vulnerability.Description == "" {
+			vulnerability.Description = related.Description
+		}
+		if vulnerability.CVSSScore == 0 {
+			vulnerability.CVSSScore = cvssScore(related.CVSS)
+		}
+		vulnerability.URLs = append(vulnerability.URLs, related.URLs...)
+	}
+	if match.Vulnerability.Fix.State == "fixed" {
+		vulnerability.FixedVersions = match.Vulnerability.Fix.Versions
+	}
+	return vulnerability
+}
+
+// cvssScore returns the highest CVSS v3 base score, 0 when there is none.
+func cvssScore(scores []CVSS) (score float64) {
+	for _, cvss := range scores {
+		if strings.HasPrefix(cvss.Version, "3") && cvss.Metrics.BaseScore > score {
+			score = cvss.Metrics.BaseScore
+		}
+	}
+	return
+}
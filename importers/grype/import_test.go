@@ -0,0 +1,99 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grype_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/grype"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *grype.Options) *importers.Report {
+		file, err := os.Open("testdata/grype.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := grype.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`creates one note per vulnerability`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("grype"))
+		Expect(*report.ReportedBy.Title).To(Equal("Grype"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(3))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("CVE-2021-3711"))
+		Expect(*note.ShortDescription).To(Equal("CVE-2021-3711"))
+		Expect(*note.LongDescription).To(HavePrefix("In order to decrypt SM2 encrypted data"))
+		Expect(*note.Finding.Severity).To(Equal("CRITICAL"))
+		Expect(note.RelatedURL).To(HaveLen(3))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://security.alpinelinux.org/vuln/CVE-2021-3711"))
+
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("HIGH"))
+		Expect(report.Notes[1].RelatedURL).To(HaveLen(1))
+		Expect(*report.Notes[2].Finding.Severity).To(Equal("LOW"))
+	})
+	It(`creates occurrences per image digest`, func() {
+		report := importFile(nil)
+		Expect(report.Occurrences).To(HaveLen(3))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/grype/notes/CVE-2021-3711"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://docker.io/library/my-app@sha256:451eee8bedcb2f029756dc3e9d73bab0e7943c1ac55cff3a4861c52a0fdd3e98"))
+		Expect(*occurrence.Remediation).To(Equal("Upgrade libssl1.1 1.1.1k-r0 to 1.1.1l-r0"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("docker.io/library/my-app"))
+		Expect(*occurrence.Context.ResourceType).To(Equal(importers.ResourceType_Image))
+		Expect(*occurrence.Context.ResourceID).To(HavePrefix("sha256:451eee"))
+
+		Expect(*report.Occurrences[2].Remediation).To(Equal("No fixed version of bzip2 1.0.6-r7 is available"))
+
+		Expect(report.Validate()).To(Succeed())
+		Expect(*importFile(nil).Occurrences[0].ID).To(Equal(*occurrence.ID))
+	})
+	It(`creates a single note in scanner mode`, func() {
+		report := importFile(&grype.Options{NoteMode: importers.NoteMode_Scanner})
+		Expect(report.Notes).To(HaveLen(1))
+		Expect(*report.Notes[0].LongDescription).To(Equal("Grype found a package with a known vulnerability."))
+		Expect(*report.Occurrences[0].Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*report.Occurrences[1].Finding.Severity).To(Equal("HIGH"))
+	})
+	It(`references sources other than images by path`, func() {
+		report, err := grype.Import(strings.NewReader(`{"source": {"type": "directory", "target": "/src/my-app"},
+			"matches": [{"vulnerability": {"id": "CVE-1", "fix": {"versions": ["2"], "state": "fixed"}}, "artifact": {"name": "p", "version": "1"}}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Occurrences[0].ResourceURL).To(BeNil())
+		Expect(*report.Occurrences[0].Context.ResourceName).To(Equal("/src/my-app"))
+		Expect(*report.Occurrences[0].Context.ResourceType).To(Equal("directory"))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("MEDIUM"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := grype.Import(strings.NewReader(`{"source": {"type": "image", "target": "alpine"}}`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = grype.Import(strings.NewReader(`{"matches": [{"vulnerability": {"id": ""}, "artifact": {"name": "p"}}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("matches[0]: the vulnerability of p has no ID"))
+	})
+})
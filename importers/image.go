@@ -0,0 +1,76 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"strings"
+)
+
+// dockerHub is the registry of image references without registry.
+const dockerHub = "docker.io"
+
+// Image : A reference to a container image
+type Image struct {
+
+	// The repository, including the registry, e.g. us.icr.io/my-namespace/my-app or docker.io/library/alpine.
+	Repository string
+
+	Tag string
+
+	// The manifest digest, e.g. sha256:5b8f...
+	Digest string
+}
+
+// ParseImage : Splits an image reference such as "alpine:3.10", "us.icr.io/my-namespace/my-app@sha256:5b8f..." or
+// "registry:5000/my-app:1.0@sha256:5b8f..." into its parts. References without registry are Docker Hub images, as
+// for the docker command.
+func ParseImage(reference string) Image {
+	image := Image{}
+	reference = strings.TrimSpace(reference)
+	if at := strings.Index(reference, "@"); at >= 0 {
+		reference, image.Digest = reference[:at], reference[at+1:]
+	}
+	if colon := strings.LastIndex(reference, ":"); colon > strings.LastIndex(reference, "/") {
+		reference, image.Tag = reference[:colon], reference[colon+1:]
+	}
+	parts := strings.SplitN(reference, "/", 2)
+	if len(parts) == 1 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		if len(parts) == 1 {
+			reference = "library/" + reference
+		}
+		reference = dockerHub + "/" + reference
+	}
+	image.Repository = reference
+	return image
+}
+
+// String : Returns the reference of the image, by digest when known, else by tag
+func (image Image) String() string {
+	switch {
+	case image.Digest != "":
+		return image.Repository + "@" + image.Digest
+	case image.Tag != "":
+		return image.Repository + ":" + image.Tag
+	default:
+		return image.Repository
+	}
+}
+
+// URL : Returns the reference of the image as a resource URL, e.g. https://us.icr.io/my-namespace/my-app@sha256:5b8f...
+func (image Image) URL() string {
+	return "https://" + image.String()
+}
@@ -0,0 +1,42 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Image`, func() {
+	It(`parses image references`, func() {
+		Expect(importers.ParseImage("alpine")).To(Equal(importers.Image{Repository: "docker.io/library/alpine"}))
+		Expect(importers.ParseImage("alpine:3.10")).To(Equal(importers.Image{Repository: "docker.io/library/alpine", Tag: "3.10"}))
+		Expect(importers.ParseImage("my-org/my-app:1.0")).To(Equal(importers.Image{Repository: "docker.io/my-org/my-app", Tag: "1.0"}))
+		Expect(importers.ParseImage("us.icr.io/ns/app@sha256:abc")).To(Equal(importers.Image{Repository: "us.icr.io/ns/app", Digest: "sha256:abc"}))
+		Expect(importers.ParseImage("registry:5000/app:1.0@sha256:abc")).To(Equal(importers.Image{Repository: "registry:5000/app", Tag: "1.0", Digest: "sha256:abc"}))
+		Expect(importers.ParseImage("localhost/app")).To(Equal(importers.Image{Repository: "localhost/app"}))
+	})
+	It(`formats image references`, func() {
+		image := importers.ParseImage("us.icr.io/ns/app:1.0")
+		Expect(image.String()).To(Equal("us.icr.io/ns/app:1.0"))
+		image.Digest = "sha256:abc"
+		Expect(image.String()).To(Equal("us.icr.io/ns/app@sha256:abc"))
+		Expect(image.URL()).To(Equal("https://us.icr.io/ns/app@sha256:abc"))
+		Expect(importers.ParseImage("us.icr.io/ns/app").String()).To(Equal("us.icr.io/ns/app"))
+	})
+})
@@ -0,0 +1,276 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package importers converts the reports of security tools to the notes and occurrences of the Findings API.
+// Each tool has its own subpackage returning a Report, which Publish sends to the service. Occurrence IDs are
+// derived from the findings, so that publishing the same report twice replaces the occurrences instead of
+// duplicating them.
+package importers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// maxIDLength is the length note IDs are truncated to by SanitizeID.
+const maxIDLength = 100
+
+// invalidIDChars matches the characters SanitizeID replaces.
+var invalidIDChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// Options : The options common to all importers
+type Options struct {
+
+	// The provider the notes and occurrences are published under. Each importer has its own default.
+	ProviderID string
+
+	// The reporter of the notes. Defaults to the tool that produced the report.
+	ReportedBy *findingsapiv1.Reporter
+
+	// The context set on every occurrence, e.g. its EnvironmentName, ComponentName or ToolchainID. The context
+	// fields filled by the importer take precedence.
+	Context *findingsapiv1.Context
+}
+
+// Report : The notes and occurrences converted from the report of a tool
+type Report struct {
+
+	// The provider the notes and occurrences belong to.
+	ProviderID string
+
+	// The reporter of the notes that don't declare one.
+	ReportedBy *findingsapiv1.Reporter
+
+	// The notes, in the order they were added.
+	Notes []findingsapiv1.ApiNote
+
+	// The occurrences, in the order they were added. Their note names omit the account ID.
+	Occurrences []findingsapiv1.ApiOccurrence
+
+	notes       map[string]bool
+	occurrences map[string]bool
+}
+
+// NewReport : Instantiate an empty Report
+func NewReport(providerID string, reportedBy *findingsapiv1.Reporter) *Report {
+	return &Report{ProviderID: providerID, ReportedBy: reportedBy}
+}
+
+// AddNote : Adds the note unless the report already has a note with the same ID. Reports whether it was added.
+func (report *Report) AddNote(note findingsapiv1.ApiNote) bool {
+	noteID := StringValue(note.ID)
+	if report.HasNote(noteID) {
+		return false
+	}
+	report.notes[noteID] = true
+	report.Notes = append(report.Notes, note)
+	return true
+}
+
+// HasNote : Reports whether the report has a note with the given ID
+func (report *Report) HasNote(noteID string) bool {
+	if report.notes == nil {
+		report.notes = make(map[string]bool, len(report.Notes))
+		for _, note := range report.Notes {
+			report.notes[StringValue(note.ID)] = true
+		}
+	}
+	return report.notes[noteID]
+}
+
+// Note : Returns the note of the report with the given ID, nil when there is none
+func (report *Report) Note(noteID string) *findingsapiv1.ApiNote {
+	for i := range report.Notes {
+		if StringValue(report.Notes[i].ID) == noteID {
+			return &report.Notes[i]
+		}
+	}
+	return nil
+}
+
+// AddOccurrence : Adds the occurrence unless the report already has an occurrence with the same ID, i.e. the same
+// finding was reported twice. Reports whether it was added.
+func (report *Report) AddOccurrence(occurrence findingsapiv1.ApiOccurrence) bool {
+	occurrenceID := StringValue(occurrence.ID)
+	if report.occurrences == nil {
+		report.occurrences = make(map[string]bool, len(report.Occurrences))
+		for _, existing := range report.Occurrences {
+			report.occurrences[StringValue(existing.ID)] = true
+		}
+	}
+	if report.occurrences[occurrenceID] {
+		return false
+	}
+	report.occurrences[occurrenceID] = true
+	report.Occurrences = append(report.Occurrences, occurrence)
+	return true
+}
+
+// NoteName : Returns the name of the note of the report with the given ID, without account ID
+func (report *Report) NoteName(noteID string) string {
+	return findingsapiv1.FormatNoteName("", report.ProviderID, noteID)
+}
+
+// Bundle : Returns the notes of the report as a bundle, to be planned and applied with PlanNotes and ApplyNotes
+func (report *Report) Bundle() *findingsapiv1.NotesBundle {
+	return &findingsapiv1.NotesBundle{
+		ProviderID: report.ProviderID,
+		ReportedBy: report.ReportedBy,
+		Notes:      append([]findingsapiv1.ApiNote(nil), report.Notes...),
+	}
+}
+
+// Validate : Checks the report as a whole: its notes must form a valid bundle, and each occurrence must pass
+// CreateOccurrenceOptions.Validate and reference a note of the report when it references a note of the provider.
+// Returns ValidationErrors listing every violation.
+func (report *Report) Validate() error {
+	var errs findingsapiv1.ValidationErrors
+	if bundleErrs, ok := report.Bundle().Validate().(findingsapiv1.ValidationErrors); ok {
+		errs = append(errs, bundleErrs...)
+	}
+	_, occurrenceErrs := report.createOccurrenceOptions("account")
+	errs = append(errs, occurrenceErrs...)
+	for i, occurrence := range report.Occurrences {
+		_, providerID, noteID, err := findingsapiv1.ParseNoteName(StringValue(occurrence.NoteName))
+		if err == nil && providerID == report.ProviderID && !report.HasNote(noteID) {
+			errs = append(errs, &findingsapiv1.ValidationError{
+				Field:   fmt.Sprintf("occurrences[%d].note_name", i),
+				Message: fmt.Sprintf("references the note %q, which the report doesn't have", noteID),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// createOccurrenceOptions returns the options creating the occurrences in the given account, along with the
+// violations of their rules.
+func (report *Report) createOccurrenceOptions(accountID string) (occurrences []*findingsapiv1.CreateOccurrenceOptions, errs findingsapiv1.ValidationErrors) {
+	occurrences = make([]*findingsapiv1.CreateOccurrenceOptions, len(report.Occurrences))
+	for i := range report.Occurrences {
+		occurrences[i] = CreateOccurrenceOptions(accountID, report.ProviderID, &report.Occurrences[i])
+		if validationErr, ok := occurrences[i].Validate().(findingsapiv1.ValidationErrors); ok {
+			for _, occurrenceErr := range validationErr {
+				errs = append(errs, &findingsapiv1.ValidationError{
+					Field:   fmt.Sprintf("occurrences[%d].%s", i, occurrenceErr.Field),
+					Message: occurrenceErr.Message,
+				})
+			}
+		}
+	}
+	return
+}
+
+// StableID : Returns an ID derived from the given parts, e.g. the rule, file and line of a finding, so that the
+// same finding gets the same occurrence ID in every import
+func StableID(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:16])
+}
+
+// SanitizeID : Converts the identifier of a tool, e.g. a rule ID, to a note ID. Runs of characters other than
+// letters, digits, "_", "." and "-" are replaced with "-", and the result is truncated to 100 characters.
+func SanitizeID(id string) string {
+	sanitized := strings.Trim(invalidIDChars.ReplaceAllString(id, "-"), "-")
+	if len(sanitized) > maxIDLength {
+		sanitized = strings.TrimRight(sanitized[:maxIDLength], "-")
+	}
+	return sanitized
+}
+
+// MergeContext : Returns a copy of base with the fields set in override replaced, nil when both are nil
+func MergeContext(base *findingsapiv1.Context, override *findingsapiv1.Context) *findingsapiv1.Context {
+	if base == nil && override == nil {
+		return nil
+	}
+	merged := &findingsapiv1.Context{}
+	for _, context := range []*findingsapiv1.Context{base, override} {
+		if context == nil {
+			continue
+		}
+		mergeString(&merged.Region, context.Region)
+		mergeString(&merged.ResourceCrn, context.ResourceCrn)
+		mergeString(&merged.ResourceID, context.ResourceID)
+		mergeString(&merged.ResourceName, context.ResourceName)
+		mergeString(&merged.ResourceType, context.ResourceType)
+		mergeString(&merged.ServiceCrn, context.ServiceCrn)
+		mergeString(&merged.ServiceName, context.ServiceName)
+		mergeString(&merged.EnvironmentName, context.EnvironmentName)
+		mergeString(&merged.ComponentName, context.ComponentName)
+		mergeString(&merged.ToolchainID, context.ToolchainID)
+	}
+	return merged
+}
+
+// Reporter : Returns the reporter of the options, or a reporter with the given ID, title and URL when the options
+// declare none
+func (options *Options) Reporter(id string, title string, url string) *findingsapiv1.Reporter {
+	if options != nil && options.ReportedBy != nil {
+		return options.ReportedBy
+	}
+	reporter := &findingsapiv1.Reporter{ID: core.StringPtr(id), Title: core.StringPtr(title)}
+	if url != "" {
+		reporter.URL = core.StringPtr(url)
+	}
+	return reporter
+}
+
+// Provider : Returns the provider ID of the options, or defaultID when the options declare none
+func (options *Options) Provider(defaultID string) string {
+	if options != nil && options.ProviderID != "" {
+		return options.ProviderID
+	}
+	return defaultID
+}
+
+// OccurrenceContext : Returns the context of an occurrence, i.e. the context of the options merged with the fields
+// filled by the importer
+func (options *Options) OccurrenceContext(context *findingsapiv1.Context) *findingsapiv1.Context {
+	if options == nil {
+		return MergeContext(nil, context)
+	}
+	return MergeContext(options.Context, context)
+}
+
+// StringPtr : Returns a pointer to value, or nil when value is empty
+func StringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return core.StringPtr(value)
+}
+
+func mergeString(target **string, value *string) {
+	if value != nil && *value != "" {
+		*target = core.StringPtr(*value)
+	}
+}
+
+// StringValue : Returns the value of a string pointer, or an empty string when it is nil
+func StringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,131 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Report`, func() {
+	It(`ignores notes and occurrences added twice`, func() {
+		report := importers.NewReport("my-tool", &findingsapiv1.Reporter{ID: core.StringPtr("my-tool"), Title: core.StringPtr("My tool")})
+		report.Notes = []findingsapiv1.ApiNote{{ID: core.StringPtr("existing")}}
+
+		Expect(report.AddNote(findingsapiv1.ApiNote{ID: core.StringPtr("existing")})).To(BeFalse())
+		Expect(report.AddNote(findingsapiv1.ApiNote{ID: core.StringPtr("rule")})).To(BeTrue())
+		Expect(report.AddNote(findingsapiv1.ApiNote{ID: core.StringPtr("rule")})).To(BeFalse())
+		Expect(report.HasNote("rule")).To(BeTrue())
+		Expect(report.Note("rule")).To(BeIdenticalTo(&report.Notes[1]))
+		Expect(report.Note("missing")).To(BeNil())
+		Expect(report.Notes).To(HaveLen(2))
+
+		Expect(report.AddOccurrence(findingsapiv1.ApiOccurrence{ID: core.StringPtr("a")})).To(BeTrue())
+		Expect(report.AddOccurrence(findingsapiv1.ApiOccurrence{ID: core.StringPtr("a")})).To(BeFalse())
+		Expect(report.Occurrences).To(HaveLen(1))
+
+		Expect(report.NoteName("rule")).To(Equal("providers/my-tool/notes/rule"))
+		bundle := report.Bundle()
+		Expect(bundle.ProviderID).To(Equal("my-tool"))
+		Expect(*bundle.ReportedBy.ID).To(Equal("my-tool"))
+		Expect(bundle.Notes).To(HaveLen(2))
+	})
+	It(`validates the notes and occurrences together`, func() {
+		report := importers.NewReport("my-tool", &findingsapiv1.Reporter{ID: core.StringPtr("my-tool"), Title: core.StringPtr("My tool")})
+		report.AddNote(findingsapiv1.ApiNote{
+			ID:               core.StringPtr("rule"),
+			Kind:             core.StringPtr("FINDING"),
+			ShortDescription: core.StringPtr("Rule"),
+			LongDescription:  core.StringPtr("A rule was violated"),
+			Finding:          &findingsapiv1.FindingType{Severity: core.StringPtr("HIGH")},
+		})
+		report.AddOccurrence(findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr("a"),
+			NoteName: core.StringPtr(report.NoteName("rule")),
+			Kind:     core.StringPtr("FINDING"),
+			Finding:  &findingsapiv1.Finding{},
+		})
+		report.AddOccurrence(findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr("b"),
+			NoteName: core.StringPtr("providers/other-tool/notes/rule"),
+			Kind:     core.StringPtr("FINDING"),
+			Finding:  &findingsapiv1.Finding{},
+		})
+		Expect(report.Validate()).To(Succeed())
+
+		report.Notes[0].LongDescription = nil
+		report.AddOccurrence(findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr("c"),
+			NoteName: core.StringPtr(report.NoteName("missing")),
+			Kind:     core.StringPtr("KPI"),
+		})
+		err := report.Validate()
+		Expect(err).To(BeAssignableToTypeOf(findingsapiv1.ValidationErrors{}))
+		Expect(err.Error()).To(ContainSubstring("notes[0].long_description"))
+		Expect(err.Error()).To(ContainSubstring("occurrences[2].kpi"))
+		Expect(err.Error()).To(ContainSubstring(`occurrences[2].note_name: references the note "missing", which the report doesn't have`))
+	})
+})
+
+var _ = Describe(`IDs`, func() {
+	It(`derives stable IDs from their parts`, func() {
+		id := importers.StableID("rule", "main.go", "12")
+		Expect(id).To(HaveLen(32))
+		Expect(importers.StableID("rule", "main.go", "12")).To(Equal(id))
+		Expect(importers.StableID("rule", "main.go1", "2")).ToNot(Equal(id))
+	})
+	It(`sanitizes note IDs`, func() {
+		Expect(importers.SanitizeID("CVE-2020-1234")).To(Equal("CVE-2020-1234"))
+		Expect(importers.SanitizeID(" go/sql-injection ")).To(Equal("go-sql-injection"))
+		Expect(importers.SanitizeID("CKV_AWS_20: S3 bucket")).To(Equal("CKV_AWS_20-S3-bucket"))
+		Expect(importers.SanitizeID("/// ")).To(BeEmpty())
+		Expect(importers.SanitizeID(strings.Repeat("a", 150))).To(HaveLen(100))
+	})
+})
+
+var _ = Describe(`Options`, func() {
+	It(`merges the context of the options with the context of occurrences`, func() {
+		options := &importers.Options{Context: &findingsapiv1.Context{
+			EnvironmentName: core.StringPtr("production"),
+			ToolchainID:     core.StringPtr("toolchain"),
+			ResourceName:    core.StringPtr("default"),
+		}}
+		context := options.OccurrenceContext(&findingsapiv1.Context{ResourceName: core.StringPtr("main.go"), ResourceType: core.StringPtr("")})
+		Expect(*context.EnvironmentName).To(Equal("production"))
+		Expect(*context.ToolchainID).To(Equal("toolchain"))
+		Expect(*context.ResourceName).To(Equal("main.go"))
+		Expect(context.ResourceType).To(BeNil())
+		Expect(*options.Context.ResourceName).To(Equal("default"))
+
+		var none *importers.Options
+		Expect(none.OccurrenceContext(nil)).To(BeNil())
+		Expect(none.Provider("tool")).To(Equal("tool"))
+		Expect(*none.Reporter("tool", "Tool", "").Title).To(Equal("Tool"))
+		Expect(none.Reporter("tool", "Tool", "").URL).To(BeNil())
+	})
+	It(`prefers the provider and reporter of the options`, func() {
+		reporter := &findingsapiv1.Reporter{ID: core.StringPtr("ci"), Title: core.StringPtr("CI")}
+		options := &importers.Options{ProviderID: "ci", ReportedBy: reporter}
+		Expect(options.Provider("tool")).To(Equal("ci"))
+		Expect(options.Reporter("tool", "Tool", "https://tool.example.com")).To(BeIdenticalTo(reporter))
+	})
+})
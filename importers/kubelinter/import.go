@@ -0,0 +1,153 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubelinter
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL the file paths are resolved against to build the resource URL of the occurrences, e.g.
+	// https://github.com/my-org/my-repo/blob/main/.
+	BaseURL string
+
+	// The directory kube-linter scanned, removed from the absolute file paths of the report, e.g. the root of the
+	// repository.
+	SourceRoot string
+
+	// The severity of the checks, as kube-linter has none. Defaults to MEDIUM.
+	Severity findingsapiv1.Severity
+
+	// The severities of specific checks, by check name, overriding Severity.
+	Severities map[string]findingsapiv1.Severity
+}
+
+// CheckURL : Returns the URL of the documentation of a check
+func CheckURL(name string) string {
+	return "https://docs.kubelinter.io/#/generated/checks?id=" + url.QueryEscape(name)
+}
+
+// Import : Reads the JSON report of kube-linter and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the failed checks to FINDING notes, and the reports to occurrences.
+//
+// Notes are identified by the check name, e.g. no-read-only-root-fs. Their severity is the one of the options for the
+// check, as kube-linter doesn't rate its reports, so that every report of a check has the severity of its note. Their
+// related URL is the documentation of the check, and their next step its remediation, linking to the documentation.
+//
+// Occurrences are identified by the check, file, object and diagnostic message, as a check reports each container of
+// an object separately. Their context names the object, prefixed with its namespace, with its kind as resource type,
+// and their resource URL is the file when the options have a base URL. The provider defaults to kube-linter.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("kube-linter")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "kube-linter", "https://github.com/stackrox/kube-linter"))
+
+	checks := make(map[string]*Check, len(output.Checks))
+	for i := range output.Checks {
+		checks[output.Checks[i].Name] = &output.Checks[i]
+	}
+	for i := range output.Reports {
+		objectReport := &output.Reports[i]
+		if objectReport.Check == "" {
+			return nil, fmt.Errorf("reports[%d]: the report has no check", i)
+		}
+		check, ok := checks[objectReport.Check]
+		if !ok {
+			check = &Check{Name: objectReport.Check, Remediation: objectReport.Remediation}
+		}
+		note := newNote(check, opts.severity(check.Name))
+		report.AddNote(note)
+
+		path := importers.RelativePath(opts.SourceRoot, objectReport.Object.Metadata.FilePath)
+		object := objectReport.Object.K8sObject
+		occurrence := findingsapiv1.ApiOccurrence{
+			ID: core.StringPtr(importers.StableID(report.ProviderID, check.Name, path, object.GroupVersionKind.Kind,
+				object.Namespace, object.Name, objectReport.Diagnostic.Message)),
+			NoteName: core.StringPtr(report.NoteName(*note.ID)),
+			Kind:     core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+			Finding:  &findingsapiv1.Finding{},
+		}
+		var context *findingsapiv1.Context
+		occurrence.ResourceURL, context = importers.ResourceLocation(opts.BaseURL, path, 0, object.name(), object.GroupVersionKind.Kind)
+		occurrence.Context = opts.OccurrenceContext(context)
+		report.AddOccurrence(occurrence)
+	}
+	return
+}
+
+// severity returns the severity of the check.
+func (options *Options) severity(check string) findingsapiv1.Severity {
+	if severity, ok := options.Severities[check]; ok {
+		return severity
+	}
+	if options.Severity != "" {
+		return options.Severity
+	}
+	return findingsapiv1.Severity_Medium
+}
+
+// name returns the name of the object, prefixed with its namespace.
+func (object *K8sObject) name() string {
+	if object.Namespace == "" {
+		return object.Name
+	}
+	return object.Namespace + "/" + object.Name
+}
+
+// newNote converts the check to a FINDING note.
+func newNote(check *Check, severity findingsapiv1.Severity) findingsapiv1.ApiNote {
+	shortDescription := check.Description
+	if shortDescription == "" {
+		shortDescription = check.Name
+	}
+	documentation := CheckURL(check.Name)
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(importers.SanitizeID(check.Name)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(shortDescription),
+		RelatedURL:       []findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr(check.Name), URL: core.StringPtr(documentation)}},
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(severity)},
+	}
+	if check.Remediation != "" {
+		note.Finding.NextSteps = []findingsapiv1.RemediationStep{{Title: core.StringPtr(check.Remediation), URL: core.StringPtr(documentation)}}
+	}
+	return note
+}
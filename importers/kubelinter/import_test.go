@@ -0,0 +1,98 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubelinter_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/kubelinter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *kubelinter.Options) *importers.Report {
+		file, err := os.Open("testdata/kube-linter.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := kubelinter.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`creates one note per failed check`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("kube-linter"))
+		Expect(*report.ReportedBy.Title).To(Equal("kube-linter"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(3))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("no-read-only-root-fs"))
+		Expect(*note.ShortDescription).To(Equal("Indicates when containers are running without a read-only root filesystem."))
+		Expect(*note.Finding.Severity).To(Equal("MEDIUM"))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://docs.kubelinter.io/#/generated/checks?id=no-read-only-root-fs"))
+		Expect(*note.Finding.NextSteps[0].Title).To(Equal("Set readOnlyRootFilesystem to true in the container securityContext."))
+		Expect(*note.Finding.NextSteps[0].URL).To(Equal(*note.RelatedURL[0].URL))
+	})
+	It(`creates occurrences per object and diagnostic`, func() {
+		report := importFile(&kubelinter.Options{BaseURL: "https://github.com/my-org/my-repo/blob/main/", SourceRoot: "/src"})
+		Expect(report.Occurrences).To(HaveLen(4))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/kube-linter/notes/no-read-only-root-fs"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/deploy/app.yaml"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("default/my-app"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("Deployment"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("deploy/app.yaml"))
+		Expect(*report.Occurrences[1].ID).ToNot(Equal(*occurrence.ID))
+
+		occurrence = report.Occurrences[3]
+		Expect(*occurrence.Context.ResourceName).To(Equal("web"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("Service"))
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`sets the severities of the options`, func() {
+		report := importFile(&kubelinter.Options{
+			Severity:   findingsapiv1.Severity_Low,
+			Severities: map[string]findingsapiv1.Severity{"run-as-non-root": findingsapiv1.Severity_High},
+		})
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("LOW"))
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("HIGH"))
+	})
+	It(`converts reports of unlisted checks`, func() {
+		report, err := kubelinter.Import(strings.NewReader(`{"Reports": [{"Check": "privileged-container", "Remediation": "Do not run privileged containers.",
+			"Diagnostic": {"Message": "container \"app\" is privileged"}, "Object": {"Metadata": {"FilePath": "pod.yaml"},
+			"K8sObject": {"Namespace": "ns", "Name": "pod", "GroupVersionKind": {"Version": "v1", "Kind": "Pod"}}}}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*report.Notes[0].ShortDescription).To(Equal("privileged-container"))
+		Expect(*report.Notes[0].Finding.NextSteps[0].Title).To(Equal("Do not run privileged containers."))
+		Expect(*report.Occurrences[0].Context.ResourceName).To(Equal("ns/pod"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := kubelinter.Import(strings.NewReader(`{"Reports": {}}`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = kubelinter.Import(strings.NewReader(`{"Reports": [{"Diagnostic": {"Message": "m"}}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("reports[0]: the report has no check"))
+	})
+})
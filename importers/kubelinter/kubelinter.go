@@ -0,0 +1,117 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kubelinter converts the JSON reports of kube-linter, i.e. of "kube-linter lint --format json", to notes and
+// occurrences of the misconfigurations of Kubernetes objects. Each check becomes a FINDING note, and each report of
+// an object an occurrence of it.
+package kubelinter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output : The JSON report of kube-linter
+type Output struct {
+
+	// The checks that ran.
+	Checks []Check `json:"Checks"`
+
+	// The objects failing checks.
+	Reports []Report `json:"Reports"`
+
+	Summary *Summary `json:"Summary,omitempty"`
+}
+
+// Check : A check of Kubernetes objects
+type Check struct {
+
+	// The name of the check, e.g. no-read-only-root-fs.
+	Name string `json:"name"`
+
+	Description string `json:"description,omitempty"`
+
+	Remediation string `json:"remediation,omitempty"`
+
+	// The template the check is built from.
+	Template string `json:"template,omitempty"`
+}
+
+// Report : An object failing a check
+type Report struct {
+	Diagnostic Diagnostic `json:"Diagnostic"`
+
+	// The name of the check.
+	Check string `json:"Check"`
+
+	Remediation string `json:"Remediation,omitempty"`
+
+	Object Object `json:"Object"`
+}
+
+// Diagnostic : The reason the object fails the check
+type Diagnostic struct {
+	Message string `json:"Message"`
+}
+
+// Object : A Kubernetes object and the file it is declared in
+type Object struct {
+	Metadata ObjectMetadata `json:"Metadata"`
+
+	K8sObject K8sObject `json:"K8sObject"`
+}
+
+// ObjectMetadata : The file an object is declared in
+type ObjectMetadata struct {
+	FilePath string `json:"FilePath"`
+}
+
+// K8sObject : The identity of a Kubernetes object
+type K8sObject struct {
+	Namespace string `json:"Namespace,omitempty"`
+
+	Name string `json:"Name"`
+
+	GroupVersionKind GroupVersionKind `json:"GroupVersionKind"`
+}
+
+// GroupVersionKind : The API group, version and kind of a Kubernetes object
+type GroupVersionKind struct {
+	Group string `json:"Group,omitempty"`
+
+	Version string `json:"Version"`
+
+	Kind string `json:"Kind"`
+}
+
+// Summary : The outcome of the run
+type Summary struct {
+
+	// Passed or Failed.
+	ChecksStatus string `json:"ChecksStatus"`
+
+	KubeLinterVersion string `json:"KubeLinterVersion,omitempty"`
+}
+
+// Parse : Reads the JSON report of kube-linter
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(output); err != nil {
+		return nil, fmt.Errorf("failed to parse kube-linter report: %s", err.Error())
+	}
+	return
+}
@@ -0,0 +1,85 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// ResourceType_File is the resource type of the context of findings in source files.
+const ResourceType_File = "file"
+
+// FileLocation : Returns the resource URL and the context of a finding in a file. The context names the file, and
+// the file and line in its resource ID when line is positive. The resource URL is the file resolved against baseURL,
+// e.g. https://github.com/my-org/my-repo/blob/main/, pointing at the line; it is nil unless this yields an http or
+// https URL.
+func FileLocation(baseURL string, path string, line int) (resourceURL *string, context *findingsapiv1.Context) {
+	context = &findingsapiv1.Context{ResourceName: core.StringPtr(path), ResourceType: core.StringPtr(ResourceType_File)}
+	if line > 0 {
+		context.ResourceID = core.StringPtr(fmt.Sprintf("%s:%d", path, line))
+	}
+	if resolved := ResolveURL(baseURL, filepath.ToSlash(path)); resolved != "" {
+		if line > 0 {
+			resolved += fmt.Sprintf("#L%d", line)
+		}
+		resourceURL = core.StringPtr(resolved)
+	}
+	return
+}
+
+// ResolveURL : Resolves reference against baseURL, returning "" unless the result is an http or https URL
+func ResolveURL(baseURL string, reference string) string {
+	resolved, err := url.Parse(reference)
+	if err != nil {
+		return ""
+	}
+	if !resolved.IsAbs() {
+		base, err := url.Parse(baseURL)
+		if err != nil || baseURL == "" {
+			return ""
+		}
+		resolved = base.ResolveReference(resolved)
+	}
+	if !IsWebURL(resolved.String()) {
+		return ""
+	}
+	return resolved.String()
+}
+
+// IsWebURL : Reports whether value is an absolute http or https URL, as expected by the URL fields of notes and
+// occurrences
+func IsWebURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// RelativePath : Returns path relative to root when it is inside root, else path unchanged. Both use slashes in the
+// result, e.g. to turn the absolute paths reported by a tool into paths of the repository.
+func RelativePath(root string, path string) string {
+	if root != "" {
+		if relative, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(relative, "..") {
+			path = relative
+		}
+	}
+	return filepath.ToSlash(path)
+}
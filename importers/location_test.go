@@ -0,0 +1,55 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`FileLocation`, func() {
+	It(`links to the line of the file`, func() {
+		resourceURL, context := importers.FileLocation("https://github.com/my-org/my-repo/blob/main/", "cmd/main.go", 12)
+		Expect(*resourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/cmd/main.go#L12"))
+		Expect(*context.ResourceName).To(Equal("cmd/main.go"))
+		Expect(*context.ResourceType).To(Equal(importers.ResourceType_File))
+		Expect(*context.ResourceID).To(Equal("cmd/main.go:12"))
+	})
+	It(`omits the resource URL without web base URL`, func() {
+		resourceURL, context := importers.FileLocation("", "cmd/main.go", 0)
+		Expect(resourceURL).To(BeNil())
+		Expect(context.ResourceID).To(BeNil())
+
+		resourceURL, _ = importers.FileLocation("file:///src/", "cmd/main.go", 1)
+		Expect(resourceURL).To(BeNil())
+
+		resourceURL, _ = importers.FileLocation("", "https://example.com/main.go", 3)
+		Expect(*resourceURL).To(Equal("https://example.com/main.go#L3"))
+	})
+	It(`makes paths relative to their root`, func() {
+		Expect(importers.RelativePath("/src/repo", "/src/repo/cmd/main.go")).To(Equal("cmd/main.go"))
+		Expect(importers.RelativePath("/src/repo", "/other/main.go")).To(Equal("/other/main.go"))
+		Expect(importers.RelativePath("", "cmd/main.go")).To(Equal("cmd/main.go"))
+	})
+	It(`recognizes web URLs`, func() {
+		Expect(importers.IsWebURL("https://example.com")).To(BeTrue())
+		Expect(importers.IsWebURL("http://example.com/a")).To(BeTrue())
+		Expect(importers.IsWebURL("example.com")).To(BeFalse())
+		Expect(importers.IsWebURL("mailto:a@example.com")).To(BeFalse())
+	})
+})
@@ -0,0 +1,252 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nmap
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// ResourceType_Host is the resource type of the context of findings on scanned hosts.
+const ResourceType_Host = "Host"
+
+// Direction_Inbound is the direction of the network connections of the occurrences, from the network to the
+// scanned port.
+const Direction_Inbound = "inbound"
+
+// The IDs of the notes of the occurrences.
+const (
+
+	// The note of the open ports of scans without allowlist.
+	OpenPortNoteID = "open-port"
+
+	// The note of the open ports missing from the allowlist.
+	UnexpectedServiceNoteID = "unexpected-service"
+)
+
+// webServices are the names of the services whose port has a web URL.
+var webServices = map[string]bool{"http": true, "https": true, "http-alt": true, "https-alt": true}
+
+// Expected : A service expected to be open, matching the ports that match all its fields
+type Expected struct {
+
+	// The IP address, CIDR range, e.g. 10.0.0.0/24, or host name of the hosts. Matches every host when empty.
+	Host string `json:"host,omitempty"`
+
+	// tcp, udp or sctp. Matches every protocol when empty.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Matches every port when 0.
+	Port int `json:"port,omitempty"`
+
+	// The name of the service detected by Nmap, e.g. https. Matches every service when empty.
+	Service string `json:"service,omitempty"`
+}
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The services expected to be open. When set, the ports of expected services are skipped and the other open
+	// ports are unexpected services; when empty, every open port is reported.
+	Allowlist []Expected
+
+	// Report the ports in the open|filtered state, e.g. the UDP ports that didn't answer, as open.
+	IncludeOpenFiltered bool
+
+	// The severity of unexpected services. Defaults to HIGH.
+	Severity findingsapiv1.Severity
+}
+
+// Import : Reads the XML report of Nmap and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	run, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(run, opts)
+}
+
+// Convert : Converts the open ports of the scanned hosts to occurrences of the open-port note, MEDIUM, when the
+// options have no allowlist, and the open ports missing from the allowlist to occurrences of the unexpected-service
+// note, HIGH by default.
+//
+// Occurrences are identified by the IP address, protocol and port, so that the next scan replaces them. Their
+// network connection is inbound, with the protocol of the port and the IP address and port as server. Their
+// context names the host, with its IP address as resource ID, and web services have their URL as resource URL.
+// The provider defaults to nmap.
+func Convert(run *Run, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(run, "run cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("nmap")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "Nmap", "https://nmap.org"))
+
+	for i := range run.Hosts {
+		host := &run.Hosts[i]
+		address := host.Address()
+		for j := range host.Ports {
+			port := &host.Ports[j]
+			if !opts.isOpen(port) {
+				continue
+			}
+			if address == "" {
+				return nil, fmt.Errorf("hosts[%d]: the host has no IP address", i)
+			}
+			noteID := OpenPortNoteID
+			if len(opts.Allowlist) > 0 {
+				if opts.isExpected(host, port) {
+					continue
+				}
+				noteID = UnexpectedServiceNoteID
+			}
+			report.AddNote(opts.note(noteID))
+
+			occurrence := findingsapiv1.ApiOccurrence{
+				ID:          core.StringPtr(importers.StableID(report.ProviderID, address, port.Protocol, strconv.Itoa(port.PortID))),
+				NoteName:    core.StringPtr(report.NoteName(noteID)),
+				Kind:        core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+				ResourceURL: port.url(host),
+				Remediation: core.StringPtr(port.remediation(host, noteID)),
+				Context: opts.OccurrenceContext(&findingsapiv1.Context{
+					ResourceName: core.StringPtr(host.Name()),
+					ResourceType: core.StringPtr(ResourceType_Host),
+					ResourceID:   core.StringPtr(address),
+				}),
+				Finding: &findingsapiv1.Finding{
+					NetworkConnection: &findingsapiv1.NetworkConnection{
+						Direction: core.StringPtr(Direction_Inbound),
+						Protocol:  importers.StringPtr(port.Protocol),
+						Server:    &findingsapiv1.SocketAddress{Address: core.StringPtr(address), Port: core.Int64Ptr(int64(port.PortID))},
+					},
+				},
+			}
+			report.AddOccurrence(occurrence)
+		}
+	}
+	return
+}
+
+// Matches : Reports whether the port of the host is an expected service
+func (expected *Expected) Matches(host *Host, port *Port) bool {
+	switch {
+	case expected.Protocol != "" && !strings.EqualFold(expected.Protocol, port.Protocol):
+		return false
+	case expected.Port != 0 && expected.Port != port.PortID:
+		return false
+	case expected.Service != "" && (port.Service == nil || !strings.EqualFold(expected.Service, port.Service.Name)):
+		return false
+	case expected.Host != "" && !expected.matchesHost(host):
+		return false
+	default:
+		return true
+	}
+}
+
+// matchesHost reports whether one of the addresses or names of the host matches the host of the expected service.
+func (expected *Expected) matchesHost(host *Host) bool {
+	_, network, _ := net.ParseCIDR(expected.Host)
+	for _, address := range host.Addresses {
+		if address.Addr == expected.Host {
+			return true
+		}
+		if ip := net.ParseIP(address.Addr); network != nil && ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	for _, hostname := range host.Hostnames {
+		if strings.EqualFold(hostname.Name, expected.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOpen reports whether the port is reported as open.
+func (options *Options) isOpen(port *Port) bool {
+	return port.State.State == PortState_Open || (options.IncludeOpenFiltered && port.State.State == PortState_OpenFiltered)
+}
+
+// isExpected reports whether the port of the host matches the allowlist.
+func (options *Options) isExpected(host *Host, port *Port) bool {
+	for i := range options.Allowlist {
+		if options.Allowlist[i].Matches(host, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// note returns the FINDING note with the given ID.
+func (options *Options) note(noteID string) findingsapiv1.ApiNote {
+	if noteID == OpenPortNoteID {
+		return findingsapiv1.ApiNote{
+			ID:               core.StringPtr(OpenPortNoteID),
+			Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+			ShortDescription: core.StringPtr("Open port"),
+			LongDescription: core.StringPtr("Nmap found an open port. Make sure the service listening on it is expected " +
+				"to be reachable from the network the scan ran from."),
+			Finding: &findingsapiv1.FindingType{Severity: importers.SeverityPtr(findingsapiv1.Severity_Medium)},
+		}
+	}
+	severity := options.Severity
+	if severity == "" {
+		severity = findingsapiv1.Severity_High
+	}
+	return findingsapiv1.ApiNote{
+		ID:               core.StringPtr(UnexpectedServiceNoteID),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr("Unexpected service"),
+		LongDescription:  core.StringPtr("Nmap found an open port missing from the allowlist of expected services."),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(severity)},
+	}
+}
+
+// url returns the URL of web services, nil for other services.
+func (port *Port) url(host *Host) *string {
+	if port.Service == nil || !webServices[port.Service.Name] {
+		return nil
+	}
+	scheme := "http"
+	if strings.HasPrefix(port.Service.Name, "https") || port.Service.Tunnel == "ssl" {
+		scheme = "https"
+	}
+	return core.StringPtr(fmt.Sprintf("%s://%s/", scheme, net.JoinHostPort(host.Name(), strconv.Itoa(port.PortID))))
+}
+
+// remediation returns the remediation of the open port of the host.
+func (port *Port) remediation(host *Host, noteID string) string {
+	service := "the service"
+	if port.Service != nil && port.Service.Name != "" {
+		service = port.Service.Description()
+	}
+	if noteID == OpenPortNoteID {
+		return fmt.Sprintf("Close port %d/%s on %s unless %s is expected to be reachable", port.PortID, port.Protocol, host.Name(), service)
+	}
+	return fmt.Sprintf("Close port %d/%s on %s, or add %s to the allowlist of expected services", port.PortID, port.Protocol, host.Name(), service)
+}
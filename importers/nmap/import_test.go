@@ -0,0 +1,151 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nmap_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/nmap"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *nmap.Options) *importers.Report {
+		file, err := os.Open("testdata/scan.xml")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := nmap.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+	allowlist := []nmap.Expected{
+		{Host: "web.example.com", Protocol: "tcp", Port: 443},
+		{Host: "10.0.0.0/24", Service: "ssh"},
+		{Host: "10.0.0.9", Port: 5432, Service: "mysql"},
+	}
+
+	It(`reports every open port without allowlist`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("nmap"))
+		Expect(*report.ReportedBy.Title).To(Equal("Nmap"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(1))
+		Expect(*report.Notes[0].ID).To(Equal(nmap.OpenPortNoteID))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("MEDIUM"))
+		Expect(report.Occurrences).To(HaveLen(4))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/nmap/notes/open-port"))
+		Expect(occurrence.ResourceURL).To(BeNil())
+		Expect(*occurrence.Remediation).To(Equal("Close port 22/tcp on web.example.com unless ssh (OpenSSH 8.2p1 Ubuntu 4ubuntu0.5) is expected to be reachable"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("web.example.com"))
+		Expect(*occurrence.Context.ResourceType).To(Equal(nmap.ResourceType_Host))
+		Expect(*occurrence.Context.ResourceID).To(Equal("10.0.0.5"))
+		connection := occurrence.Finding.NetworkConnection
+		Expect(*connection.Direction).To(Equal(nmap.Direction_Inbound))
+		Expect(*connection.Protocol).To(Equal("tcp"))
+		Expect(connection.Client).To(BeNil())
+		Expect(*connection.Server.Address).To(Equal("10.0.0.5"))
+		Expect(*connection.Server.Port).To(Equal(int64(22)))
+
+		Expect(*report.Occurrences[1].ResourceURL).To(Equal("http://web.example.com:80/"))
+		Expect(*report.Occurrences[2].ResourceURL).To(Equal("https://web.example.com:443/"))
+		Expect(*report.Occurrences[2].Remediation).To(ContainSubstring("https (nginx 1.18.0)"))
+		Expect(*report.Occurrences[3].Context.ResourceName).To(Equal("db.internal"))
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`reports the open ports missing from the allowlist`, func() {
+		report := importFile(&nmap.Options{Allowlist: allowlist})
+		Expect(report.Notes).To(HaveLen(1))
+		Expect(*report.Notes[0].ID).To(Equal(nmap.UnexpectedServiceNoteID))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("HIGH"))
+		Expect(report.Occurrences).To(HaveLen(2))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/nmap/notes/unexpected-service"))
+		Expect(*occurrence.Finding.NetworkConnection.Server.Port).To(Equal(int64(80)))
+		Expect(*occurrence.Remediation).To(Equal("Close port 80/tcp on web.example.com, or add http (nginx 1.18.0) to the allowlist of expected services"))
+		Expect(*report.Occurrences[1].Finding.NetworkConnection.Server.Port).To(Equal(int64(5432)))
+		Expect(*report.Occurrences[1].ID).To(Equal(*importFile(nil).Occurrences[3].ID))
+	})
+	DescribeTable(`reports the open ports missing from the allowlist`,
+		func(host string, opts *nmap.Options, expected []string) {
+			report, err := nmap.Import(strings.NewReader(`<nmaprun>`+host+`</nmaprun>`), opts)
+			Expect(err).To(BeNil())
+			Expect(report.Validate()).To(Succeed())
+			reported := []string{}
+			for _, occurrence := range report.Occurrences {
+				connection := occurrence.Finding.NetworkConnection
+				reported = append(reported, fmt.Sprintf("%d/%s", *connection.Server.Port, *connection.Protocol))
+			}
+			Expect(reported).To(Equal(expected))
+		},
+		Entry(`IPv4 networks`, `<host><status state="up"/><address addr="10.0.0.7" addrtype="ipv4"/><ports>
+				<port protocol="tcp" portid="22"><state state="open"/><service name="ssh"/></port>
+				<port protocol="tcp" portid="23"><state state="open"/><service name="telnet"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Host: "10.0.0.0/24", Service: "ssh"}, {Host: "10.0.1.0/24"}}},
+			[]string{"23/tcp"}),
+		Entry(`IPv6 networks`, `<host><status state="up"/><address addr="fd00::5" addrtype="ipv6"/><ports>
+				<port protocol="tcp" portid="443"><state state="open"/><service name="https"/></port>
+				<port protocol="tcp" portid="8443"><state state="open"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Host: "fd00::/64", Port: 443}}},
+			[]string{"8443/tcp"}),
+		Entry(`hostnames, ignoring the case`, `<host><status state="up"/><address addr="10.0.0.5" addrtype="ipv4"/>
+				<hostnames><hostname name="Web.Example.com" type="user"/></hostnames><ports>
+				<port protocol="tcp" portid="443"><state state="open"/><service name="https"/></port>
+				<port protocol="tcp" portid="80"><state state="open"/><service name="http"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Host: "web.example.com", Protocol: "TCP", Service: "HTTPS"}, {Host: "db.example.com"}}},
+			[]string{"80/tcp"}),
+		Entry(`addresses of other hosts`, `<host><status state="up"/><address addr="10.0.0.9" addrtype="ipv4"/><ports>
+				<port protocol="tcp" portid="5432"><state state="open"/><service name="postgresql"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Host: "10.0.0.90"}, {Host: "10.0.0.9", Service: "mysql"}}},
+			[]string{"5432/tcp"}),
+		Entry(`open|filtered ports left out by default`, `<host><status state="up"/><address addr="10.0.0.5" addrtype="ipv4"/><ports>
+				<port protocol="udp" portid="161"><state state="open|filtered"/><service name="snmp"/></port>
+				<port protocol="tcp" portid="25"><state state="filtered"/></port>
+				<port protocol="tcp" portid="110"><state state="closed"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Service: "ssh"}}},
+			[]string{}),
+		Entry(`open|filtered ports on demand`, `<host><status state="up"/><address addr="10.0.0.5" addrtype="ipv4"/><ports>
+				<port protocol="udp" portid="161"><state state="open|filtered"/><service name="snmp"/></port>
+				<port protocol="udp" portid="53"><state state="open|filtered"/><service name="domain"/></port>
+				<port protocol="tcp" portid="25"><state state="filtered"/></port></ports></host>`,
+			&nmap.Options{Allowlist: []nmap.Expected{{Protocol: "udp", Port: 53}}, IncludeOpenFiltered: true},
+			[]string{"161/udp"}),
+	)
+	It(`matches expected services`, func() {
+		host := &nmap.Host{Addresses: []nmap.Address{{Addr: "fd00::5", AddrType: "ipv6"}}}
+		Expect((&nmap.Expected{}).Matches(host, &nmap.Port{Protocol: "tcp", PortID: 443})).To(BeTrue())
+		Expect((&nmap.Expected{Service: "ssh"}).Matches(host, &nmap.Port{Protocol: "tcp", PortID: 22})).To(BeFalse())
+		Expect(host.Address()).To(Equal("fd00::5"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := nmap.Import(strings.NewReader(`{"hosts": []}`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = nmap.Import(strings.NewReader(`<nmaprun><host><status state="up"/><address addr="02:42:AC:11:00:02" addrtype="mac"/>
+			<ports><port protocol="tcp" portid="22"><state state="open"/></port></ports></host></nmaprun>`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("hosts[0]: the host has no IP address"))
+	})
+})
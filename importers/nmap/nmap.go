@@ -0,0 +1,187 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package nmap converts the XML reports of Nmap, i.e. of "nmap -oX", to occurrences of the open ports of the scanned
+// hosts, with the port as the server of their network connection. An allowlist of expected services tells the ports
+// that are expected to be open from the unexpected ones.
+package nmap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Constants associated with the PortState.State property, the states of the ports Nmap reports.
+// - open&#58; An application accepts connections on the port.
+// - open|filtered&#58; The port is open or filtered, e.g. a UDP port that didn't answer.
+// - closed&#58; The port is reachable but no application listens on it.
+// - filtered&#58; A firewall prevents Nmap from telling whether the port is open.
+const (
+	PortState_Open         = "open"
+	PortState_OpenFiltered = "open|filtered"
+	PortState_Closed       = "closed"
+	PortState_Filtered     = "filtered"
+)
+
+// Run : The XML report of Nmap
+type Run struct {
+	XMLName xml.Name `xml:"nmaprun"`
+
+	Scanner string `xml:"scanner,attr"`
+
+	// The command line of the scan.
+	Args string `xml:"args,attr"`
+
+	// The start of the scan, in seconds since the epoch.
+	Start int64 `xml:"start,attr"`
+
+	Version string `xml:"version,attr"`
+
+	Hosts []Host `xml:"host"`
+}
+
+// Host : A scanned host
+type Host struct {
+	Status Status `xml:"status"`
+
+	Addresses []Address `xml:"address"`
+
+	Hostnames []Hostname `xml:"hostnames>hostname"`
+
+	Ports []Port `xml:"ports>port"`
+}
+
+// Status : Whether a host is up
+type Status struct {
+
+	// up, down or unknown.
+	State string `xml:"state,attr"`
+
+	Reason string `xml:"reason,attr"`
+}
+
+// Address : An address of a host
+type Address struct {
+	Addr string `xml:"addr,attr"`
+
+	// ipv4, ipv6 or mac.
+	AddrType string `xml:"addrtype,attr"`
+}
+
+// Hostname : A name of a host
+type Hostname struct {
+	Name string `xml:"name,attr"`
+
+	// user for the names of the command line, PTR for the names of reverse DNS lookups.
+	Type string `xml:"type,attr"`
+}
+
+// Port : A scanned port of a host
+type Port struct {
+
+	// tcp, udp or sctp.
+	Protocol string `xml:"protocol,attr"`
+
+	PortID int `xml:"portid,attr"`
+
+	State PortState `xml:"state"`
+
+	// The service detected on the port, nil when Nmap didn't guess one.
+	Service *Service `xml:"service"`
+}
+
+// PortState : The state of a port
+type PortState struct {
+
+	// One of the PortState constants.
+	State string `xml:"state,attr"`
+
+	Reason string `xml:"reason,attr"`
+}
+
+// Service : The service listening on a port
+type Service struct {
+
+	// The name of the service, e.g. ssh or http.
+	Name string `xml:"name,attr"`
+
+	Product string `xml:"product,attr"`
+
+	Version string `xml:"version,attr"`
+
+	ExtraInfo string `xml:"extrainfo,attr"`
+
+	// ssl when the service is wrapped in TLS.
+	Tunnel string `xml:"tunnel,attr"`
+
+	// probed when the service answered the probes of Nmap, table when it is guessed from the port number.
+	Method string `xml:"method,attr"`
+
+	// The confidence of the detection, from 0 to 10.
+	Conf int `xml:"conf,attr"`
+}
+
+// Parse : Reads the XML report of Nmap
+func Parse(r io.Reader) (run *Run, err error) {
+	run = new(Run)
+	if err = xml.NewDecoder(r).Decode(run); err != nil {
+		return nil, fmt.Errorf("failed to parse Nmap report: %s", err.Error())
+	}
+	return
+}
+
+// Address : Returns the IP address of the host, its IPv4 address when it has one, "" when it has none
+func (host *Host) Address() string {
+	address := ""
+	for _, candidate := range host.Addresses {
+		switch candidate.AddrType {
+		case "ipv4":
+			return candidate.Addr
+		case "ipv6":
+			if address == "" {
+				address = candidate.Addr
+			}
+		}
+	}
+	return address
+}
+
+// Name : Returns the name of the host, the name of the command line first, else its IP address
+func (host *Host) Name() string {
+	for _, hostnameType := range []string{"user", "PTR"} {
+		for _, hostname := range host.Hostnames {
+			if hostname.Type == hostnameType && hostname.Name != "" {
+				return hostname.Name
+			}
+		}
+	}
+	return host.Address()
+}
+
+// Description : Describes the service, e.g. "https (nginx 1.18.0)"
+func (service *Service) Description() string {
+	name := service.Name
+	if service.Tunnel == "ssl" && name == "http" {
+		name = "https"
+	}
+	product := strings.TrimSpace(service.Product + " " + service.Version)
+	if product == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, product)
+}
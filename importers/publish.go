@@ -0,0 +1,172 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// occurrencesPageSize is the number of occurrences listed per request while pruning.
+const occurrencesPageSize = 100
+
+// PublishOptions : The Publish options.
+type PublishOptions struct {
+
+	// Plan the notes and validate the occurrences without changing anything.
+	DryRun bool
+
+	// Delete the occurrences and notes of the provider missing from the report once its occurrences are published,
+	// for example the findings fixed since the previous import. Every occurrence and note of the provider is
+	// considered, so only prune providers filled by a single report.
+	Prune bool
+}
+
+// PublishResult : The outcome of Publish
+type PublishResult struct {
+
+	// The changes to the notes of the provider. Notes of the provider missing from the report are deleted when
+	// pruning, and kept otherwise.
+	Notes *findingsapiv1.NotesPlan
+
+	// The number of occurrences created or replaced, 0 for a dry run.
+	Occurrences int
+
+	// The occurrences of the provider missing from the report, listed when pruning.
+	Stale []findingsapiv1.ApiOccurrence
+
+	// The number of stale occurrences deleted, 0 for a dry run.
+	Pruned int
+}
+
+// Publish : Validates the report, creates or updates its notes with PlanNotes and ApplyNotes, then creates its
+// occurrences with Replace-If-Exists so that the occurrences of a previous import with the same ID are replaced.
+// Occurrences and notes of a previous import missing from the report are kept unless opts.Prune is set, in which
+// case they are deleted last, the occurrences before the notes they may reference. Stops at the first failure.
+func Publish(ctx context.Context, findingsApi *findingsapiv1.FindingsApiV1, accountID string, report *Report, opts *PublishOptions) (result *PublishResult, err error) {
+	err = core.ValidateNotNil(report, "report cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &PublishOptions{}
+	}
+
+	occurrences, errs := report.createOccurrenceOptions(accountID)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	plan, err := findingsApi.PlanNotes(ctx, accountID, report.Bundle(), &findingsapiv1.NotesPlanOptions{Prune: opts.Prune})
+	if err != nil {
+		return
+	}
+	result = &PublishResult{Notes: plan}
+	if opts.Prune {
+		result.Stale, err = staleOccurrences(ctx, findingsApi, accountID, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.DryRun {
+		return
+	}
+	upserts, deletions := splitNoteDeletions(plan)
+	if err = findingsApi.ApplyNotes(ctx, accountID, upserts); err != nil {
+		return
+	}
+	for _, options := range occurrences {
+		if _, _, err = findingsApi.CreateOccurrenceWithContext(ctx, options); err != nil {
+			return result, fmt.Errorf("failed to publish occurrence %s: %s", *options.ID, err.Error())
+		}
+		result.Occurrences++
+	}
+	for _, occurrence := range result.Stale {
+		options := findingsApi.NewDeleteOccurrenceOptions(accountID, report.ProviderID, StringValue(occurrence.ID))
+		if _, err = findingsApi.DeleteOccurrenceWithContext(ctx, options); err != nil {
+			return result, fmt.Errorf("failed to prune occurrence %s: %s", *options.OccurrenceID, err.Error())
+		}
+		result.Pruned++
+	}
+	err = findingsApi.ApplyNotes(ctx, accountID, deletions)
+	return
+}
+
+// splitNoteDeletions splits the plan into the creations and updates, and the deletions applied once the stale
+// occurrences are deleted.
+func splitNoteDeletions(plan *findingsapiv1.NotesPlan) (upserts *findingsapiv1.NotesPlan, deletions *findingsapiv1.NotesPlan) {
+	upserts = &findingsapiv1.NotesPlan{ProviderID: plan.ProviderID}
+	deletions = &findingsapiv1.NotesPlan{ProviderID: plan.ProviderID}
+	for _, change := range plan.Changes {
+		if change.Action == findingsapiv1.NoteChange_Action_Delete {
+			deletions.Changes = append(deletions.Changes, change)
+		} else {
+			upserts.Changes = append(upserts.Changes, change)
+		}
+	}
+	return
+}
+
+// staleOccurrences lists the occurrences of the provider, page by page, keeping those missing from the report.
+func staleOccurrences(ctx context.Context, findingsApi *findingsapiv1.FindingsApiV1, accountID string, report *Report) (stale []findingsapiv1.ApiOccurrence, err error) {
+	reported := make(map[string]bool, len(report.Occurrences))
+	for _, occurrence := range report.Occurrences {
+		reported[StringValue(occurrence.ID)] = true
+	}
+	options := findingsApi.NewListOccurrencesOptions(accountID, report.ProviderID)
+	options.PageSize = core.Int64Ptr(occurrencesPageSize)
+	for {
+		result, _, listErr := findingsApi.ListOccurrencesWithContext(ctx, options)
+		if listErr != nil {
+			return nil, fmt.Errorf("failed to list the occurrences of provider %s: %s", report.ProviderID, listErr.Error())
+		}
+		for _, occurrence := range result.Occurrences {
+			if !reported[StringValue(occurrence.ID)] {
+				stale = append(stale, occurrence)
+			}
+		}
+		if StringValue(result.NextPageToken) == "" {
+			return
+		}
+		options.PageToken = result.NextPageToken
+	}
+}
+
+// CreateOccurrenceOptions : Returns options creating or replacing the occurrence in the given account. Note names
+// without account ID are prefixed with accountID.
+func CreateOccurrenceOptions(accountID string, providerID string, occurrence *findingsapiv1.ApiOccurrence) *findingsapiv1.CreateOccurrenceOptions {
+	noteName := StringValue(occurrence.NoteName)
+	if noteAccountID, noteProviderID, noteID, err := findingsapiv1.ParseNoteName(noteName); err == nil && noteAccountID == "" {
+		noteName = findingsapiv1.FormatNoteName(accountID, noteProviderID, noteID)
+	}
+	return &findingsapiv1.CreateOccurrenceOptions{
+		AccountID:       core.StringPtr(accountID),
+		ProviderID:      core.StringPtr(providerID),
+		NoteName:        core.StringPtr(noteName),
+		Kind:            occurrence.Kind,
+		ID:              occurrence.ID,
+		ResourceURL:     occurrence.ResourceURL,
+		Remediation:     occurrence.Remediation,
+		Context:         occurrence.Context,
+		Finding:         occurrence.Finding,
+		Kpi:             occurrence.Kpi,
+		ReplaceIfExists: core.BoolPtr(true),
+	}
+}
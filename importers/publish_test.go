@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Publish`, func() {
+	var requests []string
+	var bodies []map[string]interface{}
+	var testService *findingsapiv1.FindingsApiV1
+	var testServer *httptest.Server
+	var report *importers.Report
+	BeforeEach(func() {
+		requests, bodies = nil, nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			request := req.Method + " " + strings.TrimPrefix(req.URL.Path, "/v1/account/providers/my-tool")
+			if replace := req.Header.Get("Replace-If-Exists"); replace != "" {
+				request += " Replace-If-Exists: " + replace
+			}
+			requests = append(requests, request)
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			switch {
+			case request == "GET /notes":
+				res.Write([]byte(`{"notes": [{"id": "existing", "kind": "FINDING", "short_description": "Existing",
+					"long_description": "Existing", "reported_by": {"id": "my-tool", "title": "My tool"}, "finding": {"severity": "LOW"}}]}`))
+				return
+			case request == "GET /occurrences" && req.URL.Query().Get("page_token") == "":
+				res.Write([]byte(`{"occurrences": [{"id": "` + importers.StableID("rule", "main.go") + `"}, {"id": "fixed"}],
+					"next_page_token": "next"}`))
+				return
+			case request == "GET /occurrences":
+				res.Write([]byte(`{"occurrences": [{"id": "moved"}]}`))
+				return
+			case req.Method == "DELETE":
+				return
+			}
+			var body map[string]interface{}
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			bodies = append(bodies, body)
+			res.Write([]byte(`{}`))
+		}))
+		var err error
+		testService, err = findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		report = importers.NewReport("my-tool", &findingsapiv1.Reporter{ID: core.StringPtr("my-tool"), Title: core.StringPtr("My tool")})
+		report.AddNote(findingsapiv1.ApiNote{
+			ID:               core.StringPtr("rule"),
+			Kind:             core.StringPtr("FINDING"),
+			ShortDescription: core.StringPtr("Rule"),
+			LongDescription:  core.StringPtr("A rule was violated"),
+			Finding:          &findingsapiv1.FindingType{Severity: core.StringPtr("HIGH")},
+		})
+		report.AddOccurrence(findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr(importers.StableID("rule", "main.go")),
+			NoteName: core.StringPtr(report.NoteName("rule")),
+			Kind:     core.StringPtr("FINDING"),
+			Finding:  &findingsapiv1.Finding{},
+		})
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`creates the notes, then replaces the occurrences`, func() {
+		result, err := importers.Publish(context.Background(), testService, "account", report, nil)
+		Expect(err).To(BeNil())
+		Expect(result.Occurrences).To(Equal(1))
+		Expect(result.Notes.Changes).To(HaveLen(1))
+		Expect(result.Notes.Changes[0].Action).To(Equal(findingsapiv1.NoteChange_Action_Create))
+		Expect(requests).To(Equal([]string{
+			"GET /notes",
+			"POST /notes",
+			"POST /occurrences Replace-If-Exists: true",
+		}))
+		Expect(bodies[1]["note_name"]).To(Equal("account/providers/my-tool/notes/rule"))
+		Expect(bodies[1]["id"]).To(Equal(importers.StableID("rule", "main.go")))
+	})
+	It(`only plans the notes for a dry run`, func() {
+		result, err := importers.Publish(context.Background(), testService, "account", report, &importers.PublishOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(result.Occurrences).To(Equal(0))
+		Expect(result.Notes.HasChanges()).To(BeTrue())
+		Expect(requests).To(Equal([]string{"GET /notes"}))
+	})
+	It(`keeps the occurrences and notes missing from the report unless pruning`, func() {
+		result, err := importers.Publish(context.Background(), testService, "account", report, &importers.PublishOptions{Prune: true})
+		Expect(err).To(BeNil())
+		Expect(result.Stale).To(HaveLen(2))
+		Expect(result.Pruned).To(Equal(2))
+		Expect(requests).To(Equal([]string{
+			"GET /notes",
+			"GET /occurrences",
+			"GET /occurrences",
+			"POST /notes",
+			"POST /occurrences Replace-If-Exists: true",
+			"DELETE /occurrences/fixed",
+			"DELETE /occurrences/moved",
+			"DELETE /notes/existing",
+		}))
+		Expect(result.Notes.Changes[1].Action).To(Equal(findingsapiv1.NoteChange_Action_Delete))
+	})
+	It(`lists the stale occurrences and notes without deleting them for a dry run`, func() {
+		result, err := importers.Publish(context.Background(), testService, "account", report, &importers.PublishOptions{DryRun: true, Prune: true})
+		Expect(err).To(BeNil())
+		Expect(*result.Stale[0].ID).To(Equal("fixed"))
+		Expect(*result.Stale[1].ID).To(Equal("moved"))
+		Expect(result.Pruned).To(Equal(0))
+		Expect(result.Notes.Changes[1].NoteID).To(Equal("existing"))
+		Expect(requests).To(Equal([]string{"GET /notes", "GET /occurrences", "GET /occurrences"}))
+	})
+	It(`validates the occurrences before changing anything`, func() {
+		report.Occurrences[0].ResourceURL = core.StringPtr("main.go")
+		report.Occurrences[0].Finding = nil
+
+		_, err := importers.Publish(context.Background(), testService, "account", report, nil)
+		Expect(err).To(BeAssignableToTypeOf(findingsapiv1.ValidationErrors{}))
+		Expect(err.Error()).To(ContainSubstring("occurrences[0].resource_url"))
+		Expect(err.Error()).To(ContainSubstring("occurrences[0].finding"))
+		Expect(requests).To(BeEmpty())
+	})
+	It(`requires a report`, func() {
+		_, err := importers.Publish(context.Background(), testService, "account", nil, nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
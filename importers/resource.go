@@ -0,0 +1,57 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// ResourceLocation : Returns the resource URL and the context of a finding in a resource declared in a file, e.g. a
+// Terraform resource or a Kubernetes object. The context names the resource and its type, and has the file, and the
+// line when positive, in its resource ID. The resource URL is the one of FileLocation. Returns the location of the
+// file when name is empty.
+func ResourceLocation(baseURL string, path string, line int, name string, resourceType string) (resourceURL *string, context *findingsapiv1.Context) {
+	resourceURL, context = FileLocation(baseURL, path, line)
+	if name == "" {
+		return
+	}
+	if context.ResourceID == nil {
+		context.ResourceID = StringPtr(path)
+	}
+	context.ResourceName = core.StringPtr(name)
+	context.ResourceType = StringPtr(resourceType)
+	return
+}
+
+// TerraformResourceType : Returns the type of the resource of a Terraform address, e.g. aws_s3_bucket for
+// module.storage.aws_s3_bucket.logs[0] or data.aws_iam_policy_document.assume, "" when the address has no type
+func TerraformResourceType(address string) string {
+	parts := strings.Split(address, ".")
+	for len(parts) >= 2 && parts[0] == "module" {
+		parts = parts[2:]
+	}
+	if len(parts) > 2 && parts[0] == "data" {
+		parts = parts[1:]
+	}
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
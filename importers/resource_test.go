@@ -0,0 +1,51 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Resources`, func() {
+	It(`locates resources in files`, func() {
+		resourceURL, context := importers.ResourceLocation("https://github.com/my-org/infra/blob/main/", "s3.tf", 12, "aws_s3_bucket.logs", "aws_s3_bucket")
+		Expect(*resourceURL).To(Equal("https://github.com/my-org/infra/blob/main/s3.tf#L12"))
+		Expect(*context.ResourceName).To(Equal("aws_s3_bucket.logs"))
+		Expect(*context.ResourceType).To(Equal("aws_s3_bucket"))
+		Expect(*context.ResourceID).To(Equal("s3.tf:12"))
+
+		resourceURL, context = importers.ResourceLocation("", "deploy/app.yaml", 0, "default/my-app", "Deployment")
+		Expect(resourceURL).To(BeNil())
+		Expect(*context.ResourceName).To(Equal("default/my-app"))
+		Expect(*context.ResourceID).To(Equal("deploy/app.yaml"))
+
+		_, context = importers.ResourceLocation("", "main.tf", 3, "", "")
+		Expect(*context.ResourceName).To(Equal("main.tf"))
+		Expect(*context.ResourceType).To(Equal(importers.ResourceType_File))
+	})
+	It(`returns the type of Terraform addresses`, func() {
+		Expect(importers.TerraformResourceType("aws_s3_bucket.logs")).To(Equal("aws_s3_bucket"))
+		Expect(importers.TerraformResourceType("aws_s3_bucket.logs[0]")).To(Equal("aws_s3_bucket"))
+		Expect(importers.TerraformResourceType("module.storage.aws_s3_bucket.logs")).To(Equal("aws_s3_bucket"))
+		Expect(importers.TerraformResourceType("module.a.module.b[\"x\"].google_storage_bucket.b")).To(Equal("google_storage_bucket"))
+		Expect(importers.TerraformResourceType("data.aws_iam_policy_document.assume")).To(Equal("aws_iam_policy_document"))
+		Expect(importers.TerraformResourceType("module.storage")).To(Equal(""))
+		Expect(importers.TerraformResourceType("logs")).To(Equal(""))
+	})
+})
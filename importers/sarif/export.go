@@ -0,0 +1,301 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// OccurrenceFingerprint is the name of the fingerprint holding the ID of the exported occurrence.
+const OccurrenceFingerprint = "securityAdvisorOccurrenceId/v1"
+
+// urlLine matches the line of a resource URL, e.g. "#L12".
+var urlLine = regexp.MustCompile(`#L(\d+)$`)
+
+// resourceIDLine matches the line of a resource ID, e.g. "main.go:12".
+var resourceIDLine = regexp.MustCompile(`:(\d+)$`)
+
+// securitySeverities are the "security-severity" scores of the exported rules, within the CVSS range of each
+// severity so that importing the log again yields the same severities.
+var securitySeverities = map[findingsapiv1.Severity]string{
+	findingsapiv1.Severity_Critical: "9.5",
+	findingsapiv1.Severity_High:     "8.0",
+	findingsapiv1.Severity_Medium:   "5.5",
+	findingsapiv1.Severity_Low:      "2.0",
+}
+
+// ExportOptions : The Export options.
+type ExportOptions struct {
+
+	// The name of the tool of the run. Defaults to the title of the reporter of the first note.
+	ToolName string
+
+	// The URL resource URLs are made relative to, e.g. https://github.com/my-org/my-repo/blob/main/. It becomes the
+	// SRCROOT base URI of the run.
+	BaseURL string
+
+	// The provider of the notes. Defaults to the provider of the first FINDING occurrence.
+	ProviderID string
+}
+
+// Export : Converts the FINDING notes of a provider to rules, and its FINDING occurrences to results. Notes and
+// occurrences of other kinds are skipped. Occurrences of notes of other providers are rejected, as the rules are
+// identified by note ID only.
+//
+// The severity of the notes maps to the level of the rules, CRITICAL and HIGH to error, MEDIUM to warning and LOW to
+// note, and to their "security-severity" property. The related URL and next steps of the notes become the help of
+// the rules. The results have the level of the effective severity of the occurrence, their remediation and
+// overridden next steps as fixes, and the fields of their context as properties. Fixes only have a description,
+// as occurrences don't describe changes to files.
+func Export(notes []findingsapiv1.ApiNote, occurrences []findingsapiv1.ApiOccurrence, opts *ExportOptions) (log *Log, err error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	run := Run{Tool: Tool{Driver: ToolComponent{Name: opts.ToolName}}, Results: []Result{}}
+	if opts.BaseURL != "" {
+		run.OriginalURIBaseIDs = map[string]ArtifactLocation{"SRCROOT": {URI: opts.BaseURL}}
+	}
+
+	ruleIndexes := make(map[string]int)
+	notesByID := make(map[string]*findingsapiv1.ApiNote)
+	for i := range notes {
+		note := &notes[i]
+		if importers.StringValue(note.Kind) != string(findingsapiv1.ApiNoteKind_Finding) {
+			continue
+		}
+		if run.Tool.Driver.Name == "" && note.ReportedBy != nil {
+			run.Tool.Driver.Name = importers.StringValue(note.ReportedBy.Title)
+			run.Tool.Driver.InformationURI = importers.StringValue(note.ReportedBy.URL)
+		}
+		noteID := importers.StringValue(note.ID)
+		ruleIndexes[noteID] = len(run.Tool.Driver.Rules)
+		notesByID[noteID] = note
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, noteRule(note))
+	}
+
+	providerID := opts.ProviderID
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		if importers.StringValue(occurrence.Kind) != string(findingsapiv1.ApiNoteKind_Finding) {
+			continue
+		}
+		_, noteProviderID, noteID, parseErr := findingsapiv1.ParseNoteName(importers.StringValue(occurrence.NoteName))
+		if parseErr != nil {
+			return nil, fmt.Errorf("occurrence %s: %s", importers.StringValue(occurrence.ID), parseErr.Error())
+		}
+		if providerID == "" {
+			providerID = noteProviderID
+		}
+		if noteProviderID != providerID {
+			return nil, fmt.Errorf("occurrence %s: note %s belongs to provider %s, not %s", importers.StringValue(occurrence.ID),
+				noteID, noteProviderID, providerID)
+		}
+		if run.Tool.Driver.Name == "" {
+			run.Tool.Driver.Name = providerID
+		}
+		note, ok := notesByID[noteID]
+		if !ok {
+			return nil, fmt.Errorf("occurrence %s: note %s isn't exported", importers.StringValue(occurrence.ID), noteID)
+		}
+		finding, resolveErr := findingsapiv1.ResolveFinding(note, occurrence)
+		if resolveErr != nil {
+			return nil, fmt.Errorf("occurrence %s: %s", importers.StringValue(occurrence.ID), resolveErr.Error())
+		}
+		ruleIndex := ruleIndexes[noteID]
+		run.Results = append(run.Results, occurrenceResult(finding, noteID, ruleIndex, opts.BaseURL))
+	}
+	if run.Tool.Driver.Name == "" {
+		run.Tool.Driver.Name = "Security Advisor"
+	}
+	return &Log{Version: Version, Schema: Schema, Runs: []Run{run}}, nil
+}
+
+// Write : Writes the log as indented JSON
+func (log *Log) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// SeverityLevel : Converts a severity to a SARIF level: CRITICAL and HIGH are error, MEDIUM warning, and LOW or
+// unknown severities note
+func SeverityLevel(severity findingsapiv1.Severity) string {
+	switch severity {
+	case findingsapiv1.Severity_Critical, findingsapiv1.Severity_High:
+		return Level_Error
+	case findingsapiv1.Severity_Medium:
+		return Level_Warning
+	default:
+		return Level_Note
+	}
+}
+
+// noteRule converts a FINDING note to a rule.
+func noteRule(note *findingsapiv1.ApiNote) ReportingDescriptor {
+	rule := ReportingDescriptor{
+		ID:               importers.StringValue(note.ID),
+		ShortDescription: &MultiformatMessageString{Text: importers.StringValue(note.ShortDescription)},
+		FullDescription:  &MultiformatMessageString{Text: importers.StringValue(note.LongDescription)},
+	}
+	for _, relatedURL := range note.RelatedURL {
+		if url := importers.StringValue(relatedURL.URL); url != "" {
+			rule.HelpURI = url
+			break
+		}
+	}
+	if note.Finding != nil {
+		severity := findingsapiv1.Severity(importers.StringValue(note.Finding.Severity))
+		rule.DefaultConfiguration = &ReportingConfiguration{Level: SeverityLevel(severity)}
+		if score, ok := securitySeverities[severity]; ok {
+			rule.Properties = map[string]interface{}{"security-severity": score}
+		}
+		rule.Help = nextStepsMessage(note.Finding.NextSteps)
+	}
+	return rule
+}
+
+// occurrenceResult converts a resolved FINDING occurrence to a result of the rule of its note.
+func occurrenceResult(finding *findingsapiv1.ResolvedFinding, ruleID string, ruleIndex int, baseURL string) Result {
+	occurrence := finding.Occurrence
+	result := Result{
+		RuleID:       ruleID,
+		RuleIndex:    &ruleIndex,
+		Level:        SeverityLevel(finding.Severity),
+		Message:      Message{Text: importers.StringValue(finding.Note.ShortDescription)},
+		Fingerprints: map[string]string{OccurrenceFingerprint: importers.StringValue(occurrence.ID)},
+		Properties:   contextProperties(occurrence.Context),
+	}
+	if finding.SeverityOverridden {
+		if score, ok := securitySeverities[finding.Severity]; ok {
+			result.setProperty("security-severity", score)
+		}
+	}
+	if finding.Certainty != "" {
+		result.setProperty("certainty", string(finding.Certainty))
+	}
+	if location := occurrenceLocation(occurrence, baseURL); location != nil {
+		result.Locations = []Location{*location}
+	}
+	if remediation := importers.StringValue(occurrence.Remediation); remediation != "" {
+		result.Fixes = append(result.Fixes, Fix{Description: &Message{Text: remediation}})
+	}
+	if finding.NextStepsOverridden {
+		if help := nextStepsMessage(finding.NextSteps); help != nil {
+			result.Fixes = append(result.Fixes, Fix{Description: &Message{Text: help.Text, Markdown: help.Markdown}})
+		}
+	}
+	return result
+}
+
+func (result *Result) setProperty(name string, value interface{}) {
+	if result.Properties == nil {
+		result.Properties = make(map[string]interface{})
+	}
+	result.Properties[name] = value
+}
+
+// occurrenceLocation returns the location of the occurrence: the file of its resource URL or context, or a
+// logical location named after its resource.
+func occurrenceLocation(occurrence *findingsapiv1.ApiOccurrence, baseURL string) *Location {
+	context := occurrence.Context
+	if context == nil {
+		context = &findingsapiv1.Context{}
+	}
+	resourceURL := importers.StringValue(occurrence.ResourceURL)
+	if resourceURL != "" || importers.StringValue(context.ResourceType) == importers.ResourceType_File {
+		artifact := &ArtifactLocation{URI: importers.StringValue(context.ResourceName)}
+		line := lineNumber(resourceIDLine, importers.StringValue(context.ResourceID))
+		if resourceURL != "" {
+			uri := resourceURL
+			if urlLine.MatchString(uri) {
+				line = lineNumber(urlLine, uri)
+				uri = urlLine.ReplaceAllString(uri, "")
+			}
+			artifact.URI = uri
+			if baseURL != "" && strings.HasPrefix(uri, baseURL) {
+				artifact.URI, artifact.URIBaseID = strings.TrimPrefix(uri, baseURL), "SRCROOT"
+			}
+		}
+		if artifact.URI != "" {
+			location := &Location{PhysicalLocation: &PhysicalLocation{ArtifactLocation: artifact}}
+			if line > 0 {
+				location.PhysicalLocation.Region = &Region{StartLine: line}
+			}
+			return location
+		}
+	}
+	if name := importers.StringValue(context.ResourceName); name != "" {
+		return &Location{LogicalLocations: []LogicalLocation{{FullyQualifiedName: name, Kind: importers.StringValue(context.ResourceType)}}}
+	}
+	return nil
+}
+
+// contextProperties returns the fields of the context set, by JSON name.
+func contextProperties(context *findingsapiv1.Context) map[string]interface{} {
+	if context == nil {
+		return nil
+	}
+	data, err := json.Marshal(context)
+	if err != nil {
+		return nil
+	}
+	var properties map[string]interface{}
+	if err = json.Unmarshal(data, &properties); err != nil || len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// nextStepsMessage lists the next steps as text and as Markdown links, nil when there are none.
+func nextStepsMessage(nextSteps []findingsapiv1.RemediationStep) *MultiformatMessageString {
+	if len(nextSteps) == 0 {
+		return nil
+	}
+	var text, markdown []string
+	for _, step := range nextSteps {
+		title, url := importers.StringValue(step.Title), importers.StringValue(step.URL)
+		switch {
+		case url == "":
+			text = append(text, title)
+			markdown = append(markdown, "- "+title)
+		case title == "":
+			text = append(text, url)
+			markdown = append(markdown, "- <"+url+">")
+		default:
+			text = append(text, title+": "+url)
+			markdown = append(markdown, "- ["+title+"]("+url+")")
+		}
+	}
+	return &MultiformatMessageString{Text: strings.Join(text, "\n"), Markdown: strings.Join(markdown, "\n")}
+}
+
+func lineNumber(pattern *regexp.Regexp, value string) int {
+	match := pattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(match[1])
+	return line
+}
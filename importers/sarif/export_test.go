@@ -0,0 +1,207 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sarif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/sarif"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Export`, func() {
+	notes := []findingsapiv1.ApiNote{
+		{
+			ID:               core.StringPtr("hardcoded-credentials"),
+			Kind:             core.StringPtr("FINDING"),
+			ShortDescription: core.StringPtr("Hardcoded credentials"),
+			LongDescription:  core.StringPtr("Credentials were found in the source code"),
+			ReportedBy:       &findingsapiv1.Reporter{ID: core.StringPtr("my-tool"), Title: core.StringPtr("My tool"), URL: core.StringPtr("https://tool.example.com")},
+			RelatedURL:       []findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr("Docs"), URL: core.StringPtr("https://tool.example.com/rules/credentials")}},
+			Finding: &findingsapiv1.FindingType{
+				Severity: core.StringPtr("CRITICAL"),
+				NextSteps: []findingsapiv1.RemediationStep{
+					{Title: core.StringPtr("Rotate the credentials"), URL: core.StringPtr("https://tool.example.com/rotate")},
+					{Title: core.StringPtr("Remove them from the history")},
+				},
+			},
+		},
+		{
+			ID:               core.StringPtr("kpi"),
+			Kind:             core.StringPtr("KPI"),
+			ShortDescription: core.StringPtr("KPI"),
+			LongDescription:  core.StringPtr("KPI"),
+		},
+	}
+	occurrences := []findingsapiv1.ApiOccurrence{
+		{
+			ID:          core.StringPtr("occurrence-1"),
+			NoteName:    core.StringPtr("account/providers/my-tool/notes/hardcoded-credentials"),
+			Kind:        core.StringPtr("FINDING"),
+			ResourceURL: core.StringPtr("https://github.com/my-org/my-repo/blob/main/config/config.go#L12"),
+			Remediation: core.StringPtr("Remove the password"),
+			Context: &findingsapiv1.Context{
+				ResourceName:    core.StringPtr("config/config.go"),
+				ResourceType:    core.StringPtr("file"),
+				EnvironmentName: core.StringPtr("production"),
+				ToolchainID:     core.StringPtr("toolchain"),
+			},
+			Finding: &findingsapiv1.Finding{Certainty: core.StringPtr("HIGH")},
+		},
+		{
+			ID:       core.StringPtr("occurrence-2"),
+			NoteName: core.StringPtr("providers/my-tool/notes/hardcoded-credentials"),
+			Kind:     core.StringPtr("FINDING"),
+			Context:  &findingsapiv1.Context{ResourceName: core.StringPtr("test/fixtures.go"), ResourceType: core.StringPtr("file"), ResourceID: core.StringPtr("test/fixtures.go:3")},
+			Finding: &findingsapiv1.Finding{
+				Severity:  core.StringPtr("LOW"),
+				NextSteps: []findingsapiv1.RemediationStep{{Title: core.StringPtr("Ignore test fixtures"), URL: core.StringPtr("https://tool.example.com/ignore")}},
+			},
+		},
+		{
+			ID:       core.StringPtr("occurrence-3"),
+			NoteName: core.StringPtr("providers/my-tool/notes/hardcoded-credentials"),
+			Kind:     core.StringPtr("FINDING"),
+			Context:  &findingsapiv1.Context{ResourceName: core.StringPtr("my-secret"), ResourceType: core.StringPtr("Secret")},
+			Finding:  &findingsapiv1.Finding{},
+		},
+		{
+			ID:       core.StringPtr("kpi-1"),
+			NoteName: core.StringPtr("providers/my-tool/notes/kpi"),
+			Kind:     core.StringPtr("KPI"),
+		},
+	}
+
+	It(`converts notes to rules`, func() {
+		log, err := sarif.Export(notes, occurrences, &sarif.ExportOptions{BaseURL: "https://github.com/my-org/my-repo/blob/main/"})
+		Expect(err).To(BeNil())
+		Expect(log.Version).To(Equal(sarif.Version))
+		Expect(log.Runs).To(HaveLen(1))
+		run := log.Runs[0]
+		Expect(run.Tool.Driver.Name).To(Equal("My tool"))
+		Expect(run.Tool.Driver.InformationURI).To(Equal("https://tool.example.com"))
+		Expect(run.OriginalURIBaseIDs["SRCROOT"].URI).To(Equal("https://github.com/my-org/my-repo/blob/main/"))
+
+		Expect(run.Tool.Driver.Rules).To(HaveLen(1))
+		rule := run.Tool.Driver.Rules[0]
+		Expect(rule.ID).To(Equal("hardcoded-credentials"))
+		Expect(rule.ShortDescription.Text).To(Equal("Hardcoded credentials"))
+		Expect(rule.FullDescription.Text).To(Equal("Credentials were found in the source code"))
+		Expect(rule.HelpURI).To(Equal("https://tool.example.com/rules/credentials"))
+		Expect(rule.DefaultConfiguration.Level).To(Equal(sarif.Level_Error))
+		Expect(rule.Properties["security-severity"]).To(Equal("9.5"))
+		Expect(rule.Help.Text).To(Equal("Rotate the credentials: https://tool.example.com/rotate\nRemove them from the history"))
+		Expect(rule.Help.Markdown).To(Equal("- [Rotate the credentials](https://tool.example.com/rotate)\n- Remove them from the history"))
+	})
+	It(`converts occurrences to results`, func() {
+		log, err := sarif.Export(notes, occurrences, &sarif.ExportOptions{BaseURL: "https://github.com/my-org/my-repo/blob/main/", ToolName: "Scanner"})
+		Expect(err).To(BeNil())
+		run := log.Runs[0]
+		Expect(run.Tool.Driver.Name).To(Equal("Scanner"))
+		Expect(run.Results).To(HaveLen(3))
+
+		result := run.Results[0]
+		Expect(result.RuleID).To(Equal("hardcoded-credentials"))
+		Expect(*result.RuleIndex).To(Equal(0))
+		Expect(result.Level).To(Equal(sarif.Level_Error))
+		Expect(result.Message.Text).To(Equal("Hardcoded credentials"))
+		Expect(result.Fingerprints[sarif.OccurrenceFingerprint]).To(Equal("occurrence-1"))
+		Expect(result.Properties).To(Equal(map[string]interface{}{
+			"resource_name":    "config/config.go",
+			"resource_type":    "file",
+			"environment_name": "production",
+			"toolchain_id":     "toolchain",
+			"certainty":        "HIGH",
+		}))
+		Expect(*result.Locations[0].PhysicalLocation.ArtifactLocation).To(Equal(sarif.ArtifactLocation{URI: "config/config.go", URIBaseID: "SRCROOT"}))
+		Expect(result.Locations[0].PhysicalLocation.Region.StartLine).To(Equal(12))
+		Expect(result.Fixes).To(HaveLen(1))
+		Expect(result.Fixes[0].Description.Text).To(Equal("Remove the password"))
+
+		result = run.Results[1]
+		Expect(result.Level).To(Equal(sarif.Level_Note))
+		Expect(result.Properties["security-severity"]).To(Equal("2.0"))
+		Expect(*result.Locations[0].PhysicalLocation.ArtifactLocation).To(Equal(sarif.ArtifactLocation{URI: "test/fixtures.go"}))
+		Expect(result.Locations[0].PhysicalLocation.Region.StartLine).To(Equal(3))
+		Expect(result.Fixes[0].Description.Markdown).To(Equal("- [Ignore test fixtures](https://tool.example.com/ignore)"))
+
+		result = run.Results[2]
+		Expect(result.Locations[0].PhysicalLocation).To(BeNil())
+		Expect(result.Locations[0].LogicalLocations[0]).To(Equal(sarif.LogicalLocation{FullyQualifiedName: "my-secret", Kind: "Secret"}))
+		Expect(result.Fixes).To(BeNil())
+	})
+	It(`writes logs that import to the same findings`, func() {
+		log, err := sarif.Export(notes, occurrences, &sarif.ExportOptions{BaseURL: "https://github.com/my-org/my-repo/blob/main/"})
+		Expect(err).To(BeNil())
+		var out bytes.Buffer
+		Expect(log.Write(&out)).To(Succeed())
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(out.Bytes(), &decoded)).To(Succeed())
+		Expect(decoded["$schema"]).To(Equal(sarif.Schema))
+
+		report, err := sarif.Import(&out, nil)
+		Expect(err).To(BeNil())
+		Expect(report.ProviderID).To(Equal("my-tool"))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*report.Notes[0].RelatedURL[0].URL).To(Equal("https://tool.example.com/rules/credentials"))
+		Expect(report.Occurrences).To(HaveLen(3))
+		Expect(*report.Occurrences[0].ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/config/config.go#L12"))
+		Expect(*report.Occurrences[0].Remediation).To(Equal("Remove the password"))
+		Expect(*report.Occurrences[1].Finding.Severity).To(Equal("LOW"))
+	})
+	It(`exports imported logs`, func() {
+		file, err := os.Open("testdata/gosec.sarif")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := sarif.Import(file, nil)
+		Expect(err).To(BeNil())
+
+		log, err := sarif.Export(report.Notes, report.Occurrences, nil)
+		Expect(err).To(BeNil())
+		Expect(log.Runs[0].Tool.Driver.Name).To(Equal("gosec"))
+		Expect(log.Runs[0].Results).To(HaveLen(len(report.Occurrences)))
+	})
+	It(`requires the notes of the occurrences, from the provider of the notes`, func() {
+		_, err := sarif.Export(notes[1:], occurrences[:1], nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("occurrence occurrence-1: note hardcoded-credentials isn't exported"))
+
+		other := occurrences[1]
+		other.NoteName = core.StringPtr("providers/other-tool/notes/hardcoded-credentials")
+		_, err = sarif.Export(notes, []findingsapiv1.ApiOccurrence{occurrences[0], other}, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("occurrence occurrence-2: note hardcoded-credentials belongs to provider other-tool, not my-tool"))
+		_, err = sarif.Export(notes, occurrences[:1], &sarif.ExportOptions{ProviderID: "other-tool"})
+		Expect(err).ToNot(BeNil())
+
+		log, err := sarif.Export(nil, nil, nil)
+		Expect(err).To(BeNil())
+		Expect(log.Runs[0].Tool.Driver.Name).To(Equal("Security Advisor"))
+		Expect(log.Runs[0].Results).To(BeEmpty())
+	})
+	It(`maps severities to levels`, func() {
+		Expect(sarif.SeverityLevel(findingsapiv1.Severity_Critical)).To(Equal(sarif.Level_Error))
+		Expect(sarif.SeverityLevel(findingsapiv1.Severity_High)).To(Equal(sarif.Level_Error))
+		Expect(sarif.SeverityLevel(findingsapiv1.Severity_Medium)).To(Equal(sarif.Level_Warning))
+		Expect(sarif.SeverityLevel(findingsapiv1.Severity_Low)).To(Equal(sarif.Level_Note))
+	})
+})
@@ -0,0 +1,334 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sarif
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL relative artifact URIs are resolved against to build the resource URL of the occurrences, e.g.
+	// https://github.com/my-org/my-repo/blob/main/. Defaults to the originalUriBaseIds of the run. Occurrences of
+	// artifacts without absolute http or https URL have no resource URL.
+	BaseURL string
+}
+
+// precisionCertainties maps the "precision" property of rules to certainties.
+var precisionCertainties = map[string]findingsapiv1.Certainty{
+	"very-high": findingsapiv1.Certainty_High,
+	"high":      findingsapiv1.Certainty_High,
+	"medium":    findingsapiv1.Certainty_Medium,
+	"low":       findingsapiv1.Certainty_Low,
+}
+
+// Import : Reads a SARIF log and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	log, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(log, opts)
+}
+
+// Convert : Converts the results of a SARIF log to occurrences, and the rules they refer to to FINDING notes.
+//
+// Notes are identified by the rule ID. Their severity is derived from the "security-severity" property of the rule
+// when it has one, else from the level of its default configuration: error is HIGH, warning MEDIUM, and note or
+// none LOW. Their help URI is their related URL.
+//
+// Occurrences are identified by a hash of the rule and the fingerprints of the result, or of the rule and its
+// location when the result has no fingerprint, so that importing the same log again yields the same occurrences.
+// Results that aren't problems, i.e. of kind pass, notApplicable or informational, and suppressed results are
+// skipped. The provider defaults to the name of the tool of the first run.
+func Convert(log *Log, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(log, "log cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID, title, informationURI := "sarif", "SARIF", ""
+	if len(log.Runs) > 0 {
+		driver := log.Runs[0].Tool.Driver
+		if id := importers.SanitizeID(strings.ToLower(driver.Name)); id != "" {
+			providerID, title, informationURI = id, driver.Name, driver.InformationURI
+		}
+	}
+	providerID = opts.Provider(providerID)
+	report = importers.NewReport(providerID, opts.Reporter(providerID, title, informationURI))
+
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		for j := range run.Results {
+			if err = convertResult(report, run, &run.Results[j], opts); err != nil {
+				return nil, fmt.Errorf("runs[%d].results[%d]: %s", i, j, err.Error())
+			}
+		}
+	}
+	return
+}
+
+func convertResult(report *importers.Report, run *Run, result *Result, opts *Options) error {
+	if !isProblem(result) {
+		return nil
+	}
+	rule := run.rule(result)
+	ruleID := result.RuleID
+	if ruleID == "" && rule != nil {
+		ruleID = rule.ID
+	}
+	if ruleID == "" {
+		return fmt.Errorf("the result has no rule")
+	}
+	if rule == nil {
+		rule = &ReportingDescriptor{ID: ruleID, DefaultConfiguration: &ReportingConfiguration{Level: result.Level}}
+	}
+
+	note := ruleNote(rule)
+	noteID := *note.ID
+	report.AddNote(note)
+
+	occurrence := findingsapiv1.ApiOccurrence{
+		ID:       core.StringPtr(importers.StableID(append([]string{report.ProviderID, ruleID}, resultIdentity(result)...)...)),
+		NoteName: core.StringPtr(report.NoteName(noteID)),
+		Kind:     core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		Finding:  &findingsapiv1.Finding{},
+	}
+	if severity, ok := resultSeverity(rule, result); ok && severity != findingsapiv1.Severity(*note.Finding.Severity) {
+		occurrence.Finding.Severity = importers.SeverityPtr(severity)
+	}
+	if precision, ok := rule.Properties["precision"].(string); ok {
+		if certainty, ok := precisionCertainties[strings.ToLower(precision)]; ok {
+			occurrence.Finding.Certainty = core.StringPtr(string(certainty))
+		}
+	}
+	var fixes []string
+	for _, fix := range result.Fixes {
+		if fix.Description != nil && fix.Description.Text != "" {
+			fixes = append(fixes, fix.Description.Text)
+		}
+	}
+	occurrence.Remediation = importers.StringPtr(strings.Join(fixes, "\n"))
+
+	var context *findingsapiv1.Context
+	if len(result.Locations) > 0 {
+		occurrence.ResourceURL, context = run.locate(&result.Locations[0], opts.BaseURL)
+	}
+	occurrence.Context = opts.OccurrenceContext(context)
+	report.AddOccurrence(occurrence)
+	return nil
+}
+
+// isProblem reports whether the result is a problem that isn't suppressed.
+func isProblem(result *Result) bool {
+	switch result.Kind {
+	case "", Result_Kind_Fail, Result_Kind_Open, Result_Kind_Review:
+	default:
+		return false
+	}
+	for _, suppression := range result.Suppressions {
+		if suppression.Status == "" || suppression.Status == "accepted" {
+			return false
+		}
+	}
+	return true
+}
+
+// rule returns the rule of the result, looked up by index in the driver or by ID in the driver and extensions.
+func (run *Run) rule(result *Result) *ReportingDescriptor {
+	rules := run.Tool.Driver.Rules
+	if result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(rules) {
+		return &rules[*result.RuleIndex]
+	}
+	for _, component := range append([]ToolComponent{run.Tool.Driver}, run.Tool.Extensions...) {
+		for i := range component.Rules {
+			if component.Rules[i].ID == result.RuleID {
+				return &component.Rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ruleNote converts the rule to a FINDING note.
+func ruleNote(rule *ReportingDescriptor) findingsapiv1.ApiNote {
+	noteID := importers.SanitizeID(rule.ID)
+	if noteID == "" {
+		noteID = importers.StableID(rule.ID)
+	}
+	shortDescription := firstText(rule.ShortDescription, nil)
+	if shortDescription == "" {
+		shortDescription = rule.Name
+	}
+	if shortDescription == "" {
+		shortDescription = rule.ID
+	}
+	longDescription := firstText(rule.FullDescription, rule.Help)
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(noteID),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(ruleSeverity(rule))},
+	}
+	if importers.IsWebURL(rule.HelpURI) {
+		note.RelatedURL = []findingsapiv1.ApiNoteRelatedURL{{Label: core.StringPtr("Rule documentation"), URL: core.StringPtr(rule.HelpURI)}}
+		if help := firstText(rule.Help, nil); help != "" {
+			note.Finding.NextSteps = []findingsapiv1.RemediationStep{{Title: core.StringPtr(firstLine(help)), URL: core.StringPtr(rule.HelpURI)}}
+		}
+	}
+	return note
+}
+
+// ruleSeverity returns the severity of the findings of the rule.
+func ruleSeverity(rule *ReportingDescriptor) findingsapiv1.Severity {
+	if severity, ok := securitySeverity(rule.Properties); ok {
+		return severity
+	}
+	level := Level_Warning
+	if rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != "" {
+		level = rule.DefaultConfiguration.Level
+	}
+	return LevelSeverity(level)
+}
+
+// resultSeverity returns the severity of the result when it overrides the severity of its rule.
+func resultSeverity(rule *ReportingDescriptor, result *Result) (findingsapiv1.Severity, bool) {
+	if severity, ok := securitySeverity(result.Properties); ok {
+		return severity, true
+	}
+	if _, ok := securitySeverity(rule.Properties); ok || result.Level == "" {
+		return "", false
+	}
+	return LevelSeverity(result.Level), true
+}
+
+// LevelSeverity : Converts a SARIF level to a severity: error is HIGH, warning MEDIUM, and note, none or unknown
+// levels LOW
+func LevelSeverity(level string) findingsapiv1.Severity {
+	switch level {
+	case Level_Error:
+		return findingsapiv1.Severity_High
+	case Level_Warning:
+		return findingsapiv1.Severity_Medium
+	default:
+		return findingsapiv1.Severity_Low
+	}
+}
+
+// securitySeverity converts the "security-severity" property, a CVSS score, to a severity.
+func securitySeverity(properties map[string]interface{}) (findingsapiv1.Severity, bool) {
+	var score float64
+	switch value := properties["security-severity"].(type) {
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", false
+		}
+		score = parsed
+	case float64:
+		score = value
+	default:
+		return "", false
+	}
+	return importers.SeverityFromCVSS(score), true
+}
+
+// resultIdentity returns the parts identifying the result: its fingerprints when it has some, else its location
+// and message.
+func resultIdentity(result *Result) []string {
+	for _, fingerprints := range []map[string]string{result.Fingerprints, result.PartialFingerprints} {
+		if len(fingerprints) > 0 {
+			parts := make([]string, 0, len(fingerprints))
+			for name, value := range fingerprints {
+				parts = append(parts, name+"="+value)
+			}
+			sort.Strings(parts)
+			return parts
+		}
+	}
+	parts := []string{}
+	for _, location := range result.Locations {
+		if physical := location.PhysicalLocation; physical != nil {
+			if physical.ArtifactLocation != nil {
+				parts = append(parts, physical.ArtifactLocation.URIBaseID, physical.ArtifactLocation.URI)
+			}
+			if physical.Region != nil {
+				parts = append(parts, strconv.Itoa(physical.Region.StartLine), strconv.Itoa(physical.Region.StartColumn))
+			}
+		}
+		for _, logical := range location.LogicalLocations {
+			parts = append(parts, logical.FullyQualifiedName, logical.Name)
+		}
+	}
+	return append(parts, result.Message.Text)
+}
+
+// locate returns the resource URL and the context of a location. The resource URL is only set when the artifact
+// resolves to an http or https URL, and then points at the start line.
+func (run *Run) locate(location *Location, baseURL string) (resourceURL *string, context *findingsapiv1.Context) {
+	if physical := location.PhysicalLocation; physical != nil && physical.ArtifactLocation != nil && physical.ArtifactLocation.URI != "" {
+		artifact := physical.ArtifactLocation
+		if baseURL == "" {
+			baseURL = run.OriginalURIBaseIDs[artifact.URIBaseID].URI
+		}
+		line := 0
+		if physical.Region != nil {
+			line = physical.Region.StartLine
+		}
+		return importers.FileLocation(baseURL, artifact.URI, line)
+	}
+	for _, logical := range location.LogicalLocations {
+		name := logical.FullyQualifiedName
+		if name == "" {
+			name = logical.Name
+		}
+		if name != "" {
+			return nil, &findingsapiv1.Context{ResourceName: core.StringPtr(name), ResourceType: importers.StringPtr(logical.Kind)}
+		}
+	}
+	return nil, nil
+}
+
+func firstText(messages ...*MultiformatMessageString) string {
+	for _, message := range messages {
+		if message != nil && message.Text != "" {
+			return message.Text
+		}
+	}
+	return ""
+}
+
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
@@ -0,0 +1,142 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sarif_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/sarif"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *sarif.Options) *importers.Report {
+		file, err := os.Open("testdata/gosec.sarif")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := sarif.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`converts rules to notes`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("gosec"))
+		Expect(*report.ReportedBy.Title).To(Equal("gosec"))
+		Expect(*report.ReportedBy.URL).To(Equal("https://github.com/securego/gosec/"))
+		Expect(report.Validate()).To(Succeed())
+
+		Expect(report.Notes).To(HaveLen(3))
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("G101"))
+		Expect(*note.Kind).To(Equal("FINDING"))
+		Expect(*note.ShortDescription).To(Equal("Look for hard coded credentials"))
+		Expect(*note.LongDescription).To(Equal("Potential hardcoded credentials were found in the source code."))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://securego.io/docs/rules/g101.html"))
+		Expect(*note.Finding.Severity).To(Equal("HIGH"))
+		Expect(*note.Finding.NextSteps[0].Title).To(Equal("Read credentials from the environment or a secrets manager."))
+		Expect(*note.Finding.NextSteps[0].URL).To(Equal("https://securego.io/docs/rules/g101.html"))
+
+		note = report.Notes[1]
+		Expect(*note.ID).To(Equal("G404"))
+		Expect(*note.LongDescription).To(Equal("Insecure random number source (rand)"))
+		Expect(*note.Finding.Severity).To(Equal("CRITICAL"))
+		Expect(note.RelatedURL).To(BeNil())
+
+		note = report.Notes[2]
+		Expect(*note.ID).To(Equal("G307"))
+		Expect(*note.ShortDescription).To(Equal("G307"))
+		Expect(*note.Finding.Severity).To(Equal("MEDIUM"))
+	})
+	It(`converts problems to occurrences`, func() {
+		report := importFile(&sarif.Options{Options: importers.Options{
+			Context: &findingsapiv1.Context{ToolchainID: core.StringPtr("toolchain")},
+		}})
+		Expect(report.Occurrences).To(HaveLen(4))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/gosec/notes/G101"))
+		Expect(*occurrence.Kind).To(Equal("FINDING"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/config/config.go#L12"))
+		Expect(*occurrence.Remediation).To(Equal("Remove the credentials"))
+		Expect(occurrence.Finding.Severity).To(BeNil())
+		Expect(*occurrence.Finding.Certainty).To(Equal("MEDIUM"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("config/config.go"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("file"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("config/config.go:12"))
+		Expect(*occurrence.Context.ToolchainID).To(Equal("toolchain"))
+
+		occurrence = report.Occurrences[1]
+		Expect(occurrence.ResourceURL).To(BeNil())
+		Expect(occurrence.Finding.Severity).To(BeNil())
+
+		occurrence = report.Occurrences[2]
+		Expect(*occurrence.Finding.Severity).To(Equal("MEDIUM"))
+
+		occurrence = report.Occurrences[3]
+		Expect(*occurrence.NoteName).To(Equal("providers/gosec/notes/G307"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("main.writeFile"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("function"))
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`derives the same occurrence IDs on every import`, func() {
+		first, second := importFile(nil), importFile(&sarif.Options{BaseURL: "https://example.com/src/"})
+		for i := range first.Occurrences {
+			Expect(*first.Occurrences[i].ID).To(Equal(*second.Occurrences[i].ID))
+		}
+		Expect(*first.Occurrences[0].ID).ToNot(Equal(*first.Occurrences[2].ID))
+		Expect(*second.Occurrences[1].ResourceURL).To(Equal("https://example.com/src/util/random.go#L5"))
+	})
+	It(`identifies results by their fingerprints`, func() {
+		log := func(line string) string {
+			return `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL"}}, "results": [
+				{"ruleId": "go/sql-injection", "message": {"text": "SQL injection"}, "partialFingerprints": {"primaryLocationLineHash": "abc"},
+				 "locations": [{"physicalLocation": {"artifactLocation": {"uri": "db.go"}, "region": {"startLine": ` + line + `}}}]}]}]}`
+		}
+		before, err := sarif.Import(strings.NewReader(log("10")), nil)
+		Expect(err).To(BeNil())
+		after, err := sarif.Import(strings.NewReader(log("12")), &sarif.Options{Options: importers.Options{ProviderID: "codeql"}})
+		Expect(err).To(BeNil())
+		Expect(before.ProviderID).To(Equal("codeql"))
+		Expect(*before.Notes[0].ID).To(Equal("go-sql-injection"))
+		Expect(*after.Occurrences[0].ID).To(Equal(*before.Occurrences[0].ID))
+	})
+	It(`rejects invalid logs`, func() {
+		_, err := sarif.Import(strings.NewReader(`{"version": "2.0.0", "runs": []}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`unsupported SARIF version "2.0.0"`))
+
+		_, err = sarif.Import(strings.NewReader(`{`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = sarif.Import(strings.NewReader(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "t"}}, "results": [{"message": {"text": "m"}}]}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("runs[0].results[0]: the result has no rule"))
+	})
+	It(`maps levels to severities`, func() {
+		Expect(sarif.LevelSeverity(sarif.Level_Error)).To(Equal(findingsapiv1.Severity_High))
+		Expect(sarif.LevelSeverity(sarif.Level_Warning)).To(Equal(findingsapiv1.Severity_Medium))
+		Expect(sarif.LevelSeverity(sarif.Level_Note)).To(Equal(findingsapiv1.Severity_Low))
+		Expect(sarif.LevelSeverity(sarif.Level_None)).To(Equal(findingsapiv1.Severity_Low))
+	})
+})
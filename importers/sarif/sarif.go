@@ -0,0 +1,249 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sarif converts SARIF 2.1.0 logs, the output format of most static analysis tools, to notes and
+// occurrences. Rules become FINDING notes and results become occurrences of the note of their rule.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Version is the SARIF version read and written by this package.
+const Version = "2.1.0"
+
+// Schema is the URI of the JSON schema of SARIF 2.1.0.
+const Schema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// Constants associated with the Result.Level and ReportingConfiguration.Level properties.
+// - error&#58; A serious problem was found.
+// - warning&#58; A problem was found.
+// - note&#58; A minor problem or an opportunity for improvement was found.
+// - none&#58; No problem was found, e.g. for informational results.
+const (
+	Level_Error   = "error"
+	Level_Warning = "warning"
+	Level_Note    = "note"
+	Level_None    = "none"
+)
+
+// Constants associated with the Result.Kind property.
+// - fail&#58; The result is a problem.
+// - open&#58; The tool couldn't tell whether the result is a problem.
+// - review&#58; The result needs a review by a user.
+// - pass&#58; The rule was evaluated and no problem was found.
+// - notApplicable&#58; The rule doesn't apply.
+// - informational&#58; The result is informative and not a problem.
+const (
+	Result_Kind_Fail          = "fail"
+	Result_Kind_Open          = "open"
+	Result_Kind_Review        = "review"
+	Result_Kind_Pass          = "pass"
+	Result_Kind_NotApplicable = "notApplicable"
+	Result_Kind_Informational = "informational"
+)
+
+// Log : A SARIF log, the subset of SARIF 2.1.0 needed to convert findings
+type Log struct {
+	Version string `json:"version"`
+
+	Schema string `json:"$schema,omitempty"`
+
+	// The runs of the tools that produced the log.
+	Runs []Run `json:"runs"`
+}
+
+// Run : The results of a single run of a tool
+type Run struct {
+	Tool Tool `json:"tool"`
+
+	// The base URIs the relative artifact locations are resolved against, by ID.
+	OriginalURIBaseIDs map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+
+	// The version control details of the analyzed sources.
+	VersionControlProvenance []VersionControlDetails `json:"versionControlProvenance,omitempty"`
+
+	Results []Result `json:"results"`
+}
+
+// Tool : The tool of a run
+type Tool struct {
+	Driver ToolComponent `json:"driver"`
+
+	Extensions []ToolComponent `json:"extensions,omitempty"`
+}
+
+// ToolComponent : The driver or an extension of a tool, with the rules it evaluates
+type ToolComponent struct {
+	Name string `json:"name"`
+
+	Version string `json:"version,omitempty"`
+
+	SemanticVersion string `json:"semanticVersion,omitempty"`
+
+	InformationURI string `json:"informationUri,omitempty"`
+
+	Rules []ReportingDescriptor `json:"rules,omitempty"`
+}
+
+// ReportingDescriptor : A rule
+type ReportingDescriptor struct {
+	ID string `json:"id"`
+
+	Name string `json:"name,omitempty"`
+
+	ShortDescription *MultiformatMessageString `json:"shortDescription,omitempty"`
+
+	FullDescription *MultiformatMessageString `json:"fullDescription,omitempty"`
+
+	Help *MultiformatMessageString `json:"help,omitempty"`
+
+	HelpURI string `json:"helpUri,omitempty"`
+
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+
+	// Additional properties, e.g. "security-severity" or "precision".
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// ReportingConfiguration : The default configuration of a rule
+type ReportingConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+// MultiformatMessageString : A text, optionally formatted as Markdown
+type MultiformatMessageString struct {
+	Text string `json:"text"`
+
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// Message : The message of a result or location
+type Message struct {
+	Text string `json:"text,omitempty"`
+
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// Result : A finding of a rule
+type Result struct {
+	RuleID string `json:"ruleId,omitempty"`
+
+	RuleIndex *int `json:"ruleIndex,omitempty"`
+
+	Kind string `json:"kind,omitempty"`
+
+	Level string `json:"level,omitempty"`
+
+	Message Message `json:"message"`
+
+	Locations []Location `json:"locations,omitempty"`
+
+	// Fingerprints identifying the result across runs.
+	Fingerprints map[string]string `json:"fingerprints,omitempty"`
+
+	// Fingerprints contributing to the identity of the result across runs.
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+
+	Fixes []Fix `json:"fixes,omitempty"`
+
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Location : The location of a result
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+
+	Message *Message `json:"message,omitempty"`
+}
+
+// PhysicalLocation : A region of a file
+type PhysicalLocation struct {
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation,omitempty"`
+
+	Region *Region `json:"region,omitempty"`
+}
+
+// ArtifactLocation : The URI of a file, relative to the base URI of the given ID when URIBaseID is set
+type ArtifactLocation struct {
+	URI string `json:"uri,omitempty"`
+
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// Region : The lines and columns of a result in a file
+type Region struct {
+	StartLine int `json:"startLine,omitempty"`
+
+	StartColumn int `json:"startColumn,omitempty"`
+
+	EndLine int `json:"endLine,omitempty"`
+
+	EndColumn int `json:"endColumn,omitempty"`
+}
+
+// LogicalLocation : A named location, e.g. a function or a resource
+type LogicalLocation struct {
+	Name string `json:"name,omitempty"`
+
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+
+	Kind string `json:"kind,omitempty"`
+}
+
+// VersionControlDetails : The repository and revision of the analyzed sources
+type VersionControlDetails struct {
+	RepositoryURI string `json:"repositoryUri"`
+
+	RevisionID string `json:"revisionId,omitempty"`
+
+	Branch string `json:"branch,omitempty"`
+}
+
+// Suppression : A suppression of a result, e.g. by an in-source comment
+type Suppression struct {
+	Kind string `json:"kind"`
+
+	// One of accepted, underReview or rejected. Accepted when omitted.
+	Status string `json:"status,omitempty"`
+
+	Justification string `json:"justification,omitempty"`
+}
+
+// Fix : A proposed fix of a result
+type Fix struct {
+	Description *Message `json:"description,omitempty"`
+
+	ArtifactChanges []interface{} `json:"artifactChanges,omitempty"`
+}
+
+// Parse : Reads a SARIF log, rejecting versions other than 2.1.0
+func Parse(r io.Reader) (log *Log, err error) {
+	log = new(Log)
+	if err = json.NewDecoder(r).Decode(log); err != nil {
+		return nil, fmt.Errorf("failed to parse SARIF log: %s", err.Error())
+	}
+	if log.Version != Version {
+		return nil, fmt.Errorf("unsupported SARIF version %q, expected %s", log.Version, Version)
+	}
+	return
+}
@@ -0,0 +1,300 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Constants associated with the Analysis.State property.
+// - resolved&#58; The vulnerability was remediated.
+// - resolved_with_pedigree&#58; The vulnerability was remediated, and the pedigree of the component tells how.
+// - exploitable&#58; The vulnerability may be exploited.
+// - in_triage&#58; The vulnerability is being investigated.
+// - false_positive&#58; The vulnerability was wrongly attributed to the component.
+// - not_affected&#58; The component isn't affected by the vulnerability.
+const (
+	Analysis_State_Resolved             = "resolved"
+	Analysis_State_ResolvedWithPedigree = "resolved_with_pedigree"
+	Analysis_State_Exploitable          = "exploitable"
+	Analysis_State_InTriage             = "in_triage"
+	Analysis_State_FalsePositive        = "false_positive"
+	Analysis_State_NotAffected          = "not_affected"
+)
+
+// cvssMethods are the rating methods of CVSS v3 scores.
+var cvssMethods = map[string]bool{"CVSSv3": true, "CVSSv31": true}
+
+// BOM : A CycloneDX JSON BOM, the subset of CycloneDX 1.4 to 1.6 needed to convert its vulnerabilities
+type BOM struct {
+	BOMFormat string `json:"bomFormat"`
+
+	SpecVersion string `json:"specVersion"`
+
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	Components []Component `json:"components,omitempty"`
+
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// Metadata : The metadata of a BOM
+type Metadata struct {
+
+	// The component the BOM describes.
+	Component *Component `json:"component,omitempty"`
+}
+
+// Component : A component of a BOM
+type Component struct {
+	BOMRef string `json:"bom-ref,omitempty"`
+
+	Type string `json:"type"`
+
+	Group string `json:"group,omitempty"`
+
+	Name string `json:"name"`
+
+	Version string `json:"version,omitempty"`
+
+	PURL string `json:"purl,omitempty"`
+
+	// The components the component is made of.
+	Components []Component `json:"components,omitempty"`
+}
+
+// Vulnerability : A vulnerability of components of a BOM
+type Vulnerability struct {
+	BOMRef string `json:"bom-ref,omitempty"`
+
+	ID string `json:"id"`
+
+	Source *VulnerabilitySource `json:"source,omitempty"`
+
+	// The same vulnerability in other sources, e.g. the GHSA of a CVE.
+	References []VulnerabilityReference `json:"references,omitempty"`
+
+	Ratings []Rating `json:"ratings,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	Detail string `json:"detail,omitempty"`
+
+	Recommendation string `json:"recommendation,omitempty"`
+
+	Advisories []Advisory `json:"advisories,omitempty"`
+
+	// The analysis of the exploitability of the vulnerability, i.e. its VEX status.
+	Analysis *Analysis `json:"analysis,omitempty"`
+
+	Affects []Affect `json:"affects,omitempty"`
+}
+
+// VulnerabilitySource : The database a vulnerability comes from
+type VulnerabilitySource struct {
+	Name string `json:"name,omitempty"`
+
+	URL string `json:"url,omitempty"`
+}
+
+// VulnerabilityReference : The ID of a vulnerability in another source
+type VulnerabilityReference struct {
+	ID string `json:"id"`
+
+	Source *VulnerabilitySource `json:"source,omitempty"`
+}
+
+// Rating : A severity rating of a vulnerability
+type Rating struct {
+	Source *VulnerabilitySource `json:"source,omitempty"`
+
+	Score float64 `json:"score,omitempty"`
+
+	// One of critical, high, medium, low, info, none or unknown.
+	Severity string `json:"severity,omitempty"`
+
+	// The scoring method, e.g. CVSSv31.
+	Method string `json:"method,omitempty"`
+
+	Vector string `json:"vector,omitempty"`
+}
+
+// Advisory : An advisory about a vulnerability
+type Advisory struct {
+	Title string `json:"title,omitempty"`
+
+	URL string `json:"url"`
+}
+
+// Analysis : The analysis of a vulnerability
+type Analysis struct {
+
+	// One of the Analysis_State constants.
+	State string `json:"state,omitempty"`
+
+	Justification string `json:"justification,omitempty"`
+
+	Response []string `json:"response,omitempty"`
+
+	Detail string `json:"detail,omitempty"`
+}
+
+// Affect : A component affected by a vulnerability
+type Affect struct {
+
+	// The bom-ref of the component, or a BOM-Link to a component of another BOM.
+	Ref string `json:"ref"`
+
+	Versions []AffectedVersion `json:"versions,omitempty"`
+}
+
+// AffectedVersion : A version of an affected component, and whether it is affected
+type AffectedVersion struct {
+	Version string `json:"version,omitempty"`
+
+	Range string `json:"range,omitempty"`
+
+	// One of affected, unaffected or unknown.
+	Status string `json:"status,omitempty"`
+}
+
+// subject returns the component the BOM describes.
+func (bom *BOM) subject() *Package {
+	if bom.Metadata == nil || bom.Metadata.Component == nil {
+		return nil
+	}
+	pkg := bom.Metadata.Component.toPackage()
+	return &pkg
+}
+
+// packages returns the components of the BOM, depth first, the described component first.
+func (bom *BOM) packages() (packages []Package) {
+	var walk func(components []Component)
+	walk = func(components []Component) {
+		for i := range components {
+			packages = append(packages, components[i].toPackage())
+			walk(components[i].Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		walk([]Component{*bom.Metadata.Component})
+	}
+	walk(bom.Components)
+	return
+}
+
+func (component *Component) toPackage() Package {
+	name := component.Name
+	if component.Group != "" {
+		name = component.Group + "/" + name
+	}
+	return Package{Ref: component.BOMRef, Name: name, Version: component.Version, PURL: component.PURL}
+}
+
+// findings returns the vulnerabilities of the BOM per affected component. Components missing from the BOM, e.g.
+// BOM-Links to other BOMs, are named after their reference.
+func (bom *BOM) findings() (findings []finding) {
+	packages := make(map[string]*Package)
+	all := bom.packages()
+	for i := range all {
+		if all[i].Ref != "" {
+			packages[all[i].Ref] = &all[i]
+		}
+	}
+	for i := range bom.Vulnerabilities {
+		vulnerability := &bom.Vulnerabilities[i]
+		status := ""
+		if vulnerability.Analysis != nil {
+			status = analysisStatus(vulnerability.Analysis.State)
+		}
+		for j, affect := range vulnerability.Affects {
+			pkg, ok := packages[affect.Ref]
+			if !ok {
+				pkg = &Package{Ref: affect.Ref, Name: affect.Ref}
+			}
+			findings = append(findings, finding{
+				field:         fmt.Sprintf("vulnerabilities[%d].affects[%d]", i, j),
+				pkg:           pkg,
+				vulnerability: vulnerability.toVulnerability(pkg, affect.Versions),
+				status:        status,
+			})
+		}
+	}
+	return
+}
+
+// toVulnerability converts the vulnerability of the package to the model shared by the importers. The versions
+// the affect declares unaffected are the fixed versions.
+func (vulnerability *Vulnerability) toVulnerability(pkg *Package, versions []AffectedVersion) *importers.Vulnerability {
+	converted := &importers.Vulnerability{
+		ID:               vulnerability.ID,
+		Description:      vulnerability.Description,
+		Package:          pkg.Name,
+		InstalledVersion: pkg.Version,
+		Recommendation:   strings.TrimSpace(vulnerability.Recommendation),
+	}
+	if converted.Description == "" {
+		converted.Description = vulnerability.Detail
+	}
+	for _, rating := range vulnerability.Ratings {
+		if cvssMethods[rating.Method] && rating.Score > converted.CVSSScore {
+			converted.CVSSScore = rating.Score
+		}
+		if converted.Severity == "" {
+			converted.Severity = importers.SeverityFromName(rating.Severity, "")
+		}
+	}
+	if vulnerability.Source != nil {
+		converted.URLs = append(converted.URLs, vulnerability.Source.URL)
+	}
+	for _, advisory := range vulnerability.Advisories {
+		converted.URLs = append(converted.URLs, advisory.URL)
+	}
+	for _, reference := range vulnerability.References {
+		if reference.Source != nil {
+			converted.URLs = append(converted.URLs, reference.Source.URL)
+		}
+	}
+	for _, version := range versions {
+		if version.Status == "unaffected" && version.Version != "" {
+			converted.FixedVersions = append(converted.FixedVersions, version.Version)
+		}
+	}
+	return converted
+}
+
+// analysisStatus converts the state of an analysis to a VEX status: not_affected and false_positive are
+// not_affected, resolved fixed, exploitable affected and in_triage under_investigation.
+func analysisStatus(state string) string {
+	switch state {
+	case Analysis_State_NotAffected, Analysis_State_FalsePositive:
+		return Status_NotAffected
+	case Analysis_State_Resolved, Analysis_State_ResolvedWithPedigree:
+		return Status_Fixed
+	case Analysis_State_Exploitable:
+		return Status_Affected
+	case Analysis_State_InTriage:
+		return Status_UnderInvestigation
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,144 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options. The ComponentName of the context of the options defaults to the name of the
+// subject of the SBOM, and its EnvironmentName and ToolchainID, e.g. the environment the build is deployed to and
+// the toolchain that built it, are set on every occurrence.
+type Options struct {
+	importers.Options
+
+	// One of the importers.NoteMode constants. Defaults to one note per vulnerability.
+	NoteMode string
+
+	// The VEX document overriding the status of the vulnerabilities of the SBOM, and adding the vulnerabilities it
+	// states affect its packages.
+	VEX *VEX
+}
+
+// finding is a vulnerability of a package of an SBOM.
+type finding struct {
+
+	// The field the finding was read from, for errors.
+	field string
+
+	pkg *Package
+
+	vulnerability *importers.Vulnerability
+
+	// The VEX status of the vulnerability in the SBOM, empty when unknown.
+	status string
+}
+
+// Import : Reads an SBOM and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	document, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(document, opts)
+}
+
+// Convert : Converts the vulnerabilities of the packages of the SBOM to occurrences, and to one note per
+// vulnerability or a single note, following the note mode of the options.
+//
+// The vulnerabilities are the CycloneDX vulnerabilities, per affected component, or the SECURITY advisory
+// references of the SPDX packages, and the vulnerabilities the VEX document of the options states affect packages of
+// the SBOM. Vulnerabilities whose VEX status is not_affected or fixed, e.g. CycloneDX analyses in the not_affected
+// or resolved state, are skipped; the statements of the VEX document of the options take precedence over the
+// analyses of the SBOM.
+//
+// There is one occurrence per component name, package and vulnerability, so that the occurrences of the SBOM of
+// the next build replace them. The package is the resource of the occurrence, which has no resource URL. Its
+// remediation is the recommendation of the SBOM or action statement of the VEX document, else the upgrade to the
+// versions the SBOM declares unaffected. The provider defaults to sbom.
+func Convert(document *Document, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(document, "document cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("sbom")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "SBOM", ""))
+
+	componentName := ""
+	if opts.Context != nil {
+		componentName = importers.StringValue(opts.Context.ComponentName)
+	}
+	if subject := document.Subject(); componentName == "" && subject != nil {
+		componentName = subject.Name
+	}
+
+	var findings []finding
+	switch {
+	case document.CycloneDX != nil:
+		findings = document.CycloneDX.findings()
+	case document.SPDX != nil:
+		findings = document.SPDX.findings()
+	}
+	found := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		found[findingKey(finding.vulnerability.ID, finding.pkg)] = true
+	}
+	findings = append(findings, opts.VEX.findings(document.Packages(), found)...)
+
+	for _, finding := range findings {
+		status := finding.status
+		if statement := opts.VEX.Statement(finding.vulnerability.ID, finding.pkg); statement != nil {
+			status = statement.Status
+			if statement.ActionStatement != "" {
+				finding.vulnerability.Recommendation = statement.ActionStatement
+			}
+		}
+		if status == Status_NotAffected || status == Status_Fixed {
+			continue
+		}
+		resourceID := finding.pkg.PURL
+		if resourceID == "" {
+			resourceID = finding.pkg.Version
+		}
+		context := &findingsapiv1.Context{
+			ResourceName:  importers.StringPtr(finding.pkg.Name),
+			ResourceType:  core.StringPtr(ResourceType_Package),
+			ResourceID:    importers.StringPtr(resourceID),
+			ComponentName: importers.StringPtr(componentName),
+		}
+		occurrenceID := importers.StableID(providerID, componentName, packageIdentity(finding.pkg), finding.vulnerability.ID)
+		err = report.AddVulnerability(finding.vulnerability, opts.NoteMode, "SBOM analysis", occurrenceID, nil, opts.OccurrenceContext(context))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", finding.field, err.Error())
+		}
+	}
+	return
+}
+
+// findingKey identifies the vulnerability of a package.
+func findingKey(vulnerabilityID string, pkg *Package) string {
+	return strings.ToUpper(vulnerabilityID) + "\x00" + packageIdentity(pkg)
+}
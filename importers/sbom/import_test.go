@@ -0,0 +1,229 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/sbom"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func readVEX(name string) *sbom.VEX {
+	file, err := os.Open(name)
+	Expect(err).To(BeNil())
+	defer file.Close()
+	vex, err := sbom.ParseVEX(file)
+	Expect(err).To(BeNil())
+	return vex
+}
+
+var _ = Describe(`Import`, func() {
+	importFile := func(name string, opts *sbom.Options) *importers.Report {
+		file, err := os.Open(name)
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := sbom.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+	deployment := importers.Options{Context: &findingsapiv1.Context{
+		EnvironmentName: core.StringPtr("production"),
+		ToolchainID:     core.StringPtr("my-toolchain"),
+	}}
+
+	Describe(`CycloneDX`, func() {
+		It(`creates one note per vulnerability`, func() {
+			report := importFile("testdata/cyclonedx.json", nil)
+			Expect(report.ProviderID).To(Equal("sbom"))
+			Expect(*report.ReportedBy.Title).To(Equal("SBOM"))
+			Expect(report.Validate()).To(Succeed())
+			Expect(report.Notes).To(HaveLen(2))
+
+			note := report.Notes[0]
+			Expect(*note.ID).To(Equal("CVE-2021-44228"))
+			Expect(*note.LongDescription).To(HavePrefix("Apache Log4j2 JNDI features"))
+			Expect(*note.Finding.Severity).To(Equal("CRITICAL"))
+			Expect(note.RelatedURL).To(HaveLen(2))
+			Expect(*note.RelatedURL[0].URL).To(Equal("https://nvd.nist.gov/vuln/detail/CVE-2021-44228"))
+
+			note = report.Notes[1]
+			Expect(*note.ID).To(Equal("GHSA-57j2-w4cx-62h2"))
+			Expect(*note.Finding.Severity).To(Equal("HIGH"))
+			Expect(note.RelatedURL).To(HaveLen(2))
+		})
+		It(`creates occurrences per package and skips not_affected vulnerabilities`, func() {
+			report := importFile("testdata/cyclonedx.json", &sbom.Options{Options: deployment})
+			Expect(report.Occurrences).To(HaveLen(2))
+
+			occurrence := report.Occurrences[0]
+			Expect(*occurrence.NoteName).To(Equal("providers/sbom/notes/CVE-2021-44228"))
+			Expect(occurrence.ResourceURL).To(BeNil())
+			Expect(*occurrence.Remediation).To(Equal("Upgrade log4j-core to 2.17.1 or later."))
+			Expect(*occurrence.Context.ResourceName).To(Equal("org.apache.logging.log4j/log4j-core"))
+			Expect(*occurrence.Context.ResourceType).To(Equal(sbom.ResourceType_Package))
+			Expect(*occurrence.Context.ResourceID).To(Equal("pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"))
+			Expect(*occurrence.Context.ComponentName).To(Equal("my-app"))
+			Expect(*occurrence.Context.EnvironmentName).To(Equal("production"))
+			Expect(*occurrence.Context.ToolchainID).To(Equal("my-toolchain"))
+
+			occurrence = report.Occurrences[1]
+			Expect(*occurrence.NoteName).To(Equal("providers/sbom/notes/GHSA-57j2-w4cx-62h2"))
+			Expect(*occurrence.Remediation).To(Equal("Upgrade com.fasterxml.jackson.core/jackson-databind 2.13.2 to 2.13.2.1"))
+
+			Expect(report.Validate()).To(Succeed())
+		})
+		It(`keeps occurrence IDs across versions of the packages and the component`, func() {
+			report := importFile("testdata/cyclonedx.json", nil)
+			file, err := ioutil.ReadFile("testdata/cyclonedx.json")
+			Expect(err).To(BeNil())
+			upgraded := strings.NewReplacer("2.14.1", "2.15.0", "1.2.0", "1.3.0").Replace(string(file))
+			next, err := sbom.Import(strings.NewReader(upgraded), nil)
+			Expect(err).To(BeNil())
+			Expect(*next.Occurrences[0].Context.ResourceID).To(HaveSuffix("@2.15.0"))
+			Expect(*next.Occurrences[0].ID).To(Equal(*report.Occurrences[0].ID))
+
+			renamed := importFile("testdata/cyclonedx.json", &sbom.Options{Options: importers.Options{
+				Context: &findingsapiv1.Context{ComponentName: core.StringPtr("my-service")},
+			}})
+			Expect(*renamed.Occurrences[0].Context.ComponentName).To(Equal("my-service"))
+			Expect(*renamed.Occurrences[0].ID).ToNot(Equal(*report.Occurrences[0].ID))
+		})
+		DescribeTable(`lets the VEX document override the analyses`,
+			func(state string, statement *sbom.Statement, remediation string) {
+				analysis := ""
+				if state != "" {
+					analysis = `"analysis": {"state": "` + state + `"},`
+				}
+				bom := `{"bomFormat": "CycloneDX", "specVersion": "1.5",
+					"components": [{"bom-ref": "lodash", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"}],
+					"vulnerabilities": [{"id": "CVE-2021-23337", "recommendation": "Upgrade lodash", ` + analysis + `
+						"affects": [{"ref": "lodash"}]}]}`
+				vex := &sbom.VEX{}
+				if statement != nil {
+					vex.Statements = []sbom.Statement{*statement}
+				}
+				report, err := sbom.Import(strings.NewReader(bom), &sbom.Options{VEX: vex})
+				Expect(err).To(BeNil())
+				Expect(report.Validate()).To(Succeed())
+				if remediation == "" {
+					Expect(report.Occurrences).To(BeEmpty())
+					return
+				}
+				Expect(report.Occurrences).To(HaveLen(1))
+				Expect(*report.Occurrences[0].NoteName).To(Equal("providers/sbom/notes/CVE-2021-23337"))
+				Expect(*report.Occurrences[0].Remediation).To(Equal(remediation))
+			},
+			Entry(`exploitable without statement`, sbom.Analysis_State_Exploitable, nil, "Upgrade lodash"),
+			Entry(`not_affected without statement`, sbom.Analysis_State_NotAffected, nil, ""),
+			Entry(`not_affected stated affected`, sbom.Analysis_State_NotAffected, &sbom.Statement{
+				Vulnerability: sbom.Identifier{Name: "CVE-2021-23337"}, Status: sbom.Status_Affected,
+				Products: []sbom.Identifier{{ID: "pkg:npm/lodash@4.17.20"}}, ActionStatement: "Upgrade lodash to 4.17.21",
+			}, "Upgrade lodash to 4.17.21"),
+			Entry(`resolved stated under investigation`, sbom.Analysis_State_Resolved, &sbom.Statement{
+				Vulnerability: sbom.Identifier{Name: "CVE-2021-23337"}, Status: sbom.Status_UnderInvestigation,
+			}, "Upgrade lodash"),
+			Entry(`exploitable stated not_affected`, sbom.Analysis_State_Exploitable, &sbom.Statement{
+				Vulnerability: sbom.Identifier{ID: "cve-2021-23337"}, Status: sbom.Status_NotAffected,
+				Products: []sbom.Identifier{{ID: "pkg:npm/lodash"}},
+			}, ""),
+			Entry(`without analysis stated fixed`, "", &sbom.Statement{
+				Vulnerability: sbom.Identifier{Name: "CVE-2021-23337"}, Status: sbom.Status_Fixed,
+			}, ""),
+			Entry(`in_triage stated not_affected for another package`, sbom.Analysis_State_InTriage, &sbom.Statement{
+				Vulnerability: sbom.Identifier{Name: "CVE-2021-23337"}, Status: sbom.Status_NotAffected,
+				Products: []sbom.Identifier{{ID: "pkg:npm/underscore@1.13.1"}},
+			}, "Upgrade lodash"),
+			Entry(`false_positive stated affected by another vulnerability`, sbom.Analysis_State_FalsePositive, &sbom.Statement{
+				Vulnerability: sbom.Identifier{Name: "CVE-2020-8203"}, Status: sbom.Status_Affected,
+			}, ""),
+		)
+	})
+
+	Describe(`SPDX`, func() {
+		It(`converts the advisory references`, func() {
+			report := importFile("testdata/spdx.json", &sbom.Options{Options: deployment})
+			Expect(report.Notes).To(HaveLen(2))
+			Expect(*report.Notes[0].ID).To(Equal("CVE-2021-23337"))
+			Expect(*report.Notes[0].LongDescription).To(Equal("Command injection via the template function."))
+			Expect(*report.Notes[0].Finding.Severity).To(Equal("MEDIUM"))
+			Expect(report.Notes[0].RelatedURL).To(HaveLen(2))
+			Expect(*report.Notes[1].ID).To(Equal("GHSA-xvch-5gv4-984h"))
+
+			Expect(report.Occurrences).To(HaveLen(2))
+			occurrence := report.Occurrences[0]
+			Expect(*occurrence.Context.ResourceName).To(Equal("lodash"))
+			Expect(*occurrence.Context.ResourceID).To(Equal("pkg:npm/lodash@4.17.20"))
+			Expect(*occurrence.Context.ComponentName).To(Equal("my-web-app"))
+			Expect(*occurrence.Context.EnvironmentName).To(Equal("production"))
+			Expect(*occurrence.Remediation).To(Equal("No fixed version of lodash 4.17.20 is available"))
+		})
+		It(`applies an OpenVEX document`, func() {
+			report := importFile("testdata/spdx.json", &sbom.Options{VEX: readVEX("testdata/openvex.json")})
+			Expect(report.Occurrences).To(HaveLen(2))
+
+			occurrence := report.Occurrences[0]
+			Expect(*occurrence.NoteName).To(Equal("providers/sbom/notes/CVE-2021-23337"))
+			Expect(*occurrence.Remediation).To(Equal("Upgrade lodash to 4.17.21"))
+
+			occurrence = report.Occurrences[1]
+			Expect(*occurrence.NoteName).To(Equal("providers/sbom/notes/CVE-2022-25883"))
+			Expect(*occurrence.Context.ResourceName).To(Equal("semver"))
+			Expect(*occurrence.Remediation).To(Equal("Upgrade semver to 7.5.2"))
+			Expect(*report.Note("CVE-2022-25883").LongDescription).To(Equal("Regular expression denial of service in the parsing of ranges."))
+			Expect(*report.Note("CVE-2022-25883").RelatedURL[0].URL).To(Equal("https://nvd.nist.gov/vuln/detail/CVE-2022-25883"))
+		})
+	})
+
+	It(`creates a single note in scanner mode`, func() {
+		report := importFile("testdata/cyclonedx.json", &sbom.Options{NoteMode: importers.NoteMode_Scanner})
+		Expect(report.Notes).To(HaveLen(1))
+		Expect(*report.Notes[0].ID).To(Equal(importers.ScannerNoteID))
+		Expect(*report.Occurrences[0].Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*report.Occurrences[1].Finding.Severity).To(Equal("HIGH"))
+	})
+	It(`names components missing from the BOM after their reference`, func() {
+		report, err := sbom.Import(strings.NewReader(`{"bomFormat": "CycloneDX", "specVersion": "1.5",
+			"vulnerabilities": [{"id": "CVE-1", "affects": [{"ref": "urn:cdx:other/1#lib"}]}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*report.Occurrences[0].Context.ResourceName).To(Equal("urn:cdx:other/1#lib"))
+		Expect(report.Occurrences[0].Context.ComponentName).To(BeNil())
+	})
+	It(`rejects invalid documents`, func() {
+		_, err := sbom.Import(strings.NewReader(`{"spdxVersion": "SPDX-3.0"}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("unsupported SBOM, expected CycloneDX JSON or SPDX 2 JSON"))
+
+		_, err = sbom.Import(strings.NewReader(`{"bomFormat": "CycloneDX", "components": {}}`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = sbom.Import(strings.NewReader(`{"bomFormat": "CycloneDX", "components": [{"bom-ref": "p", "name": "p"}],
+			"vulnerabilities": [{"id": "", "affects": [{"ref": "p"}]}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("vulnerabilities[0].affects[0]: the vulnerability of p has no ID"))
+
+		_, err = sbom.Convert(nil, nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,142 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sbom converts the vulnerabilities recorded in software bills of materials (SBOM), in the CycloneDX JSON
+// or SPDX 2 JSON formats, to notes and occurrences, so that the components of a build can be reported without
+// scanning them again. The status of the vulnerabilities can be overridden with a VEX document.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Constants associated with the Document.Format property.
+// - CycloneDX&#58; A CycloneDX JSON BOM.
+// - SPDX&#58; An SPDX 2 JSON document.
+const (
+	Format_CycloneDX = "CycloneDX"
+	Format_SPDX      = "SPDX"
+)
+
+// ResourceType_Package is the resource type of the context of findings in the packages of an SBOM.
+const ResourceType_Package = "Package"
+
+// Document : An SBOM, in either format
+type Document struct {
+
+	// One of the Format constants.
+	Format string
+
+	// The BOM of the CycloneDX format, nil for other formats.
+	CycloneDX *BOM
+
+	// The document of the SPDX format, nil for other formats.
+	SPDX *SPDXDocument
+}
+
+// Package : A package of an SBOM, i.e. a CycloneDX component or an SPDX package
+type Package struct {
+
+	// The bom-ref of the component or the SPDX ID of the package.
+	Ref string
+
+	Name string
+
+	Version string
+
+	// The package URL, e.g. pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1.
+	PURL string
+}
+
+// Parse : Reads an SBOM, detecting its format
+func Parse(r io.Reader) (document *Document, err error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %s", err.Error())
+	}
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err = json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM: %s", err.Error())
+	}
+	switch {
+	case header.BOMFormat == Format_CycloneDX:
+		document = &Document{Format: Format_CycloneDX, CycloneDX: new(BOM)}
+		err = json.Unmarshal(data, document.CycloneDX)
+	case strings.HasPrefix(header.SPDXVersion, "SPDX-2."):
+		document = &Document{Format: Format_SPDX, SPDX: new(SPDXDocument)}
+		err = json.Unmarshal(data, document.SPDX)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM, expected CycloneDX JSON or SPDX 2 JSON")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s SBOM: %s", document.Format, err.Error())
+	}
+	return
+}
+
+// Subject : Returns the package the SBOM describes, e.g. the application that was built, nil when it declares none
+func (document *Document) Subject() *Package {
+	switch {
+	case document.CycloneDX != nil:
+		return document.CycloneDX.subject()
+	case document.SPDX != nil:
+		return document.SPDX.subject()
+	default:
+		return nil
+	}
+}
+
+// Packages : Returns the packages of the SBOM, including its subject
+func (document *Document) Packages() []Package {
+	switch {
+	case document.CycloneDX != nil:
+		return document.CycloneDX.packages()
+	case document.SPDX != nil:
+		return document.SPDX.packages()
+	default:
+		return nil
+	}
+}
+
+// packageIdentity returns the identity of the package across versions: its package URL without version, else its
+// name.
+func packageIdentity(pkg *Package) string {
+	if pkg.PURL != "" {
+		return purlWithoutVersion(pkg.PURL)
+	}
+	return pkg.Name
+}
+
+// purlWithoutVersion strips the version, qualifiers and subpath of a package URL.
+func purlWithoutVersion(purl string) string {
+	purl = purlWithoutQualifiers(purl)
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		purl = purl[:i]
+	}
+	return purl
+}
+
+// purlWithoutQualifiers strips the qualifiers and subpath of a package URL.
+func purlWithoutQualifiers(purl string) string {
+	return strings.SplitN(strings.SplitN(purl, "#", 2)[0], "?", 2)[0]
+}
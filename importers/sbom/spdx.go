@@ -0,0 +1,174 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Constants associated with the ExternalRef.ReferenceType property.
+// - purl&#58; The package URL of the package.
+// - advisory&#58; The URL of an advisory about a vulnerability of the package.
+const (
+	ReferenceType_PURL     = "purl"
+	ReferenceType_Advisory = "advisory"
+)
+
+// vulnerabilityIDs matches the vulnerability IDs of advisory URLs.
+var vulnerabilityIDs = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|GHSA(-[0-9a-z]{4}){3}|GO-\d{4}-\d{4,})\b`)
+
+// SPDXDocument : An SPDX 2 JSON document, the subset of SPDX 2.2 and 2.3 needed to convert its vulnerabilities
+type SPDXDocument struct {
+	SPDXVersion string `json:"spdxVersion"`
+
+	SPDXID string `json:"SPDXID"`
+
+	Name string `json:"name"`
+
+	DocumentNamespace string `json:"documentNamespace,omitempty"`
+
+	// The SPDX IDs of the packages the document describes.
+	DocumentDescribes []string `json:"documentDescribes,omitempty"`
+
+	Packages []SPDXPackage `json:"packages,omitempty"`
+
+	Relationships []Relationship `json:"relationships,omitempty"`
+}
+
+// SPDXPackage : A package of an SPDX document
+type SPDXPackage struct {
+	SPDXID string `json:"SPDXID"`
+
+	Name string `json:"name"`
+
+	VersionInfo string `json:"versionInfo,omitempty"`
+
+	ExternalRefs []ExternalRef `json:"externalRefs,omitempty"`
+}
+
+// ExternalRef : A reference of a package to an external resource, e.g. its package URL or an advisory
+type ExternalRef struct {
+
+	// SECURITY, PACKAGE-MANAGER, PERSISTENT-ID or OTHER.
+	ReferenceCategory string `json:"referenceCategory"`
+
+	// One of the ReferenceType constants, or another type.
+	ReferenceType string `json:"referenceType"`
+
+	ReferenceLocator string `json:"referenceLocator"`
+
+	Comment string `json:"comment,omitempty"`
+}
+
+// Relationship : A relationship between SPDX elements
+type Relationship struct {
+	SPDXElementID string `json:"spdxElementId"`
+
+	RelationshipType string `json:"relationshipType"`
+
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// subject returns the first package the document describes, or a package named after the document when it
+// describes none.
+func (document *SPDXDocument) subject() *Package {
+	described := append([]string(nil), document.DocumentDescribes...)
+	for _, relationship := range document.Relationships {
+		if relationship.SPDXElementID == document.SPDXID && relationship.RelationshipType == "DESCRIBES" {
+			described = append(described, relationship.RelatedSPDXElement)
+		}
+	}
+	for _, id := range described {
+		for i := range document.Packages {
+			if document.Packages[i].SPDXID == id {
+				pkg := document.Packages[i].toPackage()
+				return &pkg
+			}
+		}
+	}
+	if document.Name == "" {
+		return nil
+	}
+	return &Package{Ref: document.SPDXID, Name: document.Name}
+}
+
+// packages returns the packages of the document.
+func (document *SPDXDocument) packages() []Package {
+	packages := make([]Package, len(document.Packages))
+	for i := range document.Packages {
+		packages[i] = document.Packages[i].toPackage()
+	}
+	return packages
+}
+
+func (spdxPackage *SPDXPackage) toPackage() Package {
+	pkg := Package{Ref: spdxPackage.SPDXID, Name: spdxPackage.Name, Version: spdxPackage.VersionInfo}
+	for _, ref := range spdxPackage.ExternalRefs {
+		if ref.ReferenceType == ReferenceType_PURL {
+			pkg.PURL = ref.ReferenceLocator
+			break
+		}
+	}
+	return pkg
+}
+
+// findings returns the vulnerabilities of the packages, i.e. their SECURITY advisory references. The vulnerability
+// ID is the CVE, GHSA or Go vulnerability ID of the advisory URL, else its last path segment. The advisories of the
+// same vulnerability are merged.
+func (document *SPDXDocument) findings() (findings []finding) {
+	for i := range document.Packages {
+		spdxPackage := &document.Packages[i]
+		pkg := spdxPackage.toPackage()
+		indexes := make(map[string]int)
+		for j, ref := range spdxPackage.ExternalRefs {
+			if !strings.EqualFold(ref.ReferenceCategory, "SECURITY") || ref.ReferenceType != ReferenceType_Advisory || ref.ReferenceLocator == "" {
+				continue
+			}
+			id := advisoryID(ref.ReferenceLocator)
+			if index, ok := indexes[id]; ok {
+				findings[index].vulnerability.URLs = append(findings[index].vulnerability.URLs, ref.ReferenceLocator)
+				continue
+			}
+			indexes[id] = len(findings)
+			findings = append(findings, finding{
+				field: fmt.Sprintf("packages[%d].externalRefs[%d]", i, j),
+				pkg:   &pkg,
+				vulnerability: &importers.Vulnerability{
+					ID:               id,
+					Description:      ref.Comment,
+					URLs:             []string{ref.ReferenceLocator},
+					Package:          pkg.Name,
+					InstalledVersion: pkg.Version,
+				},
+			})
+		}
+	}
+	return
+}
+
+// advisoryID returns the ID of the vulnerability of an advisory URL.
+func advisoryID(url string) string {
+	if id := vulnerabilityIDs.FindString(url); id != "" {
+		return strings.ToUpper(id[:4]) + id[4:]
+	}
+	return path.Base(strings.TrimRight(strings.SplitN(url, "?", 2)[0], "/"))
+}
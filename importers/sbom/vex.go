@@ -0,0 +1,249 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Constants associated with the Statement.Status property.
+// - not_affected&#58; The product isn't affected by the vulnerability.
+// - affected&#58; The product is affected by the vulnerability.
+// - fixed&#58; The product contains a fix of the vulnerability.
+// - under_investigation&#58; It isn't known yet whether the product is affected.
+const (
+	Status_NotAffected        = "not_affected"
+	Status_Affected           = "affected"
+	Status_Fixed              = "fixed"
+	Status_UnderInvestigation = "under_investigation"
+)
+
+// VEX : A Vulnerability Exploitability eXchange document in the OpenVEX format, stating whether products are
+// affected by vulnerabilities
+type VEX struct {
+	Context string `json:"@context"`
+
+	ID string `json:"@id,omitempty"`
+
+	Author string `json:"author,omitempty"`
+
+	// The statements, the latest last.
+	Statements []Statement `json:"statements"`
+}
+
+// Statement : The status of products regarding a vulnerability
+type Statement struct {
+	Vulnerability Identifier `json:"vulnerability"`
+
+	// The products the statement applies to, all the packages when empty.
+	Products []Identifier `json:"products,omitempty"`
+
+	// One of the Status constants.
+	Status string `json:"status"`
+
+	Justification string `json:"justification,omitempty"`
+
+	ImpactStatement string `json:"impact_statement,omitempty"`
+
+	// The remediation of affected products.
+	ActionStatement string `json:"action_statement,omitempty"`
+}
+
+// Identifier : The identity of a vulnerability or product. OpenVEX 0.2 identifies them with objects, and earlier
+// versions with strings, which are read as the ID.
+type Identifier struct {
+
+	// The IRI of the vulnerability or product, e.g. a package URL.
+	ID string `json:"@id,omitempty"`
+
+	// The name of the vulnerability, e.g. CVE-2021-44228.
+	Name string `json:"name,omitempty"`
+
+	// Other identifiers of the product, by type, e.g. purl.
+	Identifiers map[string]string `json:"identifiers,omitempty"`
+}
+
+// UnmarshalJSON : Reads an identifier from an object or a string
+func (identifier *Identifier) UnmarshalJSON(data []byte) error {
+	var id string
+	if json.Unmarshal(data, &id) == nil {
+		*identifier = Identifier{ID: id}
+		return nil
+	}
+	type plain Identifier
+	return json.Unmarshal(data, (*plain)(identifier))
+}
+
+// vulnerabilityID returns the ID of a vulnerability: its name, else its IRI.
+func (identifier *Identifier) vulnerabilityID() string {
+	if identifier.Name != "" {
+		return identifier.Name
+	}
+	return identifier.ID
+}
+
+// matches reports whether the product identifier refers to the package: to its reference, e.g. through a BOM-Link
+// ending with "#" and the bom-ref, or to its package URL. Package URLs without version match every version.
+func (identifier *Identifier) matches(pkg *Package) bool {
+	ids := []string{identifier.ID, identifier.Identifiers["purl"]}
+	for _, id := range ids {
+		switch {
+		case id == "":
+		case pkg.Ref != "" && (id == pkg.Ref || strings.HasSuffix(id, "#"+pkg.Ref)):
+			return true
+		case pkg.PURL != "" && strings.HasPrefix(id, "pkg:"):
+			id = purlWithoutQualifiers(id)
+			if id == purlWithoutQualifiers(pkg.PURL) || id == purlWithoutVersion(pkg.PURL) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseVEX : Reads a VEX document, in the OpenVEX format or as a CycloneDX BOM with vulnerabilities. The analyses
+// of a CycloneDX BOM are converted to statements about the components they affect, identified by package URL when
+// the BOM has one.
+func ParseVEX(r io.Reader) (vex *VEX, err error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VEX document: %s", err.Error())
+	}
+	var header struct {
+		Context   string `json:"@context"`
+		BOMFormat string `json:"bomFormat"`
+	}
+	if err = json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse VEX document: %s", err.Error())
+	}
+	switch {
+	case strings.Contains(header.Context, "openvex"):
+		vex = new(VEX)
+		if err = json.Unmarshal(data, vex); err != nil {
+			return nil, fmt.Errorf("failed to parse OpenVEX document: %s", err.Error())
+		}
+		return
+	case header.BOMFormat == Format_CycloneDX:
+		bom := new(BOM)
+		if err = json.Unmarshal(data, bom); err != nil {
+			return nil, fmt.Errorf("failed to parse CycloneDX VEX document: %s", err.Error())
+		}
+		return bom.vex(), nil
+	default:
+		return nil, fmt.Errorf("unsupported VEX document, expected OpenVEX or CycloneDX JSON")
+	}
+}
+
+// vex converts the analyses of the vulnerabilities of the BOM to statements.
+func (bom *BOM) vex() *VEX {
+	purls := make(map[string]string)
+	for _, pkg := range bom.packages() {
+		if pkg.Ref != "" && pkg.PURL != "" {
+			purls[pkg.Ref] = pkg.PURL
+		}
+	}
+	vex := &VEX{ID: bom.SerialNumber, Statements: []Statement{}}
+	for _, vulnerability := range bom.Vulnerabilities {
+		if vulnerability.Analysis == nil || analysisStatus(vulnerability.Analysis.State) == "" {
+			continue
+		}
+		statement := Statement{
+			Vulnerability:   Identifier{Name: vulnerability.ID},
+			Status:          analysisStatus(vulnerability.Analysis.State),
+			Justification:   vulnerability.Analysis.Justification,
+			ImpactStatement: vulnerability.Analysis.Detail,
+			ActionStatement: vulnerability.Recommendation,
+		}
+		for _, affect := range vulnerability.Affects {
+			product := Identifier{ID: affect.Ref}
+			if purl, ok := purls[affect.Ref]; ok {
+				product.ID = purl
+			}
+			statement.Products = append(statement.Products, product)
+		}
+		vex.Statements = append(vex.Statements, statement)
+	}
+	return vex
+}
+
+// Statement : Returns the latest statement about the vulnerability in the package, nil when there is none
+func (vex *VEX) Statement(vulnerabilityID string, pkg *Package) *Statement {
+	if vex == nil {
+		return nil
+	}
+	for i := len(vex.Statements) - 1; i >= 0; i-- {
+		statement := &vex.Statements[i]
+		if !strings.EqualFold(statement.Vulnerability.vulnerabilityID(), vulnerabilityID) {
+			continue
+		}
+		if len(statement.Products) == 0 {
+			return statement
+		}
+		for j := range statement.Products {
+			if statement.Products[j].matches(pkg) {
+				return statement
+			}
+		}
+	}
+	return nil
+}
+
+// findings returns the vulnerabilities the document states affect packages of the SBOM, except the ones already
+// found in the SBOM.
+func (vex *VEX) findings(packages []Package, found map[string]bool) (findings []finding) {
+	if vex == nil {
+		return
+	}
+	for i := range vex.Statements {
+		statement := &vex.Statements[i]
+		id := statement.Vulnerability.vulnerabilityID()
+		if statement.Status != Status_Affected || len(statement.Products) == 0 {
+			continue
+		}
+		for j := range packages {
+			pkg := &packages[j]
+			key := findingKey(id, pkg)
+			if found[key] {
+				continue
+			}
+			for k := range statement.Products {
+				if statement.Products[k].matches(pkg) {
+					found[key] = true
+					findings = append(findings, finding{
+						field: fmt.Sprintf("vex.statements[%d]", i),
+						pkg:   pkg,
+						vulnerability: &importers.Vulnerability{
+							ID:               id,
+							Description:      statement.ImpactStatement,
+							URLs:             []string{statement.Vulnerability.ID},
+							Package:          pkg.Name,
+							InstalledVersion: pkg.Version,
+						},
+					})
+					break
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,77 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/sbom"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VEX`, func() {
+	lodash := &sbom.Package{Ref: "SPDXRef-lodash", Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20"}
+
+	It(`reads OpenVEX identifiers as objects or strings`, func() {
+		vex := readVEX("testdata/openvex.json")
+		Expect(vex.Statements).To(HaveLen(4))
+		Expect(vex.Statements[0].Vulnerability.Name).To(Equal("GHSA-xvch-5gv4-984h"))
+		Expect(vex.Statements[3].Vulnerability.ID).To(Equal("CVE-2021-23337"))
+		Expect(vex.Statements[3].Products[0].ID).To(Equal("pkg:npm/underscore@1.13.1"))
+	})
+	It(`returns the latest statement about a package`, func() {
+		vex := readVEX("testdata/openvex.json")
+		Expect(vex.Statement("CVE-2021-23337", lodash).Status).To(Equal(sbom.Status_Affected))
+		Expect(vex.Statement("cve-2021-23337", lodash).ActionStatement).To(Equal("Upgrade lodash to 4.17.21"))
+		Expect(vex.Statement("CVE-2022-25883", lodash)).To(BeNil())
+
+		semver := &sbom.Package{Name: "semver", PURL: "pkg:npm/semver@7.3.5"}
+		Expect(vex.Statement("CVE-2022-25883", semver).Status).To(Equal(sbom.Status_Affected))
+		Expect(vex.Statement("CVE-2022-25883", &sbom.Package{PURL: "pkg:npm/semver-utils@1.0.0"})).To(BeNil())
+
+		vex.Statements = append(vex.Statements, sbom.Statement{Vulnerability: sbom.Identifier{Name: "CVE-2021-23337"}, Status: sbom.Status_Fixed})
+		Expect(vex.Statement("CVE-2021-23337", lodash).Status).To(Equal(sbom.Status_Fixed))
+
+		var none *sbom.VEX
+		Expect(none.Statement("CVE-2021-23337", lodash)).To(BeNil())
+	})
+	It(`converts the analyses of a CycloneDX BOM to statements`, func() {
+		vex, err := sbom.ParseVEX(strings.NewReader(`{"bomFormat": "CycloneDX", "specVersion": "1.5",
+			"components": [{"bom-ref": "lodash", "name": "lodash", "purl": "pkg:npm/lodash@4.17.20"}],
+			"vulnerabilities": [
+				{"id": "CVE-2021-23337", "recommendation": "Upgrade lodash", "analysis": {"state": "false_positive", "detail": "Not lodash"},
+					"affects": [{"ref": "lodash"}, {"ref": "urn:cdx:other/1#SPDXRef-lodash"}]},
+				{"id": "CVE-2020-8203", "affects": [{"ref": "lodash"}]}
+			]}`))
+		Expect(err).To(BeNil())
+		Expect(vex.Statements).To(HaveLen(1))
+		statement := vex.Statements[0]
+		Expect(statement.Status).To(Equal(sbom.Status_NotAffected))
+		Expect(statement.ImpactStatement).To(Equal("Not lodash"))
+		Expect(statement.ActionStatement).To(Equal("Upgrade lodash"))
+		Expect(statement.Products[0].ID).To(Equal("pkg:npm/lodash@4.17.20"))
+		Expect(vex.Statement("CVE-2021-23337", lodash)).To(Equal(&vex.Statements[0]))
+		Expect(vex.Statement("CVE-2021-23337", &sbom.Package{Ref: "SPDXRef-lodash"})).To(Equal(&vex.Statements[0]))
+	})
+	It(`rejects unknown formats`, func() {
+		_, err := sbom.ParseVEX(strings.NewReader(`{"@context": "https://example.com"}`))
+		Expect(err).ToNot(BeNil())
+		_, err = sbom.ParseVEX(strings.NewReader(`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": {}}`))
+		Expect(err).ToNot(BeNil())
+	})
+})
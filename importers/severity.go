@@ -0,0 +1,103 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// severityNames maps the severity names used by security tools to severities.
+var severityNames = map[string]findingsapiv1.Severity{
+	"critical":      findingsapiv1.Severity_Critical,
+	"high":          findingsapiv1.Severity_High,
+	"important":     findingsapiv1.Severity_High,
+	"error":         findingsapiv1.Severity_High,
+	"medium":        findingsapiv1.Severity_Medium,
+	"moderate":      findingsapiv1.Severity_Medium,
+	"warning":       findingsapiv1.Severity_Medium,
+	"low":           findingsapiv1.Severity_Low,
+	"minor":         findingsapiv1.Severity_Low,
+	"negligible":    findingsapiv1.Severity_Low,
+	"info":          findingsapiv1.Severity_Low,
+	"informational": findingsapiv1.Severity_Low,
+	"note":          findingsapiv1.Severity_Low,
+}
+
+// SeverityFromCVSS : Converts a CVSS v3 base score to a severity following the CVSS qualitative rating: 9.0 and
+// above is CRITICAL, 7.0 and above HIGH, 4.0 and above MEDIUM, and anything lower LOW
+func SeverityFromCVSS(score float64) findingsapiv1.Severity {
+	switch {
+	case score >= 9:
+		return findingsapiv1.Severity_Critical
+	case score >= 7:
+		return findingsapiv1.Severity_High
+	case score >= 4:
+		return findingsapiv1.Severity_Medium
+	default:
+		return findingsapiv1.Severity_Low
+	}
+}
+
+// SeverityFromName : Converts a case-insensitive severity name of a security tool, e.g. "Moderate" or
+// "negligible", to a severity. Returns fallback for unknown names.
+func SeverityFromName(name string, fallback findingsapiv1.Severity) findingsapiv1.Severity {
+	if severity, ok := severityNames[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return severity
+	}
+	return fallback
+}
+
+// SeverityPtr : Returns the severity as expected by FindingType and Finding
+func SeverityPtr(severity findingsapiv1.Severity) *string {
+	value := string(severity)
+	return &value
+}
+
+// RaiseNoteSeverities : Raises the severity of each FINDING note of the report to the highest severity of its
+// occurrences, then clears the severity of the occurrences having the one of their note. Importers of tools that
+// rate each finding rather than each rule set the severity of every occurrence, then call it once all are added.
+func (report *Report) RaiseNoteSeverities() {
+	highest := make(map[string]findingsapiv1.Severity, len(report.Notes))
+	for i := range report.Notes {
+		highest[StringValue(report.Notes[i].ID)] = report.Notes[i].Finding.GetSeverity()
+	}
+	noteIDs := make([]string, len(report.Occurrences))
+	for i, occurrence := range report.Occurrences {
+		_, providerID, noteID, err := findingsapiv1.ParseNoteName(StringValue(occurrence.NoteName))
+		if err != nil || providerID != report.ProviderID || !report.HasNote(noteID) {
+			continue
+		}
+		noteIDs[i] = noteID
+		if severity := occurrence.Finding.GetSeverity(); severity.Compare(highest[noteID]) > 0 {
+			highest[noteID] = severity
+		}
+	}
+	for i := range report.Notes {
+		note := &report.Notes[i]
+		if severity := highest[StringValue(note.ID)]; note.Finding != nil && severity != "" {
+			note.Finding.Severity = SeverityPtr(severity)
+		}
+	}
+	for i := range report.Occurrences {
+		finding := report.Occurrences[i].Finding
+		if finding != nil && noteIDs[i] != "" && finding.GetSeverity() == highest[noteIDs[i]] {
+			finding.Severity = nil
+		}
+	}
+}
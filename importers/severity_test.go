@@ -0,0 +1,75 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Severity`, func() {
+	It(`follows the CVSS qualitative rating`, func() {
+		Expect(importers.SeverityFromCVSS(9.8)).To(Equal(findingsapiv1.Severity_Critical))
+		Expect(importers.SeverityFromCVSS(9.0)).To(Equal(findingsapiv1.Severity_Critical))
+		Expect(importers.SeverityFromCVSS(7.5)).To(Equal(findingsapiv1.Severity_High))
+		Expect(importers.SeverityFromCVSS(4.0)).To(Equal(findingsapiv1.Severity_Medium))
+		Expect(importers.SeverityFromCVSS(3.9)).To(Equal(findingsapiv1.Severity_Low))
+		Expect(importers.SeverityFromCVSS(0)).To(Equal(findingsapiv1.Severity_Low))
+	})
+	It(`converts the severity names of tools`, func() {
+		Expect(importers.SeverityFromName("CRITICAL", findingsapiv1.Severity_Low)).To(Equal(findingsapiv1.Severity_Critical))
+		Expect(importers.SeverityFromName(" Moderate", findingsapiv1.Severity_Low)).To(Equal(findingsapiv1.Severity_Medium))
+		Expect(importers.SeverityFromName("Negligible", findingsapiv1.Severity_High)).To(Equal(findingsapiv1.Severity_Low))
+		Expect(importers.SeverityFromName("Unknown", findingsapiv1.Severity_Medium)).To(Equal(findingsapiv1.Severity_Medium))
+		Expect(*importers.SeverityPtr(findingsapiv1.Severity_High)).To(Equal("HIGH"))
+	})
+	It(`raises the severity of notes to the highest severity of their occurrences`, func() {
+		report := importers.NewReport("my-tool", nil)
+		for _, noteID := range []string{"raised", "kept"} {
+			report.AddNote(findingsapiv1.ApiNote{
+				ID:      core.StringPtr(noteID),
+				Kind:    core.StringPtr("FINDING"),
+				Finding: &findingsapiv1.FindingType{Severity: core.StringPtr("MEDIUM")},
+			})
+		}
+		occurrence := func(id string, noteName string, severity string) findingsapiv1.ApiOccurrence {
+			return findingsapiv1.ApiOccurrence{
+				ID:       core.StringPtr(id),
+				NoteName: core.StringPtr(noteName),
+				Kind:     core.StringPtr("FINDING"),
+				Finding:  &findingsapiv1.Finding{Severity: importers.StringPtr(severity)},
+			}
+		}
+		report.AddOccurrence(occurrence("a", report.NoteName("raised"), "MEDIUM"))
+		report.AddOccurrence(occurrence("b", report.NoteName("raised"), "CRITICAL"))
+		report.AddOccurrence(occurrence("c", report.NoteName("kept"), "LOW"))
+		report.AddOccurrence(occurrence("d", report.NoteName("kept"), ""))
+		report.AddOccurrence(occurrence("e", "providers/other-tool/notes/raised", "LOW"))
+		report.RaiseNoteSeverities()
+
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("MEDIUM"))
+		Expect(*report.Occurrences[0].Finding.Severity).To(Equal("MEDIUM"))
+		Expect(report.Occurrences[1].Finding.Severity).To(BeNil())
+		Expect(*report.Occurrences[2].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Occurrences[3].Finding.Severity).To(BeNil())
+		Expect(*report.Occurrences[4].Finding.Severity).To(Equal("LOW"))
+	})
+})
@@ -0,0 +1,147 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tfsec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// The URL the file paths are resolved against to build the resource URL of the occurrences, e.g.
+	// https://github.com/my-org/my-repo/blob/main/.
+	BaseURL string
+
+	// The directory tfsec scanned, removed from the absolute file paths of the report, e.g. the root of the
+	// repository.
+	SourceRoot string
+}
+
+// Import : Reads the JSON report of tfsec and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the checks of the failed results to FINDING notes, and the failed results to occurrences.
+//
+// Notes are identified by the check ID, e.g. AVD-AWS-0086. Their severity is the highest severity of the results of
+// the check, their long description the impact of the misconfiguration, and their next step its resolution, linking
+// to the documentation of the check. The other links of the check are related URLs.
+//
+// Occurrences are identified by the check, file and resource address, so that moving the resource in its file keeps
+// the occurrence. Their context names the resource and its type, e.g. aws_s3_bucket, and their resource URL is the
+// line of the file when the options have a base URL. Passed and ignored results are skipped. The provider defaults
+// to tfsec.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("tfsec")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "tfsec", "https://github.com/aquasecurity/tfsec"))
+
+	for i := range output.Results {
+		result := &output.Results[i]
+		if result.Status != Status_Failed {
+			continue
+		}
+		checkID := result.checkID()
+		if checkID == "" {
+			return nil, fmt.Errorf("results[%d]: the result has no check", i)
+		}
+		resultNote := newNote(result)
+		report.AddNote(resultNote)
+		note := report.Note(*resultNote.ID)
+
+		path := importers.RelativePath(opts.SourceRoot, result.Location.Filename)
+		occurrence := findingsapiv1.ApiOccurrence{
+			ID:       core.StringPtr(importers.StableID(report.ProviderID, checkID, path, result.Resource)),
+			NoteName: core.StringPtr(report.NoteName(*note.ID)),
+			Kind:     core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+			Finding:  &findingsapiv1.Finding{},
+		}
+		occurrence.Finding.Severity = importers.SeverityPtr(resultSeverity(result))
+		var context *findingsapiv1.Context
+		occurrence.ResourceURL, context = importers.ResourceLocation(opts.BaseURL, path, result.Location.StartLine,
+			result.Resource, importers.TerraformResourceType(result.Resource))
+		occurrence.Context = opts.OccurrenceContext(context)
+		report.AddOccurrence(occurrence)
+	}
+	report.RaiseNoteSeverities()
+	return
+}
+
+// checkID returns the ID of the check of the result, its long ID for reports of tfsec versions without check IDs.
+func (result *Result) checkID() string {
+	if result.RuleID != "" {
+		return result.RuleID
+	}
+	return result.LongID
+}
+
+// newNote converts the check of the result to a FINDING note.
+func newNote(result *Result) findingsapiv1.ApiNote {
+	checkID := result.checkID()
+	shortDescription := result.RuleDescription
+	if shortDescription == "" {
+		shortDescription = checkID
+	}
+	longDescription := result.Impact
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(importers.SanitizeID(checkID)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: importers.SeverityPtr(resultSeverity(result))},
+	}
+	for _, link := range result.Links {
+		if !importers.IsWebURL(link) {
+			continue
+		}
+		if note.Finding.NextSteps == nil && result.Resolution != "" {
+			note.Finding.NextSteps = []findingsapiv1.RemediationStep{{Title: core.StringPtr(result.Resolution), URL: core.StringPtr(link)}}
+		}
+		note.RelatedURL = append(note.RelatedURL, findingsapiv1.ApiNoteRelatedURL{Label: core.StringPtr(checkID), URL: core.StringPtr(link)})
+	}
+	if note.Finding.NextSteps == nil && result.Resolution != "" {
+		note.Finding.NextSteps = []findingsapiv1.RemediationStep{{Title: core.StringPtr(result.Resolution)}}
+	}
+	return note
+}
+
+// resultSeverity returns the severity of the result, MEDIUM when unknown.
+func resultSeverity(result *Result) findingsapiv1.Severity {
+	return importers.SeverityFromName(result.Severity, findingsapiv1.Severity_Medium)
+}
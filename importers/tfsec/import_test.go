@@ -0,0 +1,112 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tfsec_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/tfsec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	importFile := func(opts *tfsec.Options) *importers.Report {
+		file, err := os.Open("testdata/tfsec.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := tfsec.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`creates one note per check`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("tfsec"))
+		Expect(*report.ReportedBy.Title).To(Equal("tfsec"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(2))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("AVD-AWS-0086"))
+		Expect(*note.ShortDescription).To(Equal("S3 Access block should block public ACL"))
+		Expect(*note.LongDescription).To(Equal("PUT calls with public ACLs specified can make objects public"))
+		Expect(*note.Finding.Severity).To(Equal("HIGH"))
+		Expect(note.Finding.NextSteps).To(HaveLen(1))
+		Expect(*note.Finding.NextSteps[0].Title).To(Equal("Enable blocking any PUT calls with a public ACL specified"))
+		Expect(*note.Finding.NextSteps[0].URL).To(Equal("https://aquasecurity.github.io/tfsec/v1.28.1/checks/aws/s3/block-public-acls/"))
+		Expect(note.RelatedURL).To(HaveLen(2))
+
+		Expect(*report.Notes[1].ID).To(Equal("AVD-AWS-0107"))
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("CRITICAL"))
+	})
+	It(`creates occurrences of the failed results per resource`, func() {
+		report := importFile(&tfsec.Options{BaseURL: "https://github.com/my-org/my-repo/blob/main/", SourceRoot: "/src"})
+		Expect(report.Occurrences).To(HaveLen(3))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/tfsec/notes/AVD-AWS-0086"))
+		Expect(*occurrence.ResourceURL).To(Equal("https://github.com/my-org/my-repo/blob/main/infra/s3.tf#L1"))
+		Expect(*occurrence.Context.ResourceName).To(Equal("aws_s3_bucket.logs"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("aws_s3_bucket"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("infra/s3.tf:1"))
+		Expect(occurrence.Finding.Severity).To(BeNil())
+
+		occurrence = report.Occurrences[1]
+		Expect(*occurrence.Context.ResourceName).To(Equal("module.storage.aws_s3_bucket.data[0]"))
+		Expect(*occurrence.Context.ResourceType).To(Equal("aws_s3_bucket"))
+		Expect(*occurrence.Context.ResourceID).To(Equal("infra/modules/storage/main.tf:10"))
+
+		Expect(report.Validate()).To(Succeed())
+	})
+	It(`keeps occurrence IDs when resources move`, func() {
+		report := importFile(nil)
+		moved, err := tfsec.Import(strings.NewReader(`{"results": [{"rule_id": "AVD-AWS-0086", "severity": "HIGH",
+			"resource": "aws_s3_bucket.logs", "location": {"filename": "/src/infra/s3.tf", "start_line": 20}}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*moved.Occurrences[0].ID).To(Equal(*report.Occurrences[0].ID))
+		Expect(report.Occurrences[0].ResourceURL).To(BeNil())
+	})
+	It(`gives notes the highest severity of the results of their check`, func() {
+		report, err := tfsec.Import(strings.NewReader(`{"results": [
+			{"rule_id": "AVD-AWS-0088", "severity": "LOW", "resource": "aws_s3_bucket.a", "location": {"filename": "s3.tf"}},
+			{"rule_id": "AVD-AWS-0088", "severity": "HIGH", "resource": "aws_s3_bucket.b", "location": {"filename": "s3.tf"}}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("HIGH"))
+		Expect(*report.Occurrences[0].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Occurrences[1].Finding.Severity).To(BeNil())
+	})
+	It(`supports reports without check IDs`, func() {
+		report, err := tfsec.Import(strings.NewReader(`{"results": [{"long_id": "aws-s3-enable-versioning",
+			"resolution": "Enable versioning", "severity": "LOW", "resource": "aws_s3_bucket.b", "location": {"filename": "s3.tf"}}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(*report.Notes[0].ID).To(Equal("aws-s3-enable-versioning"))
+		Expect(*report.Notes[0].ShortDescription).To(Equal("aws-s3-enable-versioning"))
+		Expect(report.Notes[0].Finding.NextSteps[0].URL).To(BeNil())
+		Expect(*report.Occurrences[0].Context.ResourceID).To(Equal("s3.tf"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := tfsec.Import(strings.NewReader(`{"results": {}}`), nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = tfsec.Import(strings.NewReader(`{"results": [{"resource": "aws_s3_bucket.b"}]}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("results[0]: the result has no check"))
+	})
+})
@@ -0,0 +1,100 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tfsec converts the JSON reports of tfsec, i.e. of "tfsec --format json", to notes and occurrences of the
+// misconfigurations of Terraform resources. Each check becomes a FINDING note, and each failed result an occurrence
+// of it.
+package tfsec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Constants associated with the Result.Status property.
+// - 0&#58; The resource fails the check.
+// - 1&#58; The resource passes the check, reported with --include-passed.
+// - 2&#58; The result is ignored with a tfsec:ignore comment, reported with --include-ignored.
+const (
+	Status_Failed  = 0
+	Status_Passed  = 1
+	Status_Ignored = 2
+)
+
+// Output : The JSON report of tfsec
+type Output struct {
+	Results []Result `json:"results"`
+}
+
+// Result : The result of a check of a resource
+type Result struct {
+
+	// The ID of the check, e.g. AVD-AWS-0086.
+	RuleID string `json:"rule_id"`
+
+	// The long ID of the check, e.g. aws-s3-block-public-acls.
+	LongID string `json:"long_id,omitempty"`
+
+	RuleDescription string `json:"rule_description"`
+
+	RuleProvider string `json:"rule_provider,omitempty"`
+
+	RuleService string `json:"rule_service,omitempty"`
+
+	// The impact of the misconfiguration.
+	Impact string `json:"impact,omitempty"`
+
+	// How to fix the misconfiguration.
+	Resolution string `json:"resolution,omitempty"`
+
+	// The documentation of the check, the tfsec documentation first.
+	Links []string `json:"links,omitempty"`
+
+	// The misconfiguration of the resource.
+	Description string `json:"description"`
+
+	// One of CRITICAL, HIGH, MEDIUM or LOW.
+	Severity string `json:"severity"`
+
+	Warning bool `json:"warning,omitempty"`
+
+	// One of the Status constants.
+	Status int `json:"status"`
+
+	// The address of the resource, e.g. aws_s3_bucket.logs.
+	Resource string `json:"resource"`
+
+	Location Location `json:"location"`
+}
+
+// Location : The lines of a result in a file
+type Location struct {
+	Filename string `json:"filename"`
+
+	StartLine int `json:"start_line"`
+
+	EndLine int `json:"end_line"`
+}
+
+// Parse : Reads the JSON report of tfsec
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(output); err != nil {
+		return nil, fmt.Errorf("failed to parse tfsec report: %s", err.Error())
+	}
+	return
+}
@@ -0,0 +1,137 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trivy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+)
+
+// Options : The Import options.
+type Options struct {
+	importers.Options
+
+	// One of the importers.NoteMode constants. Defaults to one note per vulnerability.
+	NoteMode string
+}
+
+// Import : Reads the JSON report of Trivy and converts it with Convert
+func Import(r io.Reader, opts *Options) (report *importers.Report, err error) {
+	output, err := Parse(r)
+	if err != nil {
+		return
+	}
+	return Convert(output, opts)
+}
+
+// Convert : Converts the vulnerabilities of the report to occurrences, and to one note per vulnerability or a single
+// note, following the note mode of the options.
+//
+// The severity is the qualitative rating of the CVSS v3 score of the severity source, or of the highest score when
+// Trivy doesn't tell its source, else the severity of Trivy. There is one occurrence per image digest, target,
+// package and vulnerability. Its resource URL is the image reference by digest, and its remediation the upgrade to the
+// fixed versions. The provider defaults to trivy.
+func Convert(output *Output, opts *Options) (report *importers.Report, err error) {
+	err = core.ValidateNotNil(output, "output cannot be nil")
+	if err != nil {
+		return
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	providerID := opts.Provider("trivy")
+	report = importers.NewReport(providerID, opts.Reporter(providerID, "Trivy", "https://github.com/aquasecurity/trivy"))
+
+	resourceURL, context := output.resource()
+	for i := range output.Results {
+		result := &output.Results[i]
+		for j := range result.Vulnerabilities {
+			finding := &result.Vulnerabilities[j]
+			vulnerability := finding.toVulnerability()
+			occurrenceID := importers.StableID(providerID, importers.StringValue(context.ResourceName), importers.StringValue(context.ResourceID), result.target(), finding.PkgName, finding.PkgPath, finding.VulnerabilityID)
+			err = report.AddVulnerability(vulnerability, opts.NoteMode, "Trivy", occurrenceID, resourceURL, opts.OccurrenceContext(context))
+			if err != nil {
+				return nil, fmt.Errorf("results[%d].vulnerabilities[%d]: %s", i, j, err.Error())
+			}
+		}
+	}
+	return
+}
+
+// resource returns the resource URL and the context of the scanned artifact. Images are referenced by digest when
+// Trivy reports one.
+func (output *Output) resource() (resourceURL *string, context *findingsapiv1.Context) {
+	if output.ArtifactType != ArtifactType_ContainerImage {
+		return nil, &findingsapiv1.Context{ResourceName: importers.StringPtr(output.ArtifactName), ResourceType: importers.StringPtr(output.ArtifactType)}
+	}
+	image := importers.ParseImage(output.ArtifactName)
+	if len(output.Metadata.RepoDigests) > 0 {
+		image.Digest = importers.ParseImage(output.Metadata.RepoDigests[0]).Digest
+	}
+	resourceID := image.Digest
+	if resourceID == "" {
+		resourceID = output.Metadata.ImageID
+	}
+	context = &findingsapiv1.Context{
+		ResourceName: core.StringPtr(image.Repository),
+		ResourceType: core.StringPtr(importers.ResourceType_Image),
+		ResourceID:   importers.StringPtr(resourceID),
+	}
+	return core.StringPtr(image.URL()), context
+}
+
+// target returns the identity of the target: its type for OS packages, whose target name includes the image tag,
+// else its name, e.g. the path of a lock file.
+func (result *Result) target() string {
+	if result.Class == Class_OSPackages {
+		return result.Type
+	}
+	return result.Target
+}
+
+// toVulnerability converts the vulnerability to the model shared by the importers.
+func (finding *Vulnerability) toVulnerability() *importers.Vulnerability {
+	vulnerability := &importers.Vulnerability{
+		ID:               finding.VulnerabilityID,
+		Title:            finding.Title,
+		Description:      finding.Description,
+		Severity:         importers.SeverityFromName(finding.Severity, ""),
+		URLs:             append([]string{finding.PrimaryURL}, finding.References...),
+		Package:          finding.PkgName,
+		InstalledVersion: finding.InstalledVersion,
+	}
+	if cvss, ok := finding.CVSS[finding.SeveritySource]; ok {
+		vulnerability.CVSSScore = cvss.V3Score
+	} else {
+		for _, cvss := range finding.CVSS {
+			if cvss.V3Score > vulnerability.CVSSScore {
+				vulnerability.CVSSScore = cvss.V3Score
+			}
+		}
+	}
+	for _, version := range strings.Split(finding.FixedVersion, ",") {
+		if version = strings.TrimSpace(version); version != "" {
+			vulnerability.FixedVersions = append(vulnerability.FixedVersions, version)
+		}
+	}
+	return vulnerability
+}
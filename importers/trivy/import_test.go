@@ -0,0 +1,119 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trivy_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers/trivy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Import`, func() {
+	imageURL := "https://us.icr.io/my-namespace/my-app@sha256:451eee8bedcb2f029756dc3e9d73bab0e7943c1ac55cff3a4861c52a0fdd3e98"
+	importFile := func(opts *trivy.Options) *importers.Report {
+		file, err := os.Open("testdata/trivy.json")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		report, err := trivy.Import(file, opts)
+		Expect(err).To(BeNil())
+		return report
+	}
+
+	It(`creates one note per vulnerability`, func() {
+		report := importFile(nil)
+		Expect(report.ProviderID).To(Equal("trivy"))
+		Expect(*report.ReportedBy.Title).To(Equal("Trivy"))
+		Expect(report.Validate()).To(Succeed())
+		Expect(report.Notes).To(HaveLen(3))
+
+		note := report.Notes[0]
+		Expect(*note.ID).To(Equal("CVE-2021-36159"))
+		Expect(*note.ShortDescription).To(HavePrefix("libfetch: an out of boundary read"))
+		Expect(*note.LongDescription).To(HavePrefix("libfetch before 2021-07-26"))
+		Expect(*note.Finding.Severity).To(Equal("CRITICAL"))
+		Expect(note.RelatedURL).To(HaveLen(2))
+		Expect(*note.RelatedURL[0].URL).To(Equal("https://avd.aquasec.com/nvd/cve-2021-36159"))
+
+		Expect(*report.Notes[1].ID).To(Equal("CVE-2021-3711"))
+		Expect(*report.Notes[1].Finding.Severity).To(Equal("HIGH"))
+		Expect(*report.Notes[2].ID).To(Equal("GHSA-p6mc-m468-83gw"))
+		Expect(*report.Notes[2].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Notes[2].RelatedURL).To(BeNil())
+	})
+	It(`creates occurrences per image digest`, func() {
+		report := importFile(&trivy.Options{Options: importers.Options{
+			Context: &findingsapiv1.Context{EnvironmentName: core.StringPtr("production")},
+		}})
+		Expect(report.Occurrences).To(HaveLen(4))
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/trivy/notes/CVE-2021-36159"))
+		Expect(*occurrence.ResourceURL).To(Equal(imageURL))
+		Expect(*occurrence.Remediation).To(Equal("Upgrade apk-tools 2.10.6-r0 to 2.10.7-r0"))
+		Expect(occurrence.Finding.Severity).To(BeNil())
+		Expect(*occurrence.Context.ResourceName).To(Equal("us.icr.io/my-namespace/my-app"))
+		Expect(*occurrence.Context.ResourceType).To(Equal(importers.ResourceType_Image))
+		Expect(*occurrence.Context.ResourceID).To(HavePrefix("sha256:451eee"))
+		Expect(*occurrence.Context.EnvironmentName).To(Equal("production"))
+
+		Expect(*report.Occurrences[1].Remediation).To(Equal("Upgrade libssl1.1 1.1.1k-r0 to 1.1.1l-r0 or 1.1.1m-r0"))
+		Expect(*report.Occurrences[2].Remediation).To(Equal("No fixed version of lodash 4.17.15 is available"))
+		Expect(*report.Occurrences[3].ID).ToNot(Equal(*report.Occurrences[1].ID))
+
+		Expect(report.Validate()).To(Succeed())
+		Expect(*importFile(nil).Occurrences[0].ID).To(Equal(*occurrence.ID))
+	})
+	It(`creates a single note in scanner mode`, func() {
+		report := importFile(&trivy.Options{NoteMode: importers.NoteMode_Scanner})
+		Expect(report.Notes).To(HaveLen(1))
+		Expect(*report.Notes[0].ID).To(Equal(importers.ScannerNoteID))
+		Expect(report.Validate()).To(Succeed())
+
+		occurrence := report.Occurrences[0]
+		Expect(*occurrence.NoteName).To(Equal("providers/trivy/notes/" + importers.ScannerNoteID))
+		Expect(*occurrence.Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*occurrence.Finding.NextSteps[0].Title).To(Equal("Review CVE-2021-36159"))
+		Expect(*occurrence.Finding.NextSteps[0].URL).To(Equal("https://avd.aquasec.com/nvd/cve-2021-36159"))
+		Expect(*report.Occurrences[2].Finding.Severity).To(Equal("LOW"))
+		Expect(report.Occurrences[2].Finding.NextSteps).To(BeNil())
+	})
+	It(`references artifacts other than images by name`, func() {
+		report, err := trivy.Import(strings.NewReader(`{"SchemaVersion": 2, "ArtifactName": "my-repo", "ArtifactType": "repository",
+			"Results": [{"Target": "go.sum", "Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "p", "Severity": "UNKNOWN"}]}]}`), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Occurrences[0].ResourceURL).To(BeNil())
+		Expect(*report.Occurrences[0].Context.ResourceName).To(Equal("my-repo"))
+		Expect(*report.Occurrences[0].Context.ResourceType).To(Equal("repository"))
+		Expect(*report.Notes[0].Finding.Severity).To(Equal("MEDIUM"))
+	})
+	It(`rejects invalid reports`, func() {
+		_, err := trivy.Import(strings.NewReader(`{"SchemaVersion": 1}`), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("unsupported Trivy schema version 1, expected 2"))
+
+		_, err = trivy.Import(strings.NewReader(`{"SchemaVersion": 2, "ArtifactName": "alpine",
+			"Results": [{"Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "p"}]}]}`), &trivy.Options{NoteMode: "image"})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`results[0].vulnerabilities[0]: unknown note mode "image"`))
+	})
+})
@@ -0,0 +1,120 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package trivy converts the JSON reports of Trivy, i.e. of "trivy image --format json", to notes and occurrences of
+// the vulnerabilities of container images.
+package trivy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ArtifactType_ContainerImage is the artifact type of the reports of image scans.
+const ArtifactType_ContainerImage = "container_image"
+
+// Class_OSPackages is the class of the results listing the packages of the OS of images.
+const Class_OSPackages = "os-pkgs"
+
+// Output : The JSON report of Trivy, schema version 2
+type Output struct {
+	SchemaVersion int `json:"SchemaVersion"`
+
+	ArtifactName string `json:"ArtifactName"`
+
+	ArtifactType string `json:"ArtifactType"`
+
+	Metadata Metadata `json:"Metadata"`
+
+	Results []Result `json:"Results"`
+}
+
+// Metadata : The metadata of the scanned artifact
+type Metadata struct {
+	ImageID string `json:"ImageID,omitempty"`
+
+	RepoTags []string `json:"RepoTags,omitempty"`
+
+	// The references of the image by digest, e.g. us.icr.io/my-namespace/my-app@sha256:5b8f...
+	RepoDigests []string `json:"RepoDigests,omitempty"`
+}
+
+// Result : The vulnerabilities of a target of the artifact, e.g. its OS packages or a lock file
+type Result struct {
+	Target string `json:"Target"`
+
+	Class string `json:"Class,omitempty"`
+
+	Type string `json:"Type,omitempty"`
+
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities,omitempty"`
+}
+
+// Vulnerability : A vulnerability of a package
+type Vulnerability struct {
+	VulnerabilityID string `json:"VulnerabilityID"`
+
+	PkgName string `json:"PkgName"`
+
+	PkgPath string `json:"PkgPath,omitempty"`
+
+	InstalledVersion string `json:"InstalledVersion"`
+
+	// The fixed versions, separated by commas.
+	FixedVersion string `json:"FixedVersion,omitempty"`
+
+	Status string `json:"Status,omitempty"`
+
+	// The vendor whose severity was picked, e.g. nvd or redhat.
+	SeveritySource string `json:"SeveritySource,omitempty"`
+
+	PrimaryURL string `json:"PrimaryURL,omitempty"`
+
+	Title string `json:"Title,omitempty"`
+
+	Description string `json:"Description,omitempty"`
+
+	Severity string `json:"Severity"`
+
+	// The CVSS scores by vendor.
+	CVSS map[string]CVSS `json:"CVSS,omitempty"`
+
+	References []string `json:"References,omitempty"`
+}
+
+// CVSS : The CVSS scores of a vendor
+type CVSS struct {
+	V2Vector string `json:"V2Vector,omitempty"`
+
+	V3Vector string `json:"V3Vector,omitempty"`
+
+	V2Score float64 `json:"V2Score,omitempty"`
+
+	V3Score float64 `json:"V3Score,omitempty"`
+}
+
+// Parse : Reads the JSON report of Trivy
+func Parse(r io.Reader) (output *Output, err error) {
+	output = new(Output)
+	if err = json.NewDecoder(r).Decode(output); err != nil {
+		return nil, fmt.Errorf("failed to parse Trivy report: %s", err.Error())
+	}
+	if output.SchemaVersion != 2 {
+		return nil, fmt.Errorf("unsupported Trivy schema version %d, expected 2", output.SchemaVersion)
+	}
+	return
+}
@@ -0,0 +1,180 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// ResourceType_Image is the resource type of the context of findings in container images.
+const ResourceType_Image = "Image"
+
+// Constants associated with the NoteMode option of the vulnerability importers.
+// - vulnerability&#58; One note per vulnerability, e.g. per CVE.
+// - scanner&#58; A single note for all the vulnerabilities found by the scanner, the occurrences carry the severity.
+const (
+	NoteMode_Vulnerability = "vulnerability"
+	NoteMode_Scanner       = "scanner"
+)
+
+// ScannerNoteID is the ID of the note of the NoteMode_Scanner mode.
+const ScannerNoteID = "vulnerable-package"
+
+// Vulnerability : A known vulnerability of a package, as reported by a vulnerability scanner
+type Vulnerability struct {
+
+	// The ID of the vulnerability, e.g. CVE-2021-36159 or GHSA-vvpx-j8f3-3w6h.
+	ID string
+
+	Title string
+
+	Description string
+
+	// The CVSS v3 base score, 0 when unknown.
+	CVSSScore float64
+
+	// The severity assigned by the scanner, used when the CVSS score is unknown.
+	Severity findingsapiv1.Severity
+
+	// Links to advisories about the vulnerability, the most relevant first.
+	URLs []string
+
+	// The vulnerable package.
+	Package string
+
+	InstalledVersion string
+
+	// The versions of the package fixing the vulnerability, none when there is no fix.
+	FixedVersions []string
+
+	// The remediation advised by the source of the vulnerability, e.g. a workaround, used instead of the upgrade to
+	// the fixed versions when set.
+	Recommendation string
+}
+
+// EffectiveSeverity : Returns the severity of the vulnerability: the qualitative rating of its CVSS score when
+// known, else the severity of the scanner, else MEDIUM
+func (vulnerability *Vulnerability) EffectiveSeverity() findingsapiv1.Severity {
+	switch {
+	case vulnerability.CVSSScore > 0:
+		return SeverityFromCVSS(vulnerability.CVSSScore)
+	case vulnerability.Severity.IsValid():
+		return vulnerability.Severity
+	default:
+		return findingsapiv1.Severity_Medium
+	}
+}
+
+// Remediation : Returns the recommendation of the vulnerability, or describes the upgrade of the package to its
+// fixed versions when it has none
+func (vulnerability *Vulnerability) Remediation() string {
+	if vulnerability.Recommendation != "" {
+		return vulnerability.Recommendation
+	}
+	installed := vulnerability.Package
+	if vulnerability.InstalledVersion != "" {
+		installed += " " + vulnerability.InstalledVersion
+	}
+	if len(vulnerability.FixedVersions) == 0 {
+		return fmt.Sprintf("No fixed version of %s is available", installed)
+	}
+	return fmt.Sprintf("Upgrade %s to %s", installed, strings.Join(vulnerability.FixedVersions, " or "))
+}
+
+// Note : Returns the FINDING note of the vulnerability, identified by its sanitized ID
+func (vulnerability *Vulnerability) Note() findingsapiv1.ApiNote {
+	shortDescription := vulnerability.Title
+	if shortDescription == "" {
+		shortDescription = vulnerability.ID
+	}
+	longDescription := vulnerability.Description
+	if longDescription == "" {
+		longDescription = shortDescription
+	}
+	note := findingsapiv1.ApiNote{
+		ID:               core.StringPtr(SanitizeID(vulnerability.ID)),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr(shortDescription),
+		LongDescription:  core.StringPtr(longDescription),
+		Finding:          &findingsapiv1.FindingType{Severity: SeverityPtr(vulnerability.EffectiveSeverity())},
+	}
+	seen := make(map[string]bool)
+	for _, url := range vulnerability.URLs {
+		if IsWebURL(url) && !seen[url] {
+			seen[url] = true
+			note.RelatedURL = append(note.RelatedURL, findingsapiv1.ApiNoteRelatedURL{Label: core.StringPtr(vulnerability.ID), URL: core.StringPtr(url)})
+		}
+	}
+	return note
+}
+
+// ScannerNote : Returns the FINDING note of the NoteMode_Scanner mode, shared by all the vulnerabilities found by
+// the scanner
+func ScannerNote(scanner string) findingsapiv1.ApiNote {
+	return findingsapiv1.ApiNote{
+		ID:               core.StringPtr(ScannerNoteID),
+		Kind:             core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ShortDescription: core.StringPtr("Vulnerable package"),
+		LongDescription:  core.StringPtr(fmt.Sprintf("%s found a package with a known vulnerability.", scanner)),
+		Finding:          &findingsapiv1.FindingType{Severity: SeverityPtr(findingsapiv1.Severity_Medium)},
+	}
+}
+
+// AddVulnerability : Adds the occurrence of the vulnerability with the given ID and context, and its note. In the
+// NoteMode_Vulnerability mode, or when mode is empty, the note is the one of the vulnerability. In the
+// NoteMode_Scanner mode, it is the note of the scanner, and the occurrence has the severity of the vulnerability and
+// a next step linking to it. The remediation of the occurrence is the upgrade to the fixed versions.
+func (report *Report) AddVulnerability(vulnerability *Vulnerability, mode string, scanner string, occurrenceID string, resourceURL *string, context *findingsapiv1.Context) error {
+	occurrence := findingsapiv1.ApiOccurrence{
+		ID:          core.StringPtr(occurrenceID),
+		Kind:        core.StringPtr(string(findingsapiv1.ApiNoteKind_Finding)),
+		ResourceURL: resourceURL,
+		Remediation: core.StringPtr(vulnerability.Remediation()),
+		Context:     context,
+		Finding:     &findingsapiv1.Finding{},
+	}
+	switch mode {
+	case "", NoteMode_Vulnerability:
+		note := vulnerability.Note()
+		if *note.ID == "" {
+			return fmt.Errorf("the vulnerability of %s has no ID", vulnerability.Package)
+		}
+		report.AddNote(note)
+		occurrence.NoteName = core.StringPtr(report.NoteName(*note.ID))
+	case NoteMode_Scanner:
+		report.AddNote(ScannerNote(scanner))
+		occurrence.NoteName = core.StringPtr(report.NoteName(ScannerNoteID))
+		occurrence.Finding.Severity = SeverityPtr(vulnerability.EffectiveSeverity())
+		for _, url := range vulnerability.URLs {
+			if IsWebURL(url) {
+				occurrence.Finding.NextSteps = []findingsapiv1.RemediationStep{{
+					Title: core.StringPtr(fmt.Sprintf("Review %s", vulnerability.ID)),
+					URL:   core.StringPtr(url),
+				}}
+				break
+			}
+		}
+	default:
+		return fmt.Errorf("unknown note mode %q", mode)
+	}
+	report.AddOccurrence(occurrence)
+	return nil
+}
@@ -0,0 +1,73 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package importers_test
+
+import (
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/importers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Vulnerability`, func() {
+	vulnerability := &importers.Vulnerability{
+		ID:               "CVE-2021-3711",
+		Title:            "openssl: SM2 Decryption Buffer Overflow",
+		CVSSScore:        9.8,
+		Severity:         findingsapiv1.Severity_High,
+		URLs:             []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-3711", "", "https://nvd.nist.gov/vuln/detail/CVE-2021-3711"},
+		Package:          "openssl",
+		InstalledVersion: "1.1.1k",
+		FixedVersions:    []string{"1.1.1l"},
+	}
+
+	It(`prefers the CVSS score to the severity of the scanner`, func() {
+		Expect(vulnerability.EffectiveSeverity()).To(Equal(findingsapiv1.Severity_Critical))
+		Expect((&importers.Vulnerability{Severity: findingsapiv1.Severity_Low}).EffectiveSeverity()).To(Equal(findingsapiv1.Severity_Low))
+		Expect((&importers.Vulnerability{Severity: "UNKNOWN"}).EffectiveSeverity()).To(Equal(findingsapiv1.Severity_Medium))
+	})
+	It(`describes the upgrade to the fixed versions`, func() {
+		Expect(vulnerability.Remediation()).To(Equal("Upgrade openssl 1.1.1k to 1.1.1l"))
+		Expect((&importers.Vulnerability{Package: "bzip2"}).Remediation()).To(Equal("No fixed version of bzip2 is available"))
+		Expect((&importers.Vulnerability{Package: "bzip2", Recommendation: "Disable bzip2 support"}).Remediation()).To(Equal("Disable bzip2 support"))
+	})
+	It(`converts to a note`, func() {
+		note := vulnerability.Note()
+		Expect(*note.ID).To(Equal("CVE-2021-3711"))
+		Expect(*note.ShortDescription).To(Equal("openssl: SM2 Decryption Buffer Overflow"))
+		Expect(*note.LongDescription).To(Equal(*note.ShortDescription))
+		Expect(*note.Finding.Severity).To(Equal("CRITICAL"))
+		Expect(note.RelatedURL).To(HaveLen(1))
+	})
+	It(`adds occurrences in each note mode`, func() {
+		report := importers.NewReport("scanner", nil)
+		context := &findingsapiv1.Context{ResourceName: core.StringPtr("my-app")}
+		Expect(report.AddVulnerability(vulnerability, "", "Scanner", "a", nil, context)).To(Succeed())
+		Expect(report.AddVulnerability(vulnerability, importers.NoteMode_Scanner, "Scanner", "b", nil, context)).To(Succeed())
+		Expect(report.AddVulnerability(vulnerability, "image", "Scanner", "c", nil, context)).ToNot(Succeed())
+
+		Expect(report.Notes).To(HaveLen(2))
+		Expect(*report.Occurrences[0].NoteName).To(Equal("providers/scanner/notes/CVE-2021-3711"))
+		Expect(report.Occurrences[0].Finding.Severity).To(BeNil())
+		Expect(*report.Occurrences[0].Remediation).To(Equal("Upgrade openssl 1.1.1k to 1.1.1l"))
+		Expect(*report.Occurrences[1].NoteName).To(Equal("providers/scanner/notes/" + importers.ScannerNoteID))
+		Expect(*report.Occurrences[1].Finding.Severity).To(Equal("CRITICAL"))
+		Expect(*report.Occurrences[1].Finding.NextSteps[0].Title).To(Equal("Review CVE-2021-3711"))
+		Expect(report.Occurrences).To(HaveLen(2))
+	})
+})
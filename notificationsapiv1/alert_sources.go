@@ -0,0 +1,335 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.ProviderName property.
+// The built-in providers. ALL represents all the providers and is mutually exclusive with the other ones.
+const (
+	NotificationChannelAlertSourceItem_ProviderName_Va            = "VA"
+	NotificationChannelAlertSourceItem_ProviderName_Na            = "NA"
+	NotificationChannelAlertSourceItem_ProviderName_Ata           = "ATA"
+	NotificationChannelAlertSourceItem_ProviderName_Cert          = "CERT"
+	NotificationChannelAlertSourceItem_ProviderName_ConfigAdvisor = "config-advisor"
+	NotificationChannelAlertSourceItem_ProviderName_All           = "ALL"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// The finding types of the VA provider.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_ImageWithVulnerabilities = "image_with_vulnerabilities"
+	NotificationChannelAlertSourceItem_FindingTypes_ImageWithConfigIssues    = "image_with_config_issues"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// The finding types of the NA provider.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_AnonymServer          = "anonym_server"
+	NotificationChannelAlertSourceItem_FindingTypes_MalwareServer         = "malware_server"
+	NotificationChannelAlertSourceItem_FindingTypes_BotServer             = "bot_server"
+	NotificationChannelAlertSourceItem_FindingTypes_MinerServer           = "miner_server"
+	NotificationChannelAlertSourceItem_FindingTypes_ServerSuspectedRatio  = "server_suspected_ratio"
+	NotificationChannelAlertSourceItem_FindingTypes_ServerResponse        = "server_response"
+	NotificationChannelAlertSourceItem_FindingTypes_DataExtrusion         = "data_extrusion"
+	NotificationChannelAlertSourceItem_FindingTypes_ServerWeaponizedTotal = "server_weaponized_total"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// The finding types of the ATA provider.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_Appid   = "appid"
+	NotificationChannelAlertSourceItem_FindingTypes_Cos     = "cos"
+	NotificationChannelAlertSourceItem_FindingTypes_Iks     = "iks"
+	NotificationChannelAlertSourceItem_FindingTypes_Iam     = "iam"
+	NotificationChannelAlertSourceItem_FindingTypes_Kms     = "kms"
+	NotificationChannelAlertSourceItem_FindingTypes_Cert    = "cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Account = "account"
+	NotificationChannelAlertSourceItem_FindingTypes_App     = "app"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// The finding types of the CERT provider.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_ExpiredCert       = "expired_cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Expiring1dayCert  = "expiring_1day_cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Expiring10dayCert = "expiring_10day_cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Expiring30dayCert = "expiring_30day_cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Expiring60dayCert = "expiring_60day_cert"
+	NotificationChannelAlertSourceItem_FindingTypes_Expiring90dayCert = "expiring_90day_cert"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// The finding types of the config-advisor provider.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionDnsNotProxied = "appprotection-dns_not_proxied"
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionDnssecOff     = "appprotection-dnssec_off"
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionSslNotStrict  = "appprotection-ssl_not_strict"
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionTlsMinVersion = "appprotection-tls_min_version"
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionWafOff        = "appprotection-waf_off"
+	NotificationChannelAlertSourceItem_FindingTypes_AppprotectionWafRules      = "appprotection-waf_rules"
+	NotificationChannelAlertSourceItem_FindingTypes_CalicoDenyAllRule          = "calico-deny_all_rule"
+	NotificationChannelAlertSourceItem_FindingTypes_CalicoNonstandardPorts     = "calico-nonstandard_ports"
+	NotificationChannelAlertSourceItem_FindingTypes_CalicoUpdateCisWhitelist   = "calico-update_cis_whitelist"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosCosManagers         = "datacos-cos_managers"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosNotEncryptedViaKp   = "datacos-not_encrypted_via_kp"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosNotInPrivateNetwork = "datacos-not_in_private_network"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicBucketAcl     = "datacos-public_bucket_acl"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicBucketIam     = "datacos-public_bucket_iam"
+	NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicObjectAcl     = "datacos-public_object_acl"
+	NotificationChannelAlertSourceItem_FindingTypes_IamAccountAdmins           = "iam-account_admins"
+	NotificationChannelAlertSourceItem_FindingTypes_IamAllResourceManagers     = "iam-all_resource_managers"
+	NotificationChannelAlertSourceItem_FindingTypes_IamAllResourceReaders      = "iam-all_resource_readers"
+	NotificationChannelAlertSourceItem_FindingTypes_IamIdentityAdmins          = "iam-identity_admins"
+	NotificationChannelAlertSourceItem_FindingTypes_IamKmsManagers             = "iam-kms_managers"
+	NotificationChannelAlertSourceItem_FindingTypes_IamOutOfGroup              = "iam-out_of_group"
+)
+
+// Constants associated with the NotificationChannelAlertSourceItem.FindingTypes property.
+// ALL represents all the finding types of the provider and is mutually exclusive with the other ones.
+const (
+	NotificationChannelAlertSourceItem_FindingTypes_All = "ALL"
+)
+
+// builtinFindingTypes lists the finding types of each built-in provider.
+var builtinFindingTypes = map[string][]string{
+	NotificationChannelAlertSourceItem_ProviderName_Va: {
+		NotificationChannelAlertSourceItem_FindingTypes_ImageWithVulnerabilities,
+		NotificationChannelAlertSourceItem_FindingTypes_ImageWithConfigIssues,
+	},
+	NotificationChannelAlertSourceItem_ProviderName_Na: {
+		NotificationChannelAlertSourceItem_FindingTypes_AnonymServer,
+		NotificationChannelAlertSourceItem_FindingTypes_MalwareServer,
+		NotificationChannelAlertSourceItem_FindingTypes_BotServer,
+		NotificationChannelAlertSourceItem_FindingTypes_MinerServer,
+		NotificationChannelAlertSourceItem_FindingTypes_ServerSuspectedRatio,
+		NotificationChannelAlertSourceItem_FindingTypes_ServerResponse,
+		NotificationChannelAlertSourceItem_FindingTypes_DataExtrusion,
+		NotificationChannelAlertSourceItem_FindingTypes_ServerWeaponizedTotal,
+	},
+	NotificationChannelAlertSourceItem_ProviderName_Ata: {
+		NotificationChannelAlertSourceItem_FindingTypes_Appid,
+		NotificationChannelAlertSourceItem_FindingTypes_Cos,
+		NotificationChannelAlertSourceItem_FindingTypes_Iks,
+		NotificationChannelAlertSourceItem_FindingTypes_Iam,
+		NotificationChannelAlertSourceItem_FindingTypes_Kms,
+		NotificationChannelAlertSourceItem_FindingTypes_Cert,
+		NotificationChannelAlertSourceItem_FindingTypes_Account,
+		NotificationChannelAlertSourceItem_FindingTypes_App,
+	},
+	NotificationChannelAlertSourceItem_ProviderName_Cert: {
+		NotificationChannelAlertSourceItem_FindingTypes_ExpiredCert,
+		NotificationChannelAlertSourceItem_FindingTypes_Expiring1dayCert,
+		NotificationChannelAlertSourceItem_FindingTypes_Expiring10dayCert,
+		NotificationChannelAlertSourceItem_FindingTypes_Expiring30dayCert,
+		NotificationChannelAlertSourceItem_FindingTypes_Expiring60dayCert,
+		NotificationChannelAlertSourceItem_FindingTypes_Expiring90dayCert,
+	},
+	NotificationChannelAlertSourceItem_ProviderName_ConfigAdvisor: {
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionDnsNotProxied,
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionDnssecOff,
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionSslNotStrict,
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionTlsMinVersion,
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionWafOff,
+		NotificationChannelAlertSourceItem_FindingTypes_AppprotectionWafRules,
+		NotificationChannelAlertSourceItem_FindingTypes_CalicoDenyAllRule,
+		NotificationChannelAlertSourceItem_FindingTypes_CalicoNonstandardPorts,
+		NotificationChannelAlertSourceItem_FindingTypes_CalicoUpdateCisWhitelist,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosCosManagers,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosNotEncryptedViaKp,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosNotInPrivateNetwork,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicBucketAcl,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicBucketIam,
+		NotificationChannelAlertSourceItem_FindingTypes_DatacosPublicObjectAcl,
+		NotificationChannelAlertSourceItem_FindingTypes_IamAccountAdmins,
+		NotificationChannelAlertSourceItem_FindingTypes_IamAllResourceManagers,
+		NotificationChannelAlertSourceItem_FindingTypes_IamAllResourceReaders,
+		NotificationChannelAlertSourceItem_FindingTypes_IamIdentityAdmins,
+		NotificationChannelAlertSourceItem_FindingTypes_IamKmsManagers,
+		NotificationChannelAlertSourceItem_FindingTypes_IamOutOfGroup,
+	},
+}
+
+// listProvidersPageSize is the number of providers fetched per ListProviders call.
+const listProvidersPageSize = 200
+
+// IsBuiltinProvider : Tells whether the provider name is one of the built-in providers, including ALL
+func IsBuiltinProvider(providerName string) bool {
+	_, ok := builtinFindingTypes[providerName]
+	return ok || providerName == NotificationChannelAlertSourceItem_ProviderName_All
+}
+
+// BuiltinFindingTypes : Returns the finding types of a built-in provider, nil for ALL and custom providers
+func BuiltinFindingTypes(providerName string) []string {
+	return copyStrings(builtinFindingTypes[providerName])
+}
+
+// ValidateAlertSources : Checks the alert sources of a channel without calling any service.
+// Provider names are required and must be unique, ALL must be the only provider when used, ALL must be the only
+// finding type when used, and the finding types of the built-in providers must be the documented ones. Custom
+// providers are accepted as is; use AlertSourceValidator to check them too. The returned error is a
+// findingsapiv1.ValidationErrors listing every violation, or nil.
+func ValidateAlertSources(alertSource []NotificationChannelAlertSourceItem) error {
+	errs, _ := validateAlertSources(alertSource)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate : Checks the alert sources of the options with ValidateAlertSources. It is called by
+// CreateNotificationChannel, so that invalid provider and finding type pairs fail before reaching the service.
+func (options *CreateNotificationChannelOptions) Validate() error {
+	return ValidateAlertSources(options.AlertSource)
+}
+
+// Validate : Checks the alert sources of the options with ValidateAlertSources. It is called by
+// UpdateNotificationChannel, so that invalid provider and finding type pairs fail before reaching the service.
+func (options *UpdateNotificationChannelOptions) Validate() error {
+	return ValidateAlertSources(options.AlertSource)
+}
+
+// AlertSourceValidator : Checks alert sources, including custom provider names, before they are sent to the service.
+type AlertSourceValidator struct {
+	findingsApi *findingsapiv1.FindingsApiV1
+}
+
+// NewAlertSourceValidator : Instantiate AlertSourceValidator, looking custom providers up with findingsApi
+func NewAlertSourceValidator(findingsApi *findingsapiv1.FindingsApiV1) *AlertSourceValidator {
+	return &AlertSourceValidator{findingsApi: findingsApi}
+}
+
+// Validate : Applies the checks of ValidateAlertSources, then checks that every custom provider name is the ID or the
+// name of a provider returned by the Findings ListProviders operation for the account. Violations are reported as
+// findingsapiv1.ValidationErrors; failing to list the providers is reported as is.
+func (validator *AlertSourceValidator) Validate(ctx context.Context, accountID string, alertSource []NotificationChannelAlertSourceItem) error {
+	errs, custom := validateAlertSources(alertSource)
+	if len(custom) > 0 {
+		providers, err := validator.providers(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		for i, providerName := range custom {
+			if providerName != "" && !providers[providerName] {
+				errs = append(errs, &findingsapiv1.ValidationError{
+					Field:   fmt.Sprintf("alert_source[%d].provider_name", i),
+					Message: fmt.Sprintf("%q is neither a built-in provider nor a provider of account %q", providerName, accountID),
+				})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateCreateOptions : Validates the alert sources of the options, see Validate
+func (validator *AlertSourceValidator) ValidateCreateOptions(ctx context.Context, options *CreateNotificationChannelOptions) error {
+	return validator.Validate(ctx, stringValue(options.AccountID), options.AlertSource)
+}
+
+// ValidateUpdateOptions : Validates the alert sources of the options, see Validate
+func (validator *AlertSourceValidator) ValidateUpdateOptions(ctx context.Context, options *UpdateNotificationChannelOptions) error {
+	return validator.Validate(ctx, stringValue(options.AccountID), options.AlertSource)
+}
+
+// providers returns the IDs and names of the providers of the account.
+func (validator *AlertSourceValidator) providers(ctx context.Context, accountID string) (map[string]bool, error) {
+	providers := map[string]bool{}
+	for skip := int64(0); ; skip += listProvidersPageSize {
+		options := validator.findingsApi.NewListProvidersOptions(accountID)
+		options.SetLimit(listProvidersPageSize)
+		options.SetSkip(skip)
+		result, _, err := validator.findingsApi.ListProvidersWithContext(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list providers: %s", err.Error())
+		}
+		for _, provider := range result.Providers {
+			if provider.ID != nil {
+				providers[*provider.ID] = true
+			}
+			if provider.Name != nil {
+				providers[*provider.Name] = true
+			}
+		}
+		if len(result.Providers) < listProvidersPageSize {
+			return providers, nil
+		}
+	}
+}
+
+// validateAlertSources returns the violations found without calling any service, and the custom provider names,
+// indexed like alertSource ("" for the built-in ones).
+func validateAlertSources(alertSource []NotificationChannelAlertSourceItem) (errs findingsapiv1.ValidationErrors, custom []string) {
+	addf := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &findingsapiv1.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	seen := map[string]bool{}
+	for i, item := range alertSource {
+		field := fmt.Sprintf("alert_source[%d]", i)
+		providerName := stringValue(item.ProviderName)
+		if strings.TrimSpace(providerName) == "" {
+			addf(field+".provider_name", "is required")
+			continue
+		}
+		if seen[providerName] {
+			addf(field+".provider_name", "%q is listed more than once", providerName)
+		}
+		seen[providerName] = true
+		if providerName == NotificationChannelAlertSourceItem_ProviderName_All && len(alertSource) > 1 {
+			addf(field+".provider_name", "%q is mutually exclusive with other providers", providerName)
+		}
+
+		for j, findingType := range item.FindingTypes {
+			findingTypeField := fmt.Sprintf("%s.finding_types[%d]", field, j)
+			switch {
+			case findingType == NotificationChannelAlertSourceItem_FindingTypes_All:
+				if len(item.FindingTypes) > 1 {
+					addf(findingTypeField, "%q is mutually exclusive with other finding types", findingType)
+				}
+			case providerName == NotificationChannelAlertSourceItem_ProviderName_All:
+				addf(findingTypeField, "must be %q for provider %q", NotificationChannelAlertSourceItem_FindingTypes_All, providerName)
+			case builtinFindingTypes[providerName] != nil && !contains(builtinFindingTypes[providerName], findingType):
+				addf(findingTypeField, "%q is not a finding type of provider %q", findingType, providerName)
+			}
+		}
+
+		if !IsBuiltinProvider(providerName) {
+			if custom == nil {
+				custom = make([]string, len(alertSource))
+			}
+			custom[i] = providerName
+		}
+	}
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
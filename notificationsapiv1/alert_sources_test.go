@@ -0,0 +1,150 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Alert sources`, func() {
+	source := func(providerName string, findingTypes ...string) notificationsapiv1.NotificationChannelAlertSourceItem {
+		return notificationsapiv1.NotificationChannelAlertSourceItem{ProviderName: core.StringPtr(providerName), FindingTypes: findingTypes}
+	}
+	fields := func(err error) []string {
+		var fields []string
+		for _, validationError := range err.(findingsapiv1.ValidationErrors) {
+			fields = append(fields, validationError.Field)
+		}
+		return fields
+	}
+
+	It(`lists the built-in providers and their finding types`, func() {
+		Expect(notificationsapiv1.IsBuiltinProvider(notificationsapiv1.NotificationChannelAlertSourceItem_ProviderName_ConfigAdvisor)).To(BeTrue())
+		Expect(notificationsapiv1.IsBuiltinProvider(notificationsapiv1.NotificationChannelAlertSourceItem_ProviderName_All)).To(BeTrue())
+		Expect(notificationsapiv1.IsBuiltinProvider("my-tool")).To(BeFalse())
+		Expect(notificationsapiv1.BuiltinFindingTypes(notificationsapiv1.NotificationChannelAlertSourceItem_ProviderName_Va)).To(Equal([]string{
+			notificationsapiv1.NotificationChannelAlertSourceItem_FindingTypes_ImageWithVulnerabilities,
+			notificationsapiv1.NotificationChannelAlertSourceItem_FindingTypes_ImageWithConfigIssues,
+		}))
+		Expect(notificationsapiv1.BuiltinFindingTypes(notificationsapiv1.NotificationChannelAlertSourceItem_ProviderName_Cert)).To(ContainElement("expiring_90day_cert"))
+		Expect(notificationsapiv1.BuiltinFindingTypes("my-tool")).To(BeNil())
+	})
+	It(`accepts valid alert sources`, func() {
+		Expect(notificationsapiv1.ValidateAlertSources(nil)).To(Succeed())
+		Expect(notificationsapiv1.ValidateAlertSources([]notificationsapiv1.NotificationChannelAlertSourceItem{
+			source("ALL", "ALL"),
+		})).To(Succeed())
+		Expect(notificationsapiv1.ValidateAlertSources([]notificationsapiv1.NotificationChannelAlertSourceItem{
+			source("VA", "ALL"),
+			source("CERT", "expired_cert", "expiring_1day_cert"),
+			source("my-tool", "anything"),
+		})).To(Succeed())
+	})
+	It(`reports every violation`, func() {
+		err := notificationsapiv1.ValidateAlertSources([]notificationsapiv1.NotificationChannelAlertSourceItem{
+			source("ALL", "ALL", "image_with_vulnerabilities"),
+			source("VA", "image_with_vulnerabilities", "expired_cert"),
+			source("VA"),
+			{},
+		})
+		Expect(fields(err)).To(Equal([]string{
+			"alert_source[0].provider_name",
+			"alert_source[0].finding_types[0]",
+			"alert_source[0].finding_types[1]",
+			"alert_source[1].finding_types[1]",
+			"alert_source[2].provider_name",
+			"alert_source[3].provider_name",
+		}))
+		Expect(err.Error()).To(ContainSubstring(`alert_source[0].provider_name: "ALL" is mutually exclusive with other providers`))
+	})
+	It(`rejects invalid alert sources before calling the service`, func() {
+		requests := 0
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			requests++
+			res.WriteHeader(500)
+		}))
+		defer testServer.Close()
+		service, err := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		alertSource := []notificationsapiv1.NotificationChannelAlertSourceItem{source("VA", "expired_cert")}
+		createOptions := service.NewCreateNotificationChannelOptions("account", "channel", "Webhook", "https://example.com")
+		createOptions.SetAlertSource(alertSource)
+		_, _, err = service.CreateNotificationChannel(createOptions)
+		Expect(fields(err)).To(Equal([]string{"alert_source[0].finding_types[0]"}))
+
+		updateOptions := createOptions.ToUpdateOptions("channel-id")
+		_, _, err = service.UpdateNotificationChannel(updateOptions)
+		Expect(fields(err)).To(Equal([]string{"alert_source[0].finding_types[0]"}))
+		Expect(requests).To(BeZero())
+	})
+	Context(`with custom providers`, func() {
+		var requests int
+		var validator *notificationsapiv1.AlertSourceValidator
+		var testServer *httptest.Server
+		BeforeEach(func() {
+			requests = 0
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				requests++
+				Expect(req.URL.Path).To(Equal("/v1/account/providers"))
+				Expect(req.URL.Query().Get("skip")).To(Equal("0"))
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				res.Write([]byte(`{"providers": [{"id": "my-tool", "name": "My tool"}]}`))
+			}))
+			findingsService, err := findingsapiv1.NewFindingsApiV1(&findingsapiv1.FindingsApiV1Options{
+				URL:           testServer.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+			})
+			Expect(err).To(BeNil())
+			validator = notificationsapiv1.NewAlertSourceValidator(findingsService)
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It(`checks them against the providers of the account`, func() {
+			options := &notificationsapiv1.CreateNotificationChannelOptions{
+				AccountID:   core.StringPtr("account"),
+				AlertSource: []notificationsapiv1.NotificationChannelAlertSourceItem{source("VA"), source("my-tool"), source("My tool")},
+			}
+			Expect(validator.ValidateCreateOptions(context.Background(), options)).To(Succeed())
+
+			options.AlertSource = append(options.AlertSource, source("unknown"))
+			err := validator.ValidateCreateOptions(context.Background(), options)
+			Expect(fields(err)).To(Equal([]string{"alert_source[3].provider_name"}))
+			Expect(requests).To(Equal(2))
+		})
+		It(`doesn't list providers for built-in ones`, func() {
+			Expect(validator.Validate(context.Background(), "account", []notificationsapiv1.NotificationChannelAlertSourceItem{source("CERT")})).To(Succeed())
+			Expect(requests).To(BeZero())
+		})
+	})
+})
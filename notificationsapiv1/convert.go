@@ -0,0 +1,246 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1
+
+import (
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+)
+
+// ToList : Returns the enabled severities in the format of the channel options, nil when severity is nil
+func (severity *ChannelResponseDefinitionSeverity) ToList() []string {
+	if severity == nil {
+		return nil
+	}
+	return severityList(severity.Critical, severity.High, severity.Medium, severity.Low)
+}
+
+// ToList : Returns the enabled severities in the format of the channel options, nil when severity is nil
+func (severity *GetChannelResponseChannelSeverity) ToList() []string {
+	if severity == nil {
+		return nil
+	}
+	return severityList(severity.Critical, severity.High, severity.Medium, severity.Low)
+}
+
+// NewChannelResponseDefinitionSeverity : Instantiate ChannelResponseDefinitionSeverity from severities in the format
+// of the channel options. Severities are matched case-insensitively; unknown ones are ignored.
+func NewChannelResponseDefinitionSeverity(severity []string) *ChannelResponseDefinitionSeverity {
+	critical, high, medium, low := severityFlags(severity)
+	return &ChannelResponseDefinitionSeverity{Critical: critical, High: high, Medium: medium, Low: low}
+}
+
+// NewGetChannelResponseChannelSeverity : Instantiate GetChannelResponseChannelSeverity from severities in the format
+// of the channel options. Severities are matched case-insensitively; unknown ones are ignored.
+func NewGetChannelResponseChannelSeverity(severity []string) *GetChannelResponseChannelSeverity {
+	critical, high, medium, low := severityFlags(severity)
+	return &GetChannelResponseChannelSeverity{Critical: critical, High: high, Medium: medium, Low: low}
+}
+
+// ToAlertSourceItem : Converts the alert source to the format of the channel options
+func (item ChannelResponseDefinitionAlertSourceItem) ToAlertSourceItem() NotificationChannelAlertSourceItem {
+	return NotificationChannelAlertSourceItem{ProviderName: copyString(item.ProviderName), FindingTypes: copyStrings(item.FindingTypes)}
+}
+
+// ToAlertSourceItem : Converts the alert source to the format of the channel options
+func (item GetChannelResponseChannelAlertSource) ToAlertSourceItem() NotificationChannelAlertSourceItem {
+	return NotificationChannelAlertSourceItem{ProviderName: copyString(item.ProviderName), FindingTypes: copyStrings(item.FindingTypes)}
+}
+
+// ToCreateOptions : Returns options creating a copy of the channel in the given account
+func (channel *ChannelResponseDefinition) ToCreateOptions(accountID string) *CreateNotificationChannelOptions {
+	alertSource := make([]NotificationChannelAlertSourceItem, len(channel.AlertSource))
+	for i, item := range channel.AlertSource {
+		alertSource[i] = item.ToAlertSourceItem()
+	}
+	return newCreateOptions(accountID, channel.Name, channel.Type, channel.Endpoint, channel.Description,
+		channel.Severity.ToList(), channel.Enabled, alertSource, channel.Frequency)
+}
+
+// ToUpdateOptions : Returns options updating the channel with its current definition, to be modified before calling
+// UpdateNotificationChannel
+func (channel *ChannelResponseDefinition) ToUpdateOptions(accountID string) *UpdateNotificationChannelOptions {
+	return channel.ToCreateOptions(accountID).ToUpdateOptions(stringValue(channel.ChannelID))
+}
+
+// ToCreateOptions : Returns options creating a copy of the channel in the given account
+func (channel *GetChannelResponseChannel) ToCreateOptions(accountID string) *CreateNotificationChannelOptions {
+	alertSource := make([]NotificationChannelAlertSourceItem, len(channel.AlertSource))
+	for i, item := range channel.AlertSource {
+		alertSource[i] = item.ToAlertSourceItem()
+	}
+	return newCreateOptions(accountID, channel.Name, channel.Type, channel.Endpoint, channel.Description,
+		channel.Severity.ToList(), channel.Enabled, alertSource, channel.Frequency)
+}
+
+// ToUpdateOptions : Returns options updating the channel with its current definition, to be modified before calling
+// UpdateNotificationChannel
+func (channel *GetChannelResponseChannel) ToUpdateOptions(accountID string) *UpdateNotificationChannelOptions {
+	return channel.ToCreateOptions(accountID).ToUpdateOptions(stringValue(channel.ChannelID))
+}
+
+// ToChannelResponseDefinition : Converts the channel to the format of ListAllChannels
+func (channel *GetChannelResponseChannel) ToChannelResponseDefinition() *ChannelResponseDefinition {
+	definition := &ChannelResponseDefinition{
+		ChannelID:   copyString(channel.ChannelID),
+		Name:        copyString(channel.Name),
+		Description: copyString(channel.Description),
+		Type:        copyString(channel.Type),
+		Endpoint:    copyString(channel.Endpoint),
+		Enabled:     copyBool(channel.Enabled),
+		Frequency:   copyString(channel.Frequency),
+	}
+	if channel.Severity != nil {
+		definition.Severity = &ChannelResponseDefinitionSeverity{
+			Critical: copyBool(channel.Severity.Critical),
+			High:     copyBool(channel.Severity.High),
+			Medium:   copyBool(channel.Severity.Medium),
+			Low:      copyBool(channel.Severity.Low),
+		}
+	}
+	if channel.AlertSource != nil {
+		definition.AlertSource = make([]ChannelResponseDefinitionAlertSourceItem, len(channel.AlertSource))
+		for i, item := range channel.AlertSource {
+			definition.AlertSource[i] = ChannelResponseDefinitionAlertSourceItem{ProviderName: copyString(item.ProviderName), FindingTypes: copyStrings(item.FindingTypes)}
+		}
+	}
+	return definition
+}
+
+// ToUpdateOptions : Returns options updating the channel of the given ID with the same definition
+func (options *CreateNotificationChannelOptions) ToUpdateOptions(channelID string) *UpdateNotificationChannelOptions {
+	return &UpdateNotificationChannelOptions{
+		AccountID:   copyString(options.AccountID),
+		ChannelID:   core.StringPtr(channelID),
+		Name:        copyString(options.Name),
+		Type:        copyString(options.Type),
+		Endpoint:    copyString(options.Endpoint),
+		Description: copyString(options.Description),
+		Severity:    copyStrings(options.Severity),
+		Enabled:     copyBool(options.Enabled),
+		AlertSource: copyAlertSource(options.AlertSource),
+		Frequency:   copyString(options.Frequency),
+		Headers:     copyHeaders(options.Headers),
+	}
+}
+
+// ToCreateOptions : Returns options creating a channel with the same definition
+func (options *UpdateNotificationChannelOptions) ToCreateOptions() *CreateNotificationChannelOptions {
+	return &CreateNotificationChannelOptions{
+		AccountID:   copyString(options.AccountID),
+		Name:        copyString(options.Name),
+		Type:        copyString(options.Type),
+		Endpoint:    copyString(options.Endpoint),
+		Description: copyString(options.Description),
+		Severity:    copyStrings(options.Severity),
+		Enabled:     copyBool(options.Enabled),
+		AlertSource: copyAlertSource(options.AlertSource),
+		Frequency:   copyString(options.Frequency),
+		Headers:     copyHeaders(options.Headers),
+	}
+}
+
+func newCreateOptions(accountID string, name, typeVar, endpoint, description *string, severity []string, enabled *bool, alertSource []NotificationChannelAlertSourceItem, frequency *string) *CreateNotificationChannelOptions {
+	options := &CreateNotificationChannelOptions{
+		AccountID:   core.StringPtr(accountID),
+		Name:        copyString(name),
+		Type:        copyString(typeVar),
+		Endpoint:    copyString(endpoint),
+		Description: copyString(description),
+		Severity:    severity,
+		Enabled:     copyBool(enabled),
+		Frequency:   copyString(frequency),
+	}
+	if len(alertSource) > 0 {
+		options.AlertSource = alertSource
+	}
+	return options
+}
+
+func severityList(critical, high, medium, low *bool) []string {
+	severity := []string{}
+	for _, level := range []struct {
+		name    string
+		enabled *bool
+	}{{CreateNotificationChannelOptions_Severity_Critical, critical}, {CreateNotificationChannelOptions_Severity_High, high}, {CreateNotificationChannelOptions_Severity_Medium, medium}, {CreateNotificationChannelOptions_Severity_Low, low}} {
+		if level.enabled != nil && *level.enabled {
+			severity = append(severity, level.name)
+		}
+	}
+	return severity
+}
+
+func severityFlags(severity []string) (critical, high, medium, low *bool) {
+	critical, high, medium, low = core.BoolPtr(false), core.BoolPtr(false), core.BoolPtr(false), core.BoolPtr(false)
+	for _, name := range severity {
+		switch strings.ToLower(name) {
+		case CreateNotificationChannelOptions_Severity_Critical:
+			*critical = true
+		case CreateNotificationChannelOptions_Severity_High:
+			*high = true
+		case CreateNotificationChannelOptions_Severity_Medium:
+			*medium = true
+		case CreateNotificationChannelOptions_Severity_Low:
+			*low = true
+		}
+	}
+	return
+}
+
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return core.StringPtr(*value)
+}
+
+func copyBool(value *bool) *bool {
+	if value == nil {
+		return nil
+	}
+	return core.BoolPtr(*value)
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+func copyAlertSource(alertSource []NotificationChannelAlertSourceItem) []NotificationChannelAlertSourceItem {
+	if alertSource == nil {
+		return nil
+	}
+	copied := make([]NotificationChannelAlertSourceItem, len(alertSource))
+	for i, item := range alertSource {
+		copied[i] = NotificationChannelAlertSourceItem{ProviderName: copyString(item.ProviderName), FindingTypes: copyStrings(item.FindingTypes)}
+	}
+	return copied
+}
+
+func copyHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(headers))
+	for name, value := range headers {
+		copied[name] = value
+	}
+	return copied
+}
@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Channel conversions`, func() {
+	channelJSON := []byte(`{"channel_id": "1", "name": "channel", "description": "my channel", "type": "Webhook",
+		"endpoint": "https://example.com/hook", "enabled": true, "frequency": "realtime",
+		"severity": {"critical": true, "high": true, "medium": false, "low": true},
+		"alert_source": [{"provider_name": "VA", "finding_types": ["image_with_vulnerabilities"]},
+		                 {"provider_name": "CERT", "finding_types": ["ALL"]}]}`)
+
+	It(`converts a fetched channel to update options without losing fields`, func() {
+		var channel notificationsapiv1.GetChannelResponseChannel
+		Expect(json.Unmarshal(channelJSON, &channel)).To(Succeed())
+
+		options := channel.ToUpdateOptions("account")
+		Expect(*options.AccountID).To(Equal("account"))
+		Expect(*options.ChannelID).To(Equal("1"))
+		Expect(*options.Name).To(Equal("channel"))
+		Expect(*options.Description).To(Equal("my channel"))
+		Expect(*options.Type).To(Equal("Webhook"))
+		Expect(*options.Endpoint).To(Equal("https://example.com/hook"))
+		Expect(*options.Enabled).To(BeTrue())
+		Expect(*options.Frequency).To(Equal("realtime"))
+		Expect(options.Severity).To(Equal([]string{"critical", "high", "low"}))
+		Expect(options.AlertSource).To(Equal([]notificationsapiv1.NotificationChannelAlertSourceItem{
+			{ProviderName: core.StringPtr("VA"), FindingTypes: []string{"image_with_vulnerabilities"}},
+			{ProviderName: core.StringPtr("CERT"), FindingTypes: []string{"ALL"}},
+		}))
+
+		options.AlertSource[0].FindingTypes[0] = "changed"
+		Expect(channel.AlertSource[0].FindingTypes[0]).To(Equal("image_with_vulnerabilities"))
+	})
+	It(`converts a listed channel like a fetched one`, func() {
+		var channel notificationsapiv1.GetChannelResponseChannel
+		Expect(json.Unmarshal(channelJSON, &channel)).To(Succeed())
+		var definition notificationsapiv1.ChannelResponseDefinition
+		Expect(json.Unmarshal(channelJSON, &definition)).To(Succeed())
+
+		Expect(channel.ToChannelResponseDefinition()).To(Equal(&definition))
+		Expect(definition.ToUpdateOptions("account")).To(Equal(channel.ToUpdateOptions("account")))
+		Expect(definition.ToCreateOptions("account")).To(Equal(definition.ToUpdateOptions("account").ToCreateOptions()))
+	})
+	It(`round-trips severities`, func() {
+		severity := notificationsapiv1.NewChannelResponseDefinitionSeverity([]string{"HIGH", "low", "unknown"})
+		Expect(*severity.Critical).To(BeFalse())
+		Expect(*severity.High).To(BeTrue())
+		Expect(*severity.Medium).To(BeFalse())
+		Expect(severity.ToList()).To(Equal([]string{"high", "low"}))
+		Expect(notificationsapiv1.NewGetChannelResponseChannelSeverity(severity.ToList()).ToList()).To(Equal([]string{"high", "low"}))
+
+		var none *notificationsapiv1.GetChannelResponseChannelSeverity
+		Expect(none.ToList()).To(BeNil())
+	})
+	It(`converts create options to update options`, func() {
+		create := &notificationsapiv1.CreateNotificationChannelOptions{
+			AccountID: core.StringPtr("account"),
+			Name:      core.StringPtr("channel"),
+			Severity:  []string{"high"},
+			Headers:   map[string]string{"X-Test": "1"},
+		}
+		update := create.ToUpdateOptions("1")
+		Expect(*update.ChannelID).To(Equal("1"))
+		Expect(update.Headers).To(Equal(create.Headers))
+		Expect(update.ToCreateOptions()).To(Equal(create))
+	})
+})
@@ -0,0 +1,198 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// ChannelMatch : Tells whether a finding triggers a channel, and why.
+type ChannelMatch struct {
+
+	// The name of the channel.
+	ChannelName string
+
+	// The ID of the channel, empty for channels that are only declared.
+	ChannelID string
+
+	// True if the finding triggers the channel.
+	Matched bool
+
+	// The outcome of each check, in order: enabled, severity and alert source.
+	Reasons []string
+}
+
+// FindingRoute : The channels a finding triggers.
+type FindingRoute struct {
+
+	// The finding.
+	Finding *findingsapiv1.ResolvedFinding
+
+	// The outcome for each channel, in the order of the channels.
+	Matches []*ChannelMatch
+}
+
+// Channels : Returns the names of the channels the finding triggers
+func (route *FindingRoute) Channels() []string {
+	channels := []string{}
+	for _, match := range route.Matches {
+		if match.Matched {
+			channels = append(channels, match.ChannelName)
+		}
+	}
+	return channels
+}
+
+// RoutingReport : The channels triggered by each finding of a batch, see RouteFindings.
+type RoutingReport struct {
+	Routes []FindingRoute
+}
+
+// WriteSummary : Prints one line per finding, listing the channels it triggers
+func (report *RoutingReport) WriteSummary(w io.Writer) error {
+	for _, route := range report.Routes {
+		channels := strings.Join(route.Channels(), ", ")
+		if channels == "" {
+			channels = "no channel"
+		}
+		providerName, findingType := findingSource(route.Finding)
+		_, err := fmt.Fprintf(w, "occurrence %q (%s/%s, %s) -> %s\n", stringValue(route.Finding.Occurrence.ID),
+			providerName, findingType, route.Finding.Severity, channels)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchChannel : Tells whether the occurrence, merged with its note, triggers the channel.
+// Only enabled channels are triggered, and only by FINDING occurrences. The effective severity of the finding must
+// be one of the severities of the channel; a channel without severities is never triggered. The provider and
+// finding type, which is the ID of the note, must be selected by the alert sources of the channel; a channel
+// without alert sources accepts all of them.
+func MatchChannel(channel *ChannelResponseDefinition, note *findingsapiv1.ApiNote, occurrence *findingsapiv1.ApiOccurrence) (result *ChannelMatch, err error) {
+	err = core.ValidateNotNil(channel, "channel cannot be nil")
+	if err != nil {
+		return
+	}
+	options := channel.ToCreateOptions("")
+	if occurrence != nil && occurrence.GetKind() != findingsapiv1.ApiNoteKind_Finding {
+		result = &ChannelMatch{
+			ChannelName: stringValue(channel.Name),
+			ChannelID:   stringValue(channel.ChannelID),
+			Reasons:     []string{fmt.Sprintf("occurrence is of kind %q, only %s occurrences are notified", occurrence.GetKind(), findingsapiv1.ApiNoteKind_Finding)},
+		}
+		return
+	}
+	finding, err := findingsapiv1.ResolveFinding(note, occurrence)
+	if err != nil {
+		return
+	}
+	result = MatchFinding(options, finding)
+	result.ChannelID = stringValue(channel.ChannelID)
+	return
+}
+
+// MatchFinding : Tells whether the resolved finding triggers the channel, declared as creation options.
+// See MatchChannel for the rules.
+func MatchFinding(channel *CreateNotificationChannelOptions, finding *findingsapiv1.ResolvedFinding) *ChannelMatch {
+	match := &ChannelMatch{ChannelName: stringValue(channel.Name), Matched: true}
+	check := func(passed bool, format string, args ...interface{}) {
+		match.Matched = match.Matched && passed
+		match.Reasons = append(match.Reasons, fmt.Sprintf(format, args...))
+	}
+
+	enabled := channel.Enabled != nil && *channel.Enabled
+	check(enabled, "channel is %s", map[bool]string{true: "enabled", false: "disabled"}[enabled])
+
+	severity := strings.ToLower(string(finding.Severity))
+	switch {
+	case severity == "":
+		check(false, "finding has no severity")
+	case len(channel.Severity) == 0:
+		check(false, "channel selects no severity")
+	case contains(normalizeSeverities(channel.Severity), severity):
+		check(true, "severity %s is selected", severity)
+	default:
+		check(false, "severity %s is not selected (%s)", severity, strings.Join(normalizeSeverities(channel.Severity), ", "))
+	}
+
+	providerName, findingType := findingSource(finding)
+	selected, reason := alertSourceSelects(channel.AlertSource, providerName, findingType)
+	check(selected, "%s", reason)
+	return match
+}
+
+// RouteFindings : Matches every finding against every channel, to test routing before channels are rolled out.
+// Findings can be resolved in bulk with findingsapiv1.FindingsApiV1.EffectiveFindings, and existing channels
+// converted with ChannelResponseDefinition.ToCreateOptions.
+func RouteFindings(channels []CreateNotificationChannelOptions, findings []*findingsapiv1.ResolvedFinding) *RoutingReport {
+	report := &RoutingReport{Routes: make([]FindingRoute, len(findings))}
+	for i, finding := range findings {
+		route := FindingRoute{Finding: finding, Matches: make([]*ChannelMatch, len(channels))}
+		for j := range channels {
+			route.Matches[j] = MatchFinding(&channels[j], finding)
+		}
+		report.Routes[i] = route
+	}
+	return report
+}
+
+// alertSourceSelects tells whether the alert sources select the provider and finding type, and explains why.
+func alertSourceSelects(alertSource []NotificationChannelAlertSourceItem, providerName string, findingType string) (bool, string) {
+	if len(alertSource) == 0 {
+		return true, "channel accepts all providers"
+	}
+	// Every item is checked, as a later item for the same provider or an ALL item may select the finding type.
+	reason := fmt.Sprintf("provider %s is not selected", providerName)
+	for _, item := range alertSource {
+		itemProvider := stringValue(item.ProviderName)
+		if itemProvider != NotificationChannelAlertSourceItem_ProviderName_All && itemProvider != providerName {
+			continue
+		}
+		if len(item.FindingTypes) == 0 || contains(item.FindingTypes, NotificationChannelAlertSourceItem_FindingTypes_All) {
+			return true, fmt.Sprintf("provider %s selects all finding types", itemProvider)
+		}
+		if contains(item.FindingTypes, findingType) {
+			return true, fmt.Sprintf("provider %s selects finding type %s", itemProvider, findingType)
+		}
+		reason = fmt.Sprintf("provider %s doesn't select finding type %s", itemProvider, findingType)
+	}
+	return false, reason
+}
+
+// findingSource returns the provider and finding type of the finding, as used by alert sources.
+func findingSource(finding *findingsapiv1.ResolvedFinding) (providerName string, findingType string) {
+	occurrence := finding.Occurrence
+	if occurrence.ProviderID != nil {
+		providerName = *occurrence.ProviderID
+	}
+	if _, providerID, noteID, err := findingsapiv1.ParseNoteName(stringValue(occurrence.NoteName)); err == nil {
+		if providerName == "" {
+			providerName = providerID
+		}
+		findingType = noteID
+	} else if finding.Note != nil {
+		findingType = stringValue(finding.Note.ID)
+	}
+	return
+}
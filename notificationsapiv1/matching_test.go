@@ -0,0 +1,139 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Channel matching`, func() {
+	var note *findingsapiv1.ApiNote
+	var occurrence *findingsapiv1.ApiOccurrence
+	var channel *notificationsapiv1.ChannelResponseDefinition
+	BeforeEach(func() {
+		note = &findingsapiv1.ApiNote{}
+		Expect(json.Unmarshal([]byte(`{"id": "image_with_vulnerabilities", "kind": "FINDING",
+			"short_description": "s", "long_description": "l", "finding": {"severity": "LOW"}}`), note)).To(Succeed())
+		occurrence = &findingsapiv1.ApiOccurrence{}
+		Expect(json.Unmarshal([]byte(`{"id": "occurrence", "kind": "FINDING", "provider_id": "VA",
+			"note_name": "account/providers/VA/notes/image_with_vulnerabilities", "finding": {"severity": "HIGH"}}`), occurrence)).To(Succeed())
+		channel = &notificationsapiv1.ChannelResponseDefinition{}
+		Expect(json.Unmarshal([]byte(`{"channel_id": "1", "name": "va", "enabled": true,
+			"severity": {"critical": true, "high": true},
+			"alert_source": [{"provider_name": "VA", "finding_types": ["image_with_vulnerabilities"]}]}`), channel)).To(Succeed())
+	})
+
+	It(`matches an occurrence selected by the channel`, func() {
+		match, err := notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(err).To(BeNil())
+		Expect(match.Matched).To(BeTrue())
+		Expect(match.ChannelID).To(Equal("1"))
+		Expect(match.Reasons).To(Equal([]string{
+			"channel is enabled",
+			"severity high is selected",
+			"provider VA selects finding type image_with_vulnerabilities",
+		}))
+	})
+	It(`explains why an occurrence doesn't match`, func() {
+		channel.Enabled = nil
+		occurrence.Finding.Severity = nil
+		channel.AlertSource[0].FindingTypes = []string{"image_with_config_issues"}
+		match, err := notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(err).To(BeNil())
+		Expect(match.Matched).To(BeFalse())
+		Expect(match.Reasons).To(Equal([]string{
+			"channel is disabled",
+			"severity low is not selected (critical, high)",
+			"provider VA doesn't select finding type image_with_vulnerabilities",
+		}))
+
+		channel.AlertSource[0].ProviderName = core.StringPtr("ATA")
+		match, _ = notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(match.Reasons[2]).To(Equal("provider VA is not selected"))
+
+		occurrence.Kind = core.StringPtr("KPI")
+		match, err = notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(err).To(BeNil())
+		Expect(match.Matched).To(BeFalse())
+
+		_, err = notificationsapiv1.MatchChannel(channel, nil, nil)
+		Expect(err).NotTo(BeNil())
+	})
+	It(`accepts every finding type for ALL`, func() {
+		channel.AlertSource = []notificationsapiv1.ChannelResponseDefinitionAlertSourceItem{{ProviderName: core.StringPtr("ALL"), FindingTypes: []string{"ALL"}}}
+		match, err := notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(err).To(BeNil())
+		Expect(match.Matched).To(BeTrue())
+		Expect(match.Reasons[2]).To(Equal("provider ALL selects all finding types"))
+	})
+	It(`checks every alert source item of the provider`, func() {
+		channel.AlertSource = []notificationsapiv1.ChannelResponseDefinitionAlertSourceItem{
+			{ProviderName: core.StringPtr("VA"), FindingTypes: []string{"image_with_config_issues"}},
+			{ProviderName: core.StringPtr("VA"), FindingTypes: []string{"image_with_vulnerabilities"}},
+		}
+		match, err := notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(err).To(BeNil())
+		Expect(match.Matched).To(BeTrue())
+		Expect(match.Reasons[2]).To(Equal("provider VA selects finding type image_with_vulnerabilities"))
+
+		channel.AlertSource[1] = notificationsapiv1.ChannelResponseDefinitionAlertSourceItem{ProviderName: core.StringPtr("ALL"), FindingTypes: []string{"ALL"}}
+		match, _ = notificationsapiv1.MatchChannel(channel, note, occurrence)
+		Expect(match.Matched).To(BeTrue())
+		Expect(match.Reasons[2]).To(Equal("provider ALL selects all finding types"))
+	})
+	It(`routes a batch of findings`, func() {
+		channels, err := notificationsapiv1.ParseChannels([]byte(`
+- {name: critical, enabled: true, severity: [critical]}
+- {name: va, enabled: true, severity: [low, high, critical], alert_source: [{provider_name: VA}]}
+- {name: certificates, enabled: true, severity: [high], alert_source: [{provider_name: CERT, finding_types: [expired_cert]}]}
+`))
+		Expect(err).To(BeNil())
+		high, err := findingsapiv1.ResolveFinding(note, occurrence)
+		Expect(err).To(BeNil())
+		occurrence.Finding.Severity = core.StringPtr("CRITICAL")
+		critical, err := findingsapiv1.ResolveFinding(note, occurrence)
+		Expect(err).To(BeNil())
+		certificate := &findingsapiv1.ApiOccurrence{}
+		Expect(json.Unmarshal([]byte(`{"id": "certificate", "kind": "FINDING",
+			"note_name": "account/providers/CERT/notes/expiring_1day_cert", "finding": {"severity": "HIGH"}}`), certificate)).To(Succeed())
+		expiring, err := findingsapiv1.ResolveFinding(note, certificate)
+		Expect(err).To(BeNil())
+
+		report := notificationsapiv1.RouteFindings(channels, []*findingsapiv1.ResolvedFinding{high, critical, expiring})
+		Expect(report.Routes[0].Channels()).To(Equal([]string{"va"}))
+		Expect(report.Routes[1].Channels()).To(Equal([]string{"critical", "va"}))
+		Expect(report.Routes[2].Channels()).To(BeEmpty())
+		Expect(report.Routes[2].Matches[2].Reasons[2]).To(Equal("provider CERT doesn't select finding type expiring_1day_cert"))
+
+		var out bytes.Buffer
+		Expect(report.WriteSummary(&out)).To(Succeed())
+		Expect(out.String()).To(Equal(strings.Join([]string{
+			`occurrence "occurrence" (VA/image_with_vulnerabilities, HIGH) -> va`,
+			`occurrence "occurrence" (VA/image_with_vulnerabilities, CRITICAL) -> critical, va`,
+			`occurrence "certificate" (CERT/expiring_1day_cert, HIGH) -> no channel`,
+			``,
+		}, "\n")))
+	})
+})
@@ -18,6 +18,7 @@
 package notificationsapiv1
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/IBM/go-sdk-core/v3/core"
@@ -107,6 +108,11 @@ func (notificationsApi *NotificationsApiV1) SetServiceURL(url string) error {
 // ListAllChannels : list all channels
 // list all channels under this account.
 func (notificationsApi *NotificationsApiV1) ListAllChannels(listAllChannelsOptions *ListAllChannelsOptions) (result *ListChannelsResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.ListAllChannelsWithContext(context.Background(), listAllChannelsOptions)
+}
+
+// ListAllChannelsWithContext : list all channels, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) ListAllChannelsWithContext(ctx context.Context, listAllChannelsOptions *ListAllChannelsOptions) (result *ListChannelsResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(listAllChannelsOptions, "listAllChannelsOptions cannot be nil")
 	if err != nil {
 		return
@@ -147,6 +153,7 @@ func (notificationsApi *NotificationsApiV1) ListAllChannels(listAllChannelsOptio
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(ListChannelsResponse))
 	if err == nil {
@@ -163,6 +170,11 @@ func (notificationsApi *NotificationsApiV1) ListAllChannels(listAllChannelsOptio
 // CreateNotificationChannel : create notification channel
 // create notification channel.
 func (notificationsApi *NotificationsApiV1) CreateNotificationChannel(createNotificationChannelOptions *CreateNotificationChannelOptions) (result *CreateChannelsResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.CreateNotificationChannelWithContext(context.Background(), createNotificationChannelOptions)
+}
+
+// CreateNotificationChannelWithContext : create notification channel, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) CreateNotificationChannelWithContext(ctx context.Context, createNotificationChannelOptions *CreateNotificationChannelOptions) (result *CreateChannelsResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(createNotificationChannelOptions, "createNotificationChannelOptions cannot be nil")
 	if err != nil {
 		return
@@ -171,6 +183,10 @@ func (notificationsApi *NotificationsApiV1) CreateNotificationChannel(createNoti
 	if err != nil {
 		return
 	}
+	err = createNotificationChannelOptions.Validate()
+	if err != nil {
+		return
+	}
 
 	pathSegments := []string{"v1", "notifications/channels"}
 	pathParameters := []string{*createNotificationChannelOptions.AccountID}
@@ -215,6 +231,9 @@ func (notificationsApi *NotificationsApiV1) CreateNotificationChannel(createNoti
 	if createNotificationChannelOptions.AlertSource != nil {
 		body["alert_source"] = createNotificationChannelOptions.AlertSource
 	}
+	if createNotificationChannelOptions.Frequency != nil {
+		body["frequency"] = createNotificationChannelOptions.Frequency
+	}
 	_, err = builder.SetBodyContentJSON(body)
 	if err != nil {
 		return
@@ -224,6 +243,7 @@ func (notificationsApi *NotificationsApiV1) CreateNotificationChannel(createNoti
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(CreateChannelsResponse))
 	if err == nil {
@@ -240,6 +260,11 @@ func (notificationsApi *NotificationsApiV1) CreateNotificationChannel(createNoti
 // DeleteNotificationChannels : bulk delete of channels
 // bulk delete of channels.
 func (notificationsApi *NotificationsApiV1) DeleteNotificationChannels(deleteNotificationChannelsOptions *DeleteNotificationChannelsOptions) (result *BulkDeleteChannelsResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.DeleteNotificationChannelsWithContext(context.Background(), deleteNotificationChannelsOptions)
+}
+
+// DeleteNotificationChannelsWithContext : bulk delete of channels, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) DeleteNotificationChannelsWithContext(ctx context.Context, deleteNotificationChannelsOptions *DeleteNotificationChannelsOptions) (result *BulkDeleteChannelsResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(deleteNotificationChannelsOptions, "deleteNotificationChannelsOptions cannot be nil")
 	if err != nil {
 		return
@@ -279,6 +304,7 @@ func (notificationsApi *NotificationsApiV1) DeleteNotificationChannels(deleteNot
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(BulkDeleteChannelsResponse))
 	if err == nil {
@@ -295,6 +321,11 @@ func (notificationsApi *NotificationsApiV1) DeleteNotificationChannels(deleteNot
 // DeleteNotificationChannel : delete the details of a specific channel
 // delete the details of a specific channel.
 func (notificationsApi *NotificationsApiV1) DeleteNotificationChannel(deleteNotificationChannelOptions *DeleteNotificationChannelOptions) (result *DeleteChannelResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.DeleteNotificationChannelWithContext(context.Background(), deleteNotificationChannelOptions)
+}
+
+// DeleteNotificationChannelWithContext : delete the details of a specific channel, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) DeleteNotificationChannelWithContext(ctx context.Context, deleteNotificationChannelOptions *DeleteNotificationChannelOptions) (result *DeleteChannelResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(deleteNotificationChannelOptions, "deleteNotificationChannelOptions cannot be nil")
 	if err != nil {
 		return
@@ -328,6 +359,7 @@ func (notificationsApi *NotificationsApiV1) DeleteNotificationChannel(deleteNoti
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(DeleteChannelResponse))
 	if err == nil {
@@ -344,6 +376,11 @@ func (notificationsApi *NotificationsApiV1) DeleteNotificationChannel(deleteNoti
 // GetNotificationChannel : get the details of a specific channel
 // get the details of a specific channel.
 func (notificationsApi *NotificationsApiV1) GetNotificationChannel(getNotificationChannelOptions *GetNotificationChannelOptions) (result *GetChannelResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.GetNotificationChannelWithContext(context.Background(), getNotificationChannelOptions)
+}
+
+// GetNotificationChannelWithContext : get the details of a specific channel, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) GetNotificationChannelWithContext(ctx context.Context, getNotificationChannelOptions *GetNotificationChannelOptions) (result *GetChannelResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(getNotificationChannelOptions, "getNotificationChannelOptions cannot be nil")
 	if err != nil {
 		return
@@ -377,6 +414,7 @@ func (notificationsApi *NotificationsApiV1) GetNotificationChannel(getNotificati
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(GetChannelResponse))
 	if err == nil {
@@ -393,6 +431,11 @@ func (notificationsApi *NotificationsApiV1) GetNotificationChannel(getNotificati
 // UpdateNotificationChannel : update notification channel
 // update notification channel.
 func (notificationsApi *NotificationsApiV1) UpdateNotificationChannel(updateNotificationChannelOptions *UpdateNotificationChannelOptions) (result *UpdateChannelResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.UpdateNotificationChannelWithContext(context.Background(), updateNotificationChannelOptions)
+}
+
+// UpdateNotificationChannelWithContext : update notification channel, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) UpdateNotificationChannelWithContext(ctx context.Context, updateNotificationChannelOptions *UpdateNotificationChannelOptions) (result *UpdateChannelResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(updateNotificationChannelOptions, "updateNotificationChannelOptions cannot be nil")
 	if err != nil {
 		return
@@ -401,6 +444,10 @@ func (notificationsApi *NotificationsApiV1) UpdateNotificationChannel(updateNoti
 	if err != nil {
 		return
 	}
+	err = updateNotificationChannelOptions.Validate()
+	if err != nil {
+		return
+	}
 
 	pathSegments := []string{"v1", "notifications/channels"}
 	pathParameters := []string{*updateNotificationChannelOptions.AccountID, *updateNotificationChannelOptions.ChannelID}
@@ -445,6 +492,9 @@ func (notificationsApi *NotificationsApiV1) UpdateNotificationChannel(updateNoti
 	if updateNotificationChannelOptions.AlertSource != nil {
 		body["alert_source"] = updateNotificationChannelOptions.AlertSource
 	}
+	if updateNotificationChannelOptions.Frequency != nil {
+		body["frequency"] = updateNotificationChannelOptions.Frequency
+	}
 	_, err = builder.SetBodyContentJSON(body)
 	if err != nil {
 		return
@@ -454,6 +504,7 @@ func (notificationsApi *NotificationsApiV1) UpdateNotificationChannel(updateNoti
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(UpdateChannelResponse))
 	if err == nil {
@@ -470,6 +521,11 @@ func (notificationsApi *NotificationsApiV1) UpdateNotificationChannel(updateNoti
 // TestNotificationChannel : test notification channel
 // test a nofication channel under this account.
 func (notificationsApi *NotificationsApiV1) TestNotificationChannel(testNotificationChannelOptions *TestNotificationChannelOptions) (result *TestChannelResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.TestNotificationChannelWithContext(context.Background(), testNotificationChannelOptions)
+}
+
+// TestNotificationChannelWithContext : test notification channel, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) TestNotificationChannelWithContext(ctx context.Context, testNotificationChannelOptions *TestNotificationChannelOptions) (result *TestChannelResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(testNotificationChannelOptions, "testNotificationChannelOptions cannot be nil")
 	if err != nil {
 		return
@@ -503,6 +559,7 @@ func (notificationsApi *NotificationsApiV1) TestNotificationChannel(testNotifica
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(TestChannelResponse))
 	if err == nil {
@@ -519,6 +576,11 @@ func (notificationsApi *NotificationsApiV1) TestNotificationChannel(testNotifica
 // GetPublicKey : fetch notifications public key
 // fetch public key to decrypt messages in notification payload.
 func (notificationsApi *NotificationsApiV1) GetPublicKey(getPublicKeyOptions *GetPublicKeyOptions) (result *PublicKeyResponse, response *core.DetailedResponse, err error) {
+	return notificationsApi.GetPublicKeyWithContext(context.Background(), getPublicKeyOptions)
+}
+
+// GetPublicKeyWithContext : fetch notifications public key, aborting the request when ctx is done
+func (notificationsApi *NotificationsApiV1) GetPublicKeyWithContext(ctx context.Context, getPublicKeyOptions *GetPublicKeyOptions) (result *PublicKeyResponse, response *core.DetailedResponse, err error) {
 	err = core.ValidateNotNil(getPublicKeyOptions, "getPublicKeyOptions cannot be nil")
 	if err != nil {
 		return
@@ -552,6 +614,7 @@ func (notificationsApi *NotificationsApiV1) GetPublicKey(getPublicKeyOptions *Ge
 	if err != nil {
 		return
 	}
+	request = request.WithContext(ctx)
 
 	response, err = notificationsApi.Service.Request(request, new(PublicKeyResponse))
 	if err == nil {
@@ -577,8 +640,10 @@ type ChannelResponseDefinitionAlertSourceItem struct {
 	//  | NA  | Network Insights findings|
 	//  | ATA | Activity Insights findings|
 	//  | CERT | Certificate Manager findings|
+	//  | config-advisor | Config Advisor findings|
 	//  | ALL | Special provider name to represent all the providers. Its mutually exclusive with other providers meaning
 	// either you choose ALL or you don't|.
+	// See the NotificationChannelAlertSourceItem_ProviderName constants and ValidateAlertSources.
 	ProviderName *string `json:"provider_name,omitempty"`
 
 	// An array of the finding types of the provider_name or "ALL" to specify all finding types under that provider Below
@@ -642,6 +707,9 @@ type CreateNotificationChannelOptions struct {
 
 	AlertSource []NotificationChannelAlertSourceItem `json:"alert_source,omitempty"`
 
+	// How often notifications are sent to the channel. Default is realtime.
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=realtime daily weekly"`
+
 	// Allows users to set headers to be GDPR compliant
 	Headers map[string]string
 }
@@ -660,6 +728,14 @@ const (
 	CreateNotificationChannelOptions_Severity_Medium   = "medium"
 )
 
+// Constants associated with the CreateNotificationChannelOptions.Frequency property.
+// How often notifications are sent to the channel.
+const (
+	CreateNotificationChannelOptions_Frequency_Daily    = "daily"
+	CreateNotificationChannelOptions_Frequency_Realtime = "realtime"
+	CreateNotificationChannelOptions_Frequency_Weekly   = "weekly"
+)
+
 // NewCreateNotificationChannelOptions : Instantiate CreateNotificationChannelOptions
 func (notificationsApi *NotificationsApiV1) NewCreateNotificationChannelOptions(accountID string, name string, typeVar string, endpoint string) *CreateNotificationChannelOptions {
 	return &CreateNotificationChannelOptions{
@@ -718,6 +794,12 @@ func (options *CreateNotificationChannelOptions) SetAlertSource(alertSource []No
 	return options
 }
 
+// SetFrequency : Allow user to set Frequency
+func (options *CreateNotificationChannelOptions) SetFrequency(frequency string) *CreateNotificationChannelOptions {
+	options.Frequency = core.StringPtr(frequency)
+	return options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *CreateNotificationChannelOptions) SetHeaders(param map[string]string) *CreateNotificationChannelOptions {
 	options.Headers = param
@@ -827,6 +909,7 @@ type GetChannelResponseChannel struct {
 
 	AlertSource []GetChannelResponseChannelAlertSource `json:"alert_source,omitempty"`
 
+	// How often notifications are sent to the channel.
 	Frequency *string `json:"frequency,omitempty"`
 }
 
@@ -848,8 +931,10 @@ type GetChannelResponseChannelAlertSource struct {
 	//  | NA  | Network Insights findings|
 	//  | ATA | Activity Insights findings|
 	//  | CERT | Certificate Manager findings|
+	//  | config-advisor | Config Advisor findings|
 	//  | ALL | Special provider name to represent all the providers. Its mutually exclusive with other providers meaning
 	// either you choose ALL or you don't|.
+	// See the NotificationChannelAlertSourceItem_ProviderName constants and ValidateAlertSources.
 	ProviderName *string `json:"provider_name,omitempty"`
 
 	// An array of the finding types of the provider_name or "ALL" to specify all finding types under that provider Below
@@ -1015,8 +1100,10 @@ type NotificationChannelAlertSourceItem struct {
 	//  | NA  | Network Insights findings|
 	//  | ATA | Activity Insights findings|
 	//  | CERT | Certificate Manager findings|
+	//  | config-advisor | Config Advisor findings|
 	//  | ALL | Special provider name to represent all the providers. Its mutually exclusive with other providers meaning
 	// either you choose ALL or you don't|.
+	// See the NotificationChannelAlertSourceItem_ProviderName constants and ValidateAlertSources.
 	ProviderName *string `json:"provider_name" validate:"required"`
 
 	// An array of the finding types of the provider_name or "ALL" to specify all finding types under that provider Below
@@ -1115,6 +1202,9 @@ type UpdateNotificationChannelOptions struct {
 
 	AlertSource []NotificationChannelAlertSourceItem `json:"alert_source,omitempty"`
 
+	// How often notifications are sent to the channel. Default is realtime.
+	Frequency *string `json:"frequency,omitempty" validate:"omitempty,oneof=realtime daily weekly"`
+
 	// Allows users to set headers to be GDPR compliant
 	Headers map[string]string
 }
@@ -1133,6 +1223,14 @@ const (
 	UpdateNotificationChannelOptions_Severity_Medium   = "medium"
 )
 
+// Constants associated with the UpdateNotificationChannelOptions.Frequency property.
+// How often notifications are sent to the channel.
+const (
+	UpdateNotificationChannelOptions_Frequency_Daily    = "daily"
+	UpdateNotificationChannelOptions_Frequency_Realtime = "realtime"
+	UpdateNotificationChannelOptions_Frequency_Weekly   = "weekly"
+)
+
 // NewUpdateNotificationChannelOptions : Instantiate UpdateNotificationChannelOptions
 func (notificationsApi *NotificationsApiV1) NewUpdateNotificationChannelOptions(accountID string, channelID string, name string, typeVar string, endpoint string) *UpdateNotificationChannelOptions {
 	return &UpdateNotificationChannelOptions{
@@ -1198,6 +1296,12 @@ func (options *UpdateNotificationChannelOptions) SetAlertSource(alertSource []No
 	return options
 }
 
+// SetFrequency : Allow user to set Frequency
+func (options *UpdateNotificationChannelOptions) SetFrequency(frequency string) *UpdateNotificationChannelOptions {
+	options.Frequency = core.StringPtr(frequency)
+	return options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *UpdateNotificationChannelOptions) SetHeaders(param map[string]string) *UpdateNotificationChannelOptions {
 	options.Headers = param
@@ -1236,6 +1340,7 @@ type ChannelResponseDefinition struct {
 
 	AlertSource []ChannelResponseDefinitionAlertSourceItem `json:"alert_source,omitempty"`
 
+	// How often notifications are sent to the channel.
 	Frequency *string `json:"frequency,omitempty"`
 }
 
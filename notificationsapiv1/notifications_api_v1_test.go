@@ -17,6 +17,8 @@
 package notificationsapiv1_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -189,6 +191,46 @@ var _ = Describe(`NotificationsApiV1`, func() {
 				createNotificationChannelOptions.SetHeaders(headers)
 			})
 		})
+		Context(`Successfully - create notification channel with frequency`, func() {
+			var body map[string]interface{}
+			testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{}`)
+			}))
+			It(`Succeed to call CreateNotificationChannel with a frequency`, func() {
+				defer testServer.Close()
+
+				testService, testServiceErr := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+				})
+				Expect(testServiceErr).To(BeNil())
+
+				createNotificationChannelOptions := testService.NewCreateNotificationChannelOptions(accountID, name, typeVar, endpoint)
+				_, _, operationErr := testService.CreateNotificationChannel(createNotificationChannelOptions)
+				Expect(operationErr).To(BeNil())
+				Expect(body).NotTo(HaveKey("frequency"))
+
+				createNotificationChannelOptions.SetFrequency(notificationsapiv1.CreateNotificationChannelOptions_Frequency_Daily)
+				Expect(*createNotificationChannelOptions.Frequency).To(Equal("daily"))
+				_, _, operationErr = testService.CreateNotificationChannel(createNotificationChannelOptions)
+				Expect(operationErr).To(BeNil())
+				Expect(body["frequency"]).To(Equal("daily"))
+
+				//Pass an unsupported frequency
+				body = nil
+				createNotificationChannelOptions.SetFrequency("hourly")
+				result, response, operationErr := testService.CreateNotificationChannel(createNotificationChannelOptions)
+				Expect(operationErr).NotTo(BeNil())
+				Expect(response).To(BeNil())
+				Expect(result).To(BeNil())
+				Expect(body).To(BeNil())
+			})
+		})
 	})
 	Describe(`DeleteNotificationChannels(deleteNotificationChannelsOptions *DeleteNotificationChannelsOptions)`, func() {
 		deleteNotificationChannelsPath := "/v1/{account_id}/notifications/channels"
@@ -345,6 +387,13 @@ var _ = Describe(`NotificationsApiV1`, func() {
 				Expect(operationErr).To(BeNil())
 				Expect(response).ToNot(BeNil())
 				Expect(result).ToNot(BeNil())
+
+				// Abort the request with the context
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				result, response, operationErr = testService.GetNotificationChannelWithContext(ctx, getNotificationChannelOptions)
+				Expect(operationErr).NotTo(BeNil())
+				Expect(result).To(BeNil())
 			})
 		})
 	})
@@ -424,6 +473,40 @@ var _ = Describe(`NotificationsApiV1`, func() {
 				Expect(result).ToNot(BeNil())
 			})
 		})
+		Context(`Successfully - update notification channel with frequency`, func() {
+			var body map[string]interface{}
+			testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{}`)
+			}))
+			It(`Succeed to call UpdateNotificationChannel with a frequency`, func() {
+				defer testServer.Close()
+
+				testService, testServiceErr := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+				})
+				Expect(testServiceErr).To(BeNil())
+
+				updateNotificationChannelOptions := testService.NewUpdateNotificationChannelOptions(accountID, channelID, name, typeVar, endpoint)
+				updateNotificationChannelOptions.SetFrequency(notificationsapiv1.UpdateNotificationChannelOptions_Frequency_Weekly)
+				Expect(*updateNotificationChannelOptions.Frequency).To(Equal("weekly"))
+				_, _, operationErr := testService.UpdateNotificationChannel(updateNotificationChannelOptions)
+				Expect(operationErr).To(BeNil())
+				Expect(body["frequency"]).To(Equal("weekly"))
+
+				//Pass an unsupported frequency
+				updateNotificationChannelOptions.SetFrequency("Weekly")
+				result, response, operationErr := testService.UpdateNotificationChannel(updateNotificationChannelOptions)
+				Expect(operationErr).NotTo(BeNil())
+				Expect(response).To(BeNil())
+				Expect(result).To(BeNil())
+			})
+		})
 	})
 	Describe(`TestNotificationChannel(testNotificationChannelOptions *TestNotificationChannelOptions)`, func() {
 		testNotificationChannelPath := "/v1/{account_id}/notifications/channels/{channel_id}/test"
@@ -0,0 +1,407 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	common "github.com/ibm-cloud-security/security-advisor-sdk-go/common"
+)
+
+// DefaultManagedBy : The default value of the managed-by marker of reconciled channels
+const DefaultManagedBy = "security-advisor-sdk-go"
+
+// channelsPageSize is the number of channels listed per request while reconciling.
+const channelsPageSize = 100
+
+// Constants associated with the ChannelChange.Action property.
+// - CREATE&#58; The channel is declared but doesn't exist.
+// - UPDATE&#58; The channel exists and differs from its declaration.
+// - DELETE&#58; The channel has the managed-by marker but isn't declared anymore.
+// - UNCHANGED&#58; The channel exists as declared.
+// - CONFLICT&#58; The channel is declared but exists without the managed-by marker. It is left untouched.
+const (
+	ChannelChange_Action_Create    = "CREATE"
+	ChannelChange_Action_Update    = "UPDATE"
+	ChannelChange_Action_Delete    = "DELETE"
+	ChannelChange_Action_Unchanged = "UNCHANGED"
+	ChannelChange_Action_Conflict  = "CONFLICT"
+)
+
+// ReconcileOptions : The Reconcile options.
+type ReconcileOptions struct {
+
+	// Only compute and print the plan.
+	DryRun bool
+
+	// Where the plan is printed. Nothing is printed when nil.
+	Out io.Writer
+
+	// The managed-by marker identifies the channels owned by this declaration. Defaults to DefaultManagedBy.
+	ManagedBy string
+}
+
+// ChannelChange : A change to a channel computed by Reconcile
+type ChannelChange struct {
+
+	// The change, one of the ChannelChange_Action constants.
+	Action string
+
+	// The name of the channel.
+	Name string
+
+	// The existing channel, nil for creations.
+	Current *ChannelResponseDefinition
+
+	// The declared channel, nil for deletions.
+	Desired *CreateNotificationChannelOptions
+
+	// The fields that differ for updates, as "field: current -> desired".
+	Diff []string
+}
+
+// ReconcilePlan : The changes computed by Reconcile, in the order they are applied: creations and updates in the
+// order of the declaration, then the deletions
+type ReconcilePlan struct {
+	Changes []ChannelChange
+}
+
+// ReconcileError : Returned by Reconcile when applying the plan fails partway
+type ReconcileError struct {
+
+	// The changes applied before the failure, in the order of the plan.
+	Applied []ChannelChange
+
+	// The changes of the failed request. The deletions are sent in a single request.
+	Failed []ChannelChange
+
+	// The error of the failed request.
+	Err error
+}
+
+// Error : Describes the failed request and the changes applied before it
+func (reconcileError *ReconcileError) Error() string {
+	message := reconcileError.Err.Error()
+	if len(reconcileError.Failed) == 1 {
+		message = fmt.Sprintf("failed to %s channel %q: %s", strings.ToLower(reconcileError.Failed[0].Action),
+			reconcileError.Failed[0].Name, message)
+	} else if len(reconcileError.Failed) > 1 {
+		message = fmt.Sprintf("failed to %s %d channels: %s", strings.ToLower(reconcileError.Failed[0].Action),
+			len(reconcileError.Failed), message)
+	}
+	if len(reconcileError.Applied) == 0 {
+		return message + " (no change applied)"
+	}
+	applied := make([]string, len(reconcileError.Applied))
+	for i, change := range reconcileError.Applied {
+		applied[i] = fmt.Sprintf("%s %q", strings.ToLower(change.Action), change.Name)
+	}
+	return message + " (applied: " + strings.Join(applied, ", ") + ")"
+}
+
+// Unwrap : Returns the error of the failed request
+func (reconcileError *ReconcileError) Unwrap() error {
+	return reconcileError.Err
+}
+
+// HasChanges : Reports whether applying the plan changes any channel
+func (plan *ReconcilePlan) HasChanges() bool {
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case ChannelChange_Action_Create, ChannelChange_Action_Update, ChannelChange_Action_Delete:
+			return true
+		}
+	}
+	return false
+}
+
+// WriteDiff : Prints the plan, one line per channel followed by the changed fields of updates
+func (plan *ReconcilePlan) WriteDiff(w io.Writer) error {
+	for _, change := range plan.Changes {
+		var line string
+		switch change.Action {
+		case ChannelChange_Action_Create:
+			line = fmt.Sprintf("+ channel %q\n", change.Name)
+		case ChannelChange_Action_Update:
+			line = fmt.Sprintf("~ channel %q\n", change.Name)
+			for _, diff := range change.Diff {
+				line += "    " + diff + "\n"
+			}
+		case ChannelChange_Action_Delete:
+			line = fmt.Sprintf("- channel %q\n", change.Name)
+		case ChannelChange_Action_Conflict:
+			line = fmt.Sprintf("! channel %q exists without the managed-by marker, left untouched\n", change.Name)
+		default:
+			continue
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadChannels : Reads the declaration of channels from a YAML or JSON file holding one channel or a list of channels,
+// in the format of CreateNotificationChannelOptions (see examples/notificationsapiv1/input)
+func LoadChannels(path string) (channels []CreateNotificationChannelOptions, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	channels, err = ParseChannels(data)
+	if err != nil {
+		err = fmt.Errorf("%s: %s", filepath.Base(path), err.Error())
+	}
+	return
+}
+
+// ParseChannels : Parses the declaration of channels, YAML or JSON, holding one channel or a list of channels
+func ParseChannels(data []byte) (channels []CreateNotificationChannelOptions, err error) {
+	jsonData, err := common.YAMLToJSON(data)
+	if err != nil {
+		return
+	}
+
+	if bytes.HasPrefix(jsonData, []byte("[")) {
+		err = json.Unmarshal(jsonData, &channels)
+		return
+	}
+	var channel CreateNotificationChannelOptions
+	if err = json.Unmarshal(jsonData, &channel); err != nil {
+		return
+	}
+	return []CreateNotificationChannelOptions{channel}, nil
+}
+
+// Reconcile : Makes the channels of the account match the declared ones, by name.
+//
+// Declared channels that don't exist are created and the ones that differ are updated. Channels carrying the
+// managed-by marker in their description that are no longer declared are deleted with DeleteNotificationChannels,
+// once all creations and updates succeeded so that a failure never leaves the account without its old channels.
+// Channels without the marker are never changed, and fields left out of a declaration, such as enabled, keep their
+// current value. The plan is printed to opts.Out, and only applied unless opts.DryRun is set. When applying the plan
+// fails partway the error is a *ReconcileError listing the changes already applied.
+func (notificationsApi *NotificationsApiV1) Reconcile(ctx context.Context, accountID string, desired []CreateNotificationChannelOptions, opts *ReconcileOptions) (plan *ReconcilePlan, err error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+	managedBy := opts.ManagedBy
+	if managedBy == "" {
+		managedBy = DefaultManagedBy
+	}
+
+	current, err := notificationsApi.listChannels(ctx, accountID)
+	if err != nil {
+		return
+	}
+	plan, err = planReconcile(current, desired, managedMarker(managedBy))
+	if err != nil {
+		return
+	}
+	if opts.Out != nil {
+		if err = plan.WriteDiff(opts.Out); err != nil {
+			return
+		}
+	}
+	if opts.DryRun {
+		return
+	}
+	err = notificationsApi.applyReconcile(ctx, accountID, plan, managedMarker(managedBy))
+	return
+}
+
+// listChannels lists all the channels of the account, page by page.
+func (notificationsApi *NotificationsApiV1) listChannels(ctx context.Context, accountID string) (channels []ChannelResponseDefinition, err error) {
+	options := notificationsApi.NewListAllChannelsOptions(accountID).SetLimit(channelsPageSize)
+	for skip := int64(0); ; skip += channelsPageSize {
+		options.SetSkip(skip)
+		result, _, listErr := notificationsApi.ListAllChannelsWithContext(ctx, options)
+		if listErr != nil {
+			return nil, fmt.Errorf("failed to list channels: %s", listErr.Error())
+		}
+		channels = append(channels, result.Channels...)
+		if len(result.Channels) < channelsPageSize {
+			return
+		}
+	}
+}
+
+func planReconcile(current []ChannelResponseDefinition, desired []CreateNotificationChannelOptions, marker string) (*ReconcilePlan, error) {
+	existing := make(map[string]*ChannelResponseDefinition, len(current))
+	for i := range current {
+		existing[stringValue(current[i].Name)] = &current[i]
+	}
+
+	plan := &ReconcilePlan{}
+	declared := make(map[string]bool, len(desired))
+	for i := range desired {
+		channel := &desired[i]
+		name := stringValue(channel.Name)
+		if name == "" {
+			return nil, fmt.Errorf("channel %d: name is required", i)
+		}
+		if declared[name] {
+			return nil, fmt.Errorf("channel %q is declared twice", name)
+		}
+		declared[name] = true
+		if err := channel.Validate(); err != nil {
+			return nil, fmt.Errorf("channel %q: %s", name, err.Error())
+		}
+
+		change := ChannelChange{Name: name, Current: existing[name], Desired: channel}
+		switch {
+		case change.Current == nil:
+			change.Action = ChannelChange_Action_Create
+		case !strings.Contains(stringValue(change.Current.Description), marker):
+			change.Action = ChannelChange_Action_Conflict
+		default:
+			change.Diff = diffChannel(change.Current, channel, marker)
+			change.Action = ChannelChange_Action_Unchanged
+			if len(change.Diff) > 0 {
+				change.Action = ChannelChange_Action_Update
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for i := range current {
+		name := stringValue(current[i].Name)
+		if !declared[name] && strings.Contains(stringValue(current[i].Description), marker) {
+			plan.Changes = append(plan.Changes, ChannelChange{Action: ChannelChange_Action_Delete, Name: name, Current: &current[i]})
+		}
+	}
+	return plan, nil
+}
+
+func (notificationsApi *NotificationsApiV1) applyReconcile(ctx context.Context, accountID string, plan *ReconcilePlan, marker string) error {
+	var applied, deletions []ChannelChange
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case ChannelChange_Action_Create:
+			options := *change.Desired
+			options.AccountID = core.StringPtr(accountID)
+			options.Description = core.StringPtr(withMarker(stringValue(change.Desired.Description), marker))
+			_, _, err = notificationsApi.CreateNotificationChannelWithContext(ctx, &options)
+		case ChannelChange_Action_Update:
+			options := change.Desired.ToUpdateOptions(stringValue(change.Current.ChannelID))
+			options.AccountID = core.StringPtr(accountID)
+			options.Description = core.StringPtr(withMarker(stringValue(change.Desired.Description), marker))
+			_, _, err = notificationsApi.UpdateNotificationChannelWithContext(ctx, options)
+		case ChannelChange_Action_Delete:
+			deletions = append(deletions, change)
+			continue
+		default:
+			continue
+		}
+		if err != nil {
+			return &ReconcileError{Applied: applied, Failed: []ChannelChange{change}, Err: err}
+		}
+		applied = append(applied, change)
+	}
+
+	if len(deletions) == 0 {
+		return nil
+	}
+	channelIDs := make([]string, len(deletions))
+	for i, change := range deletions {
+		channelIDs[i] = stringValue(change.Current.ChannelID)
+	}
+	_, _, err := notificationsApi.DeleteNotificationChannelsWithContext(ctx, notificationsApi.NewDeleteNotificationChannelsOptions(accountID, channelIDs))
+	if err != nil {
+		return &ReconcileError{Applied: applied, Failed: deletions, Err: err}
+	}
+	return nil
+}
+
+// diffChannel lists the fields of the existing channel that differ from its declaration.
+func diffChannel(current *ChannelResponseDefinition, desired *CreateNotificationChannelOptions, marker string) (diff []string) {
+	compare := func(field string, currentValue string, desiredValue string) {
+		if currentValue != desiredValue {
+			diff = append(diff, fmt.Sprintf("%s: %q -> %q", field, currentValue, desiredValue))
+		}
+	}
+	compare("type", stringValue(current.Type), stringValue(desired.Type))
+	compare("endpoint", stringValue(current.Endpoint), stringValue(desired.Endpoint))
+	compare("description", withoutMarker(stringValue(current.Description), marker), stringValue(desired.Description))
+	if desired.Enabled != nil {
+		compare("enabled", fmt.Sprint(current.Enabled != nil && *current.Enabled), fmt.Sprint(*desired.Enabled))
+	}
+	currentOptions := current.ToCreateOptions("")
+	if len(desired.Severity) > 0 {
+		compare("severity", strings.Join(normalizeSeverities(currentOptions.Severity), ","), strings.Join(normalizeSeverities(desired.Severity), ","))
+	}
+	if len(desired.AlertSource) > 0 {
+		compare("alert_source", alertSources(currentOptions.AlertSource), alertSources(desired.AlertSource))
+	}
+	if desired.Frequency != nil {
+		compare("frequency", stringValue(current.Frequency), *desired.Frequency)
+	}
+	return
+}
+
+func managedMarker(managedBy string) string {
+	return "[managed-by:" + managedBy + "]"
+}
+
+func withMarker(description string, marker string) string {
+	if description == "" {
+		return marker
+	}
+	return description + " " + marker
+}
+
+func withoutMarker(description string, marker string) string {
+	return strings.TrimSpace(strings.Replace(description, marker, "", 1))
+}
+
+func normalizeSeverities(severity []string) []string {
+	severities := make([]string, len(severity))
+	for i, name := range severity {
+		severities[i] = strings.ToLower(name)
+	}
+	sort.Strings(severities)
+	return severities
+}
+
+// alertSources formats the alert sources independently of the order of providers and finding types.
+func alertSources(alertSource []NotificationChannelAlertSourceItem) string {
+	items := make([]string, len(alertSource))
+	for i, item := range alertSource {
+		findingTypes := append([]string{}, item.FindingTypes...)
+		sort.Strings(findingTypes)
+		items[i] = stringValue(item.ProviderName) + "=" + strings.Join(findingTypes, "|")
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
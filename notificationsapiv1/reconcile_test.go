@@ -0,0 +1,248 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsapiv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Reconcile`, func() {
+	channelsPath := "/v1/account/notifications/channels"
+	declaration := []byte(`
+- name: managed-unchanged
+  type: Webhook
+  endpoint: https://example.com/unchanged
+  description: unchanged channel
+  severity: [high, critical]
+  enabled: true
+  alert_source:
+    - provider_name: VA
+      finding_types: [image_with_vulnerabilities]
+- name: managed-changed
+  type: Webhook
+  endpoint: https://example.com/new
+  severity: [low]
+  frequency: daily
+- name: new
+  type: Webhook
+  endpoint: https://example.com/created
+- name: unmanaged
+  type: Webhook
+  endpoint: https://example.com/unmanaged
+`)
+	existing := `{"channels": [
+		{"channel_id": "1", "name": "managed-unchanged", "type": "Webhook", "endpoint": "https://example.com/unchanged",
+		 "description": "unchanged channel [managed-by:security-advisor-sdk-go]", "enabled": true,
+		 "severity": {"critical": true, "high": true, "medium": false, "low": false},
+		 "alert_source": [{"provider_name": "VA", "finding_types": ["image_with_vulnerabilities"]}]},
+		{"channel_id": "2", "name": "managed-changed", "type": "Webhook", "endpoint": "https://example.com/old",
+		 "description": "[managed-by:security-advisor-sdk-go]", "enabled": true, "severity": {"low": true}},
+		{"channel_id": "3", "name": "managed-removed", "type": "Webhook", "endpoint": "https://example.com/removed",
+		 "description": "[managed-by:security-advisor-sdk-go]"},
+		{"channel_id": "4", "name": "unmanaged", "type": "Webhook", "endpoint": "https://example.com/unmanaged"},
+		{"channel_id": "5", "name": "unmanaged-removed", "type": "Webhook", "endpoint": "https://example.com/other"}
+	]}`
+
+	var requests []string
+	var bodies []map[string]interface{}
+	var failing string
+	var testService *notificationsapiv1.NotificationsApiV1
+	var testServer *httptest.Server
+	BeforeEach(func() {
+		requests = nil
+		bodies = nil
+		failing = ""
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			res.Header().Set("Content-type", "application/json")
+			if req.Method == failing {
+				res.WriteHeader(500)
+				res.Write([]byte(`{"message": "unavailable"}`))
+				return
+			}
+			switch req.Method {
+			case "GET":
+				Expect(req.URL.Path).To(Equal(channelsPath))
+				res.WriteHeader(200)
+				res.Write([]byte(existing))
+			case "DELETE":
+				var ids []string
+				Expect(json.NewDecoder(req.Body).Decode(&ids)).To(Succeed())
+				Expect(ids).To(Equal([]string{"3"}))
+				res.WriteHeader(200)
+				res.Write([]byte(`{"message": "deleted"}`))
+			default:
+				var body map[string]interface{}
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				bodies = append(bodies, body)
+				res.WriteHeader(200)
+				res.Write([]byte(`{"channel_id": "6", "status_code": 200}`))
+			}
+		}))
+		var err error
+		testService, err = notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`prints the plan without applying it in dry-run mode`, func() {
+		desired, err := notificationsapiv1.ParseChannels(declaration)
+		Expect(err).To(BeNil())
+		Expect(desired).To(HaveLen(4))
+
+		var out bytes.Buffer
+		plan, err := testService.Reconcile(context.Background(), "account", desired, &notificationsapiv1.ReconcileOptions{DryRun: true, Out: &out})
+		Expect(err).To(BeNil())
+		Expect(plan.HasChanges()).To(BeTrue())
+		Expect(out.String()).To(Equal(strings.Join([]string{
+			`~ channel "managed-changed"`,
+			`    endpoint: "https://example.com/old" -> "https://example.com/new"`,
+			`    frequency: "" -> "daily"`,
+			`+ channel "new"`,
+			`! channel "unmanaged" exists without the managed-by marker, left untouched`,
+			`- channel "managed-removed"`,
+			``,
+		}, "\n")))
+		Expect(requests).To(Equal([]string{"GET " + channelsPath}))
+	})
+	It(`applies the plan`, func() {
+		desired, err := notificationsapiv1.ParseChannels(declaration)
+		Expect(err).To(BeNil())
+
+		_, err = testService.Reconcile(context.Background(), "account", desired, nil)
+		Expect(err).To(BeNil())
+		Expect(requests).To(Equal([]string{
+			"GET " + channelsPath,
+			"PUT " + channelsPath + "/2",
+			"POST " + channelsPath,
+			"DELETE " + channelsPath,
+		}))
+		Expect(bodies[0]["description"]).To(Equal("[managed-by:security-advisor-sdk-go]"))
+		Expect(bodies[0]["endpoint"]).To(Equal("https://example.com/new"))
+		Expect(bodies[0]["frequency"]).To(Equal("daily"))
+		Expect(bodies[0]).NotTo(HaveKey("enabled"))
+		Expect(bodies[1]["name"]).To(Equal("new"))
+		Expect(bodies[1]["description"]).To(Equal("[managed-by:security-advisor-sdk-go]"))
+	})
+	It(`leaves the severity and alert sources the service defaulted alone`, func() {
+		// The service notifies every severity and alert source of channels created without them.
+		var channels []map[string]interface{}
+		service := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			if req.Method == "GET" {
+				Expect(json.NewEncoder(res).Encode(map[string]interface{}{"channels": channels})).To(Succeed())
+				return
+			}
+			Expect(req.Method).To(Equal("POST"))
+			var channel map[string]interface{}
+			Expect(json.NewDecoder(req.Body).Decode(&channel)).To(Succeed())
+			Expect(channel).NotTo(HaveKey("severity"))
+			Expect(channel).NotTo(HaveKey("alert_source"))
+			channel["channel_id"] = "1"
+			channel["severity"] = map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
+			channel["alert_source"] = []map[string]interface{}{{"provider_name": "ALL", "finding_types": []string{"ALL"}}}
+			channels = append(channels, channel)
+			res.Write([]byte(`{"channel_id": "1", "status_code": 200}`))
+		}))
+		defer service.Close()
+		serviceClient, err := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+			URL:           service.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		desired, err := notificationsapiv1.ParseChannels([]byte("- {name: defaults, type: Webhook, endpoint: https://example.com/defaults}\n"))
+		Expect(err).To(BeNil())
+
+		plan, err := serviceClient.Reconcile(context.Background(), "account", desired, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Changes[0].Action).To(Equal(notificationsapiv1.ChannelChange_Action_Create))
+		plan, err = serviceClient.Reconcile(context.Background(), "account", desired, nil)
+		Expect(err).To(BeNil())
+		Expect(plan.Changes).To(HaveLen(1))
+		Expect(plan.Changes[0].Action).To(Equal(notificationsapiv1.ChannelChange_Action_Unchanged))
+		Expect(plan.Changes[0].Diff).To(BeEmpty())
+		Expect(plan.HasChanges()).To(BeFalse())
+		Expect(channels).To(HaveLen(1))
+	})
+	It(`keeps the old channels and reports the applied changes when a change fails`, func() {
+		desired, err := notificationsapiv1.ParseChannels(declaration)
+		Expect(err).To(BeNil())
+
+		failing = "POST"
+		_, err = testService.Reconcile(context.Background(), "account", desired, nil)
+		Expect(requests).To(Equal([]string{
+			"GET " + channelsPath,
+			"PUT " + channelsPath + "/2",
+			"POST " + channelsPath,
+		}))
+		reconcileErr, ok := err.(*notificationsapiv1.ReconcileError)
+		Expect(ok).To(BeTrue())
+		Expect(reconcileErr.Applied).To(HaveLen(1))
+		Expect(reconcileErr.Applied[0].Name).To(Equal("managed-changed"))
+		Expect(reconcileErr.Failed[0].Name).To(Equal("new"))
+		Expect(err.Error()).To(HavePrefix(`failed to create channel "new": `))
+		Expect(err.Error()).To(HaveSuffix(`(applied: update "managed-changed")`))
+
+		requests = nil
+		failing = "DELETE"
+		_, err = testService.Reconcile(context.Background(), "account", desired, nil)
+		Expect(err.Error()).To(HaveSuffix(`(applied: update "managed-changed", create "new")`))
+		Expect(err.(*notificationsapiv1.ReconcileError).Failed[0].Name).To(Equal("managed-removed"))
+	})
+	It(`loads the channel declarations of the examples`, func() {
+		channels, err := notificationsapiv1.LoadChannels("../examples/notificationsapiv1/input/channel_with_all.json")
+		Expect(err).To(BeNil())
+		Expect(channels).To(HaveLen(1))
+		Expect(*channels[0].Name).To(Equal("sdkTest_channel_all_exmpl"))
+		Expect(channels[0].AlertSource).To(HaveLen(2))
+
+		_, err = notificationsapiv1.ParseChannels([]byte("- name: twice\n- name: twice\n"))
+		Expect(err).To(BeNil())
+		_, err = testService.Reconcile(context.Background(), "account", []notificationsapiv1.CreateNotificationChannelOptions{
+			{Name: core.StringPtr("twice")}, {Name: core.StringPtr("twice")},
+		}, nil)
+		Expect(err).NotTo(BeNil())
+	})
+	It(`rejects invalid alert sources before applying the plan`, func() {
+		desired, err := notificationsapiv1.ParseChannels([]byte("- {name: all, alert_source: [{provider_name: ALL}, {provider_name: VA}]}\n"))
+		Expect(err).To(BeNil())
+		_, err = testService.Reconcile(context.Background(), "account", desired, nil)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`channel "all": alert_source[0].provider_name`))
+		Expect(requests).To(Equal([]string{"GET " + channelsPath}))
+	})
+})
@@ -0,0 +1,163 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver
+
+import (
+	"context"
+	"crypto/rsa"
+	"sync"
+	"time"
+)
+
+// Refresher : Implemented by key providers able to fetch a new key on demand. The handler asks for a new key once
+// when a notification fails verification, so that notifications signed with a rotated key are not dropped.
+type Refresher interface {
+	Refresh(ctx context.Context) (*rsa.PublicKey, error)
+}
+
+// DefaultKeyTTL : The time a CachingKeyProvider keeps a key before fetching it again
+const DefaultKeyTTL = time.Hour
+
+// DefaultMinRefreshInterval : The minimum time between two fetches forced by Refresh
+const DefaultMinRefreshInterval = time.Minute
+
+// DefaultFetchTimeout : The time a CachingKeyProvider waits for its source to return a key
+const DefaultFetchTimeout = 30 * time.Second
+
+// CachingKeyProvider : Caches the key of another KeyProvider, typically the one of NewServiceKeyProvider.
+//
+// Once the TTL has passed the cached key is still returned while a new one is fetched in the background; if that
+// fetch fails the cached key keeps being used and the fetch is retried after MinRefreshInterval. Refresh fetches a
+// new key right away, but at most once per MinRefreshInterval so that forged notifications can't flood the source
+// with requests. Concurrent callers share a single fetch, which is abandoned after FetchTimeout.
+type CachingKeyProvider struct {
+	source KeyProvider
+
+	// The time a key is used before being fetched again.
+	TTL time.Duration
+
+	// The minimum time between two fetches forced by Refresh.
+	MinRefreshInterval time.Duration
+
+	// The time the source is given to return a key.
+	FetchTimeout time.Duration
+
+	mutex       sync.Mutex
+	key         *rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	inflight    *keyFetch
+}
+
+// keyFetch is a fetch from the source shared by the callers waiting for it.
+type keyFetch struct {
+	done chan struct{}
+	key  *rsa.PublicKey
+	err  error
+}
+
+// NewCachingKeyProvider : Instantiate CachingKeyProvider. A zero ttl means DefaultKeyTTL.
+func NewCachingKeyProvider(source KeyProvider, ttl time.Duration) *CachingKeyProvider {
+	if ttl <= 0 {
+		ttl = DefaultKeyTTL
+	}
+	return &CachingKeyProvider{
+		source:             source,
+		TTL:                ttl,
+		MinRefreshInterval: DefaultMinRefreshInterval,
+		FetchTimeout:       DefaultFetchTimeout,
+	}
+}
+
+// PublicKey : Returns the cached key, fetching it on first use and in the background once it has expired
+func (provider *CachingKeyProvider) PublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	provider.mutex.Lock()
+	key := provider.key
+	if key == nil {
+		call := provider.startFetch()
+		provider.mutex.Unlock()
+		return call.wait(ctx)
+	}
+	expired := time.Since(provider.fetchedAt) >= provider.TTL
+	if expired && time.Since(provider.attemptedAt) >= provider.MinRefreshInterval {
+		provider.startFetch()
+	}
+	provider.mutex.Unlock()
+	return key, nil
+}
+
+// Refresh : Fetches a new key, unless a key was fetched less than MinRefreshInterval ago
+func (provider *CachingKeyProvider) Refresh(ctx context.Context) (*rsa.PublicKey, error) {
+	provider.mutex.Lock()
+	if provider.key != nil && time.Since(provider.attemptedAt) < provider.MinRefreshInterval {
+		key := provider.key
+		provider.mutex.Unlock()
+		return key, nil
+	}
+	call := provider.startFetch()
+	provider.mutex.Unlock()
+	return call.wait(ctx)
+}
+
+// startFetch returns the fetch in progress, or starts one. The mutex must be held.
+func (provider *CachingKeyProvider) startFetch() *keyFetch {
+	if provider.inflight != nil {
+		return provider.inflight
+	}
+	call := &keyFetch{done: make(chan struct{})}
+	provider.inflight = call
+	go provider.fetch(call)
+	return call
+}
+
+// fetch gets a key from the source and caches it. On failure the cached key, if any, is returned instead.
+// The fetch isn't bound to the context of the caller that started it, as other callers may be waiting for it.
+func (provider *CachingKeyProvider) fetch(call *keyFetch) {
+	timeout := provider.FetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	key, err := provider.source.PublicKey(ctx)
+
+	provider.mutex.Lock()
+	provider.inflight = nil
+	provider.attemptedAt = time.Now()
+	if err != nil {
+		if provider.key != nil {
+			key, err = provider.key, nil
+		}
+	} else {
+		provider.key = key
+		provider.fetchedAt = provider.attemptedAt
+	}
+	provider.mutex.Unlock()
+
+	call.key, call.err = key, err
+	close(call.done)
+}
+
+// wait returns the result of the fetch, or the error of the context when it is done first.
+func (call *keyFetch) wait(ctx context.Context) (*rsa.PublicKey, error) {
+	select {
+	case <-call.done:
+		return call.key, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,170 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// rotatingSource is a KeyProvider whose key can be rotated, counting the fetches. When hang is set, fetches block
+// until their context is done.
+type rotatingSource struct {
+	mutex   sync.Mutex
+	key     *rsa.PublicKey
+	err     error
+	hang    bool
+	fetches int
+}
+
+func (source *rotatingSource) PublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	source.mutex.Lock()
+	source.fetches++
+	hang := source.hang
+	source.mutex.Unlock()
+	if hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(10 * time.Millisecond)
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.key, source.err
+}
+
+func (source *rotatingSource) set(key *rsa.PublicKey, err error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.key = key
+	source.err = err
+}
+
+func (source *rotatingSource) count() int {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.fetches
+}
+
+var _ = Describe(`CachingKeyProvider`, func() {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	It(`caches the key and refreshes it in the background once expired`, func() {
+		source := &rotatingSource{key: &oldKey.PublicKey}
+		provider := notificationsreceiver.NewCachingKeyProvider(source, 50*time.Millisecond)
+		provider.MinRefreshInterval = 0
+
+		key, err := provider.PublicKey(context.Background())
+		Expect(err).To(BeNil())
+		Expect(key).To(Equal(&oldKey.PublicKey))
+		_, _ = provider.PublicKey(context.Background())
+		Expect(source.count()).To(Equal(1))
+
+		source.set(&newKey.PublicKey, nil)
+		time.Sleep(60 * time.Millisecond)
+		key, _ = provider.PublicKey(context.Background())
+		Expect(key).To(Equal(&oldKey.PublicKey))
+		Eventually(func() *rsa.PublicKey {
+			key, _ := provider.PublicKey(context.Background())
+			return key
+		}).Should(Equal(&newKey.PublicKey))
+	})
+	It(`shares a single fetch between concurrent callers`, func() {
+		source := &rotatingSource{key: &oldKey.PublicKey}
+		provider := notificationsreceiver.NewCachingKeyProvider(source, time.Hour)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				key, err := provider.PublicKey(context.Background())
+				Expect(err).To(BeNil())
+				Expect(key).To(Equal(&oldKey.PublicKey))
+			}()
+		}
+		wg.Wait()
+		Expect(source.count()).To(Equal(1))
+	})
+	It(`abandons a fetch after FetchTimeout so that the key is fetched again later`, func() {
+		source := &rotatingSource{key: &oldKey.PublicKey, hang: true}
+		provider := notificationsreceiver.NewCachingKeyProvider(source, time.Hour)
+		provider.FetchTimeout = 20 * time.Millisecond
+		provider.MinRefreshInterval = 0
+		_, err := provider.PublicKey(context.Background())
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+
+		source.mutex.Lock()
+		source.hang = false
+		source.mutex.Unlock()
+		key, err := provider.PublicKey(context.Background())
+		Expect(err).To(BeNil())
+		Expect(key).To(Equal(&oldKey.PublicKey))
+		Expect(source.count()).To(Equal(2))
+	})
+	It(`keeps the cached key when fetching a new one fails`, func() {
+		source := &rotatingSource{key: &oldKey.PublicKey}
+		provider := notificationsreceiver.NewCachingKeyProvider(source, time.Hour)
+		provider.MinRefreshInterval = 0
+		_, _ = provider.PublicKey(context.Background())
+
+		source.set(nil, errors.New("down"))
+		key, err := provider.Refresh(context.Background())
+		Expect(err).To(BeNil())
+		Expect(key).To(Equal(&oldKey.PublicKey))
+
+		_, err = notificationsreceiver.NewCachingKeyProvider(source, time.Hour).PublicKey(context.Background())
+		Expect(err).NotTo(BeNil())
+	})
+	It(`verifies notifications signed with a rotated key`, func() {
+		source := &rotatingSource{key: &oldKey.PublicKey}
+		provider := notificationsreceiver.NewCachingKeyProvider(source, time.Hour)
+		received := 0
+		handler := notificationsreceiver.NewHandler(provider, func(context.Context, *notificationsreceiver.Notification) error {
+			received++
+			return nil
+		})
+		_, _ = provider.PublicKey(context.Background())
+		provider.MinRefreshInterval = 0
+
+		source.set(&newKey.PublicKey, nil)
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iat": time.Now().Unix()}).SignedString(newKey)
+		_, err := handler.Parse(context.Background(), strings.NewReader(fmt.Sprintf(`{"data": %q}`, token)))
+		Expect(err).To(BeNil())
+		Expect(source.count()).To(Equal(2))
+
+		// Forged notifications don't fetch the key more than once per MinRefreshInterval.
+		provider.MinRefreshInterval = time.Hour
+		forged, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iat": time.Now().Unix()}).SignedString(oldKey)
+		for i := 0; i < 3; i++ {
+			_, err = handler.ParseToken(context.Background(), forged)
+			Expect(errors.Is(err, notificationsreceiver.ErrInvalidSignature)).To(BeTrue())
+		}
+		Expect(source.count()).To(Equal(2))
+	})
+})
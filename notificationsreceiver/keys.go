@@ -0,0 +1,75 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+)
+
+// KeyProvider : Supplies the public key used to verify the signature of notifications
+type KeyProvider interface {
+	PublicKey(ctx context.Context) (*rsa.PublicKey, error)
+}
+
+// KeyProviderFunc : Adapts a function to the KeyProvider interface
+type KeyProviderFunc func(ctx context.Context) (*rsa.PublicKey, error)
+
+// PublicKey : Calls the function
+func (f KeyProviderFunc) PublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	return f(ctx)
+}
+
+// StaticKey : Returns a KeyProvider always supplying the given key
+func StaticKey(key *rsa.PublicKey) KeyProvider {
+	return KeyProviderFunc(func(context.Context) (*rsa.PublicKey, error) {
+		return key, nil
+	})
+}
+
+// NewServiceKeyProvider : Returns a KeyProvider fetching the public key of the account with GetPublicKey on every call
+func NewServiceKeyProvider(notificationsApi *notificationsapiv1.NotificationsApiV1, accountID string) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (*rsa.PublicKey, error) {
+		result, _, err := notificationsApi.GetPublicKeyWithContext(ctx, notificationsApi.NewGetPublicKeyOptions(accountID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the notifications public key: %w", err)
+		}
+		if result.PublicKey == nil {
+			return nil, fmt.Errorf("failed to get the notifications public key: empty response")
+		}
+		return ParsePublicKey(*result.PublicKey)
+	})
+}
+
+// ParsePublicKey : Parses a PEM encoded RSA public key, as returned by GetPublicKey.
+// Keys given as bare base64 without the PEM header and footer are accepted too.
+func ParsePublicKey(key string) (*rsa.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, "-----BEGIN") {
+		key = "-----BEGIN PUBLIC KEY-----\n" + key + "\n-----END PUBLIC KEY-----"
+	}
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid notifications public key: %w", err)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,75 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`KeyProvider`, func() {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	der, _ := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	It(`parses PEM and bare base64 keys`, func() {
+		key, err := notificationsreceiver.ParsePublicKey(pemKey)
+		Expect(err).To(BeNil())
+		Expect(key.N).To(Equal(privateKey.PublicKey.N))
+
+		key, err = notificationsreceiver.ParsePublicKey(base64.StdEncoding.EncodeToString(der))
+		Expect(err).To(BeNil())
+		Expect(key.N).To(Equal(privateKey.PublicKey.N))
+
+		_, err = notificationsreceiver.ParsePublicKey("not a key")
+		Expect(err).NotTo(BeNil())
+	})
+	It(`fetches the key of the account with GetPublicKey`, func() {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(req.URL.Path).To(Equal("/v1/account/notifications/public_key"))
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprintf(res, `{"public_key": %q}`, pemKey)
+		}))
+		defer testServer.Close()
+
+		testService, testServiceErr := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(testServiceErr).To(BeNil())
+
+		key, err := notificationsreceiver.NewServiceKeyProvider(testService, "account").PublicKey(context.Background())
+		Expect(err).To(BeNil())
+		Expect(key.E).To(Equal(privateKey.PublicKey.E))
+	})
+})
@@ -0,0 +1,121 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package notificationsreceiver : Receives the alerts that Security Advisor pushes to the endpoint of Webhook channels
+package notificationsreceiver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+)
+
+// Payload : The body posted to the endpoint of a Webhook channel
+type Payload struct {
+
+	// The notification, as a JWT signed with the private key matching the key returned by GetPublicKey.
+	Data string `json:"data"`
+}
+
+// Notification : An alert raised for a finding occurrence
+type Notification struct {
+
+	// The account the occurrence belongs to.
+	AccountID string `json:"account_id,omitempty"`
+
+	// The name of the channel the notification was sent through.
+	ChannelName string `json:"channel_name,omitempty"`
+
+	// The URL of the Security Advisor instance that sent the notification.
+	IssuerURL string `json:"issuer_url,omitempty"`
+
+	// The provider of the finding, for example "ATA" or a custom provider ID.
+	ProviderName string `json:"provider_name,omitempty"`
+
+	// The finding type, which is the ID of the note of the occurrence.
+	FindingType string `json:"finding_type,omitempty"`
+
+	// The severity of the finding.
+	Severity findingsapiv1.Severity `json:"severity,omitempty"`
+
+	// The occurrence that raised the alert.
+	Occurrence *findingsapiv1.ApiOccurrence `json:"occurrence,omitempty"`
+
+	// The time the notification was signed at. Zero when the token has no "iat" claim.
+	IssuedAt time.Time `json:"-"`
+
+	// Identifies the delivery: the "jti" claim of the token or, when it has none, a hash of the notification
+	// fields. Retries of a delivery have the same ID, even when they are signed again.
+	ID string `json:"-"`
+}
+
+// notificationClaims are the claims of the JWT: the notification fields next to the registered claims.
+type notificationClaims struct {
+	jwt.RegisteredClaims
+	Notification
+}
+
+// Valid checks the expiry only; the registered "iat" claim isn't checked against the local clock here, as
+// notifications signed by a server whose clock is slightly ahead would be rejected.
+func (claims *notificationClaims) Valid() error {
+	if !claims.VerifyExpiresAt(time.Now(), false) {
+		return jwt.NewValidationError("token is expired", jwt.ValidationErrorExpired)
+	}
+	return nil
+}
+
+// notification completes the fields the sender left empty from the occurrence.
+func (claims *notificationClaims) notification(token string) *Notification {
+	notification := claims.Notification
+	notification.ID = claims.RegisteredClaims.ID
+	if notification.ID == "" {
+		notification.ID = payloadID(&claims.Notification, token)
+	}
+	if claims.RegisteredClaims.IssuedAt != nil {
+		notification.IssuedAt = claims.RegisteredClaims.IssuedAt.UTC()
+	}
+	occurrence := notification.Occurrence
+	if occurrence == nil {
+		return &notification
+	}
+	if notification.ProviderName == "" && occurrence.ProviderID != nil {
+		notification.ProviderName = *occurrence.ProviderID
+	}
+	if notification.FindingType == "" && occurrence.NoteName != nil {
+		if _, _, noteID, err := findingsapiv1.ParseNoteName(*occurrence.NoteName); err == nil {
+			notification.FindingType = noteID
+		}
+	}
+	if notification.Severity == "" {
+		notification.Severity = occurrence.Finding.GetSeverity()
+	}
+	return &notification
+}
+
+// payloadID hashes the notification fields, leaving out the registered claims such as "iat" that change when a
+// retry is signed again. The token is hashed instead if the fields can't be encoded.
+func payloadID(notification *Notification, token string) string {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		data = []byte(token)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
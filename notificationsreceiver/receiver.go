@@ -0,0 +1,267 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// MaxPayloadSize : The largest request body accepted by the handler
+const MaxPayloadSize = 1 << 20
+
+var (
+	// ErrInvalidPayload : The body isn't a notification payload or its token is malformed
+	ErrInvalidPayload = errors.New("invalid notification payload")
+
+	// ErrInvalidSignature : The token isn't signed with the notifications key, or has expired
+	ErrInvalidSignature = errors.New("invalid notification signature")
+
+	// ErrKeyUnavailable : The public key could not be obtained from the KeyProvider
+	ErrKeyUnavailable = errors.New("notifications public key unavailable")
+
+	// ErrStaleNotification : The notification was signed outside of the freshness window of the handler
+	ErrStaleNotification = errors.New("stale notification")
+)
+
+// DefaultMaxAge : The default freshness window of a Handler
+const DefaultMaxAge = 10 * time.Minute
+
+// DefaultMaxClockSkew : The default time notifications may be signed in the future of the clock of a Handler
+const DefaultMaxClockSkew = time.Minute
+
+// Callback : Handles a verified notification. Returning an error makes the handler answer 500 so that the
+// notification is delivered again.
+type Callback func(ctx context.Context, notification *Notification) error
+
+// Handler : An http.Handler for the endpoint of a Webhook channel. It verifies the signature of each notification
+// with the key of its KeyProvider and passes the decoded notification to its Callback.
+//
+// Notifications signed more than MaxAge ago are rejected, and the IDs of the delivered notifications are kept in
+// the Seen store so that retried deliveries are acknowledged without calling the callback again. Tokens without an
+// "iat" claim are rejected while RequireIssuedAt is set; when it isn't, their IDs never expire from the Seen store,
+// as nothing else stops their replay.
+//
+// The handler answers
+//  - 200 once the callback succeeded, or for notifications already delivered
+//  - 400 for bodies that aren't notification payloads
+//  - 401 for notifications that aren't signed with the notifications key, or are stale
+//  - 405 for requests other than POST
+//  - 500 when the callback or the Seen store failed
+//  - 503 when the public key could not be obtained
+type Handler struct {
+	keys     KeyProvider
+	callback Callback
+
+	// The freshness window. Notifications signed earlier are rejected. Zero disables the check.
+	MaxAge time.Duration
+
+	// Rejects notifications without an "iat" claim, whose freshness can't be checked. Set by NewHandler.
+	RequireIssuedAt bool
+
+	// The time notifications may be signed in the future of the local clock.
+	MaxClockSkew time.Duration
+
+	// The IDs of the delivered notifications. Nil disables the deduplication.
+	Seen SeenStore
+
+	// Logs why a request was rejected; the response only holds the status text. Nil disables the logging.
+	ErrorLog *log.Logger
+}
+
+// NewHandler : Instantiate Handler with DefaultMaxAge, DefaultMaxClockSkew, RequireIssuedAt and a MemorySeenStore of
+// DefaultSeenCapacity
+func NewHandler(keys KeyProvider, callback Callback) *Handler {
+	return &Handler{
+		keys:            keys,
+		callback:        callback,
+		MaxAge:          DefaultMaxAge,
+		RequireIssuedAt: true,
+		MaxClockSkew:    DefaultMaxClockSkew,
+		Seen:            NewMemorySeenStore(DefaultSeenCapacity),
+	}
+}
+
+// ServeHTTP : Verifies the posted notification and calls the callback
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	notification, err := handler.Parse(r.Context(), http.MaxBytesReader(w, r.Body, MaxPayloadSize))
+	if err != nil {
+		handler.reject(w, statusCode(err), err)
+		return
+	}
+	if err = handler.deliver(r.Context(), notification); err != nil {
+		handler.reject(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// reject answers the status code and logs the error, which isn't sent to the caller.
+func (handler *Handler) reject(w http.ResponseWriter, code int, err error) {
+	if handler.ErrorLog != nil {
+		handler.ErrorLog.Printf("notificationsreceiver: %d %s: %s", code, http.StatusText(code), err.Error())
+	}
+	http.Error(w, http.StatusText(code), code)
+}
+
+// deliver calls the callback unless the notification was already delivered.
+func (handler *Handler) deliver(ctx context.Context, notification *Notification) error {
+	if handler.Seen == nil {
+		return handler.callback(ctx, notification)
+	}
+
+	// Once the freshness window of the notification is over, its replays are rejected as stale and its ID can be
+	// dropped. Without a window, the ID is kept until the store drops it for capacity.
+	var expiresAt time.Time
+	if handler.MaxAge > 0 && !notification.IssuedAt.IsZero() {
+		expiresAt = notification.IssuedAt.Add(handler.MaxAge + handler.MaxClockSkew)
+	}
+	alreadySeen, err := handler.Seen.MarkSeen(notification.ID, expiresAt)
+	if err != nil || alreadySeen {
+		return err
+	}
+	if err = handler.callback(ctx, notification); err != nil {
+		_ = handler.Seen.Forget(notification.ID)
+		return err
+	}
+	return nil
+}
+
+// Parse : Reads a notification payload and verifies its token.
+// The returned error wraps ErrInvalidPayload, ErrInvalidSignature, ErrStaleNotification or ErrKeyUnavailable.
+func (handler *Handler) Parse(ctx context.Context, body io.Reader) (*Notification, error) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err.Error())
+	}
+	var payload Payload
+	if err = json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err.Error())
+	}
+	if payload.Data == "" {
+		return nil, fmt.Errorf("%w: data is required", ErrInvalidPayload)
+	}
+	return handler.ParseToken(ctx, payload.Data)
+}
+
+// ParseToken : Verifies the signed token of a notification and decodes it. When the signature doesn't match and the
+// KeyProvider is a Refresher, the token is verified once more with a new key.
+// The returned error wraps ErrInvalidPayload, ErrInvalidSignature, ErrStaleNotification or ErrKeyUnavailable.
+func (handler *Handler) ParseToken(ctx context.Context, token string) (*Notification, error) {
+	key, err := handler.keys.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyUnavailable, err.Error())
+	}
+
+	claims, err := verify(token, key)
+	if err == errKeyMismatch {
+		if refresher, ok := handler.keys.(Refresher); ok {
+			var freshKey *rsa.PublicKey
+			freshKey, err = refresher.Refresh(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrKeyUnavailable, err.Error())
+			}
+			claims, err = verify(token, freshKey)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	notification := claims.notification(token)
+	if err = handler.checkFreshness(notification.IssuedAt); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// checkFreshness rejects notifications signed outside of the freshness window.
+func (handler *Handler) checkFreshness(issuedAt time.Time) error {
+	if handler.MaxAge <= 0 {
+		return nil
+	}
+	if issuedAt.IsZero() {
+		if handler.RequireIssuedAt {
+			return fmt.Errorf("%w: the token has no iat claim", ErrStaleNotification)
+		}
+		return nil
+	}
+	now := time.Now()
+	if age := now.Sub(issuedAt); age > handler.MaxAge {
+		return fmt.Errorf("%w: signed %s ago", ErrStaleNotification, age.Round(time.Second))
+	}
+	if issuedAt.Sub(now) > handler.MaxClockSkew {
+		return fmt.Errorf("%w: signed in the future", ErrStaleNotification)
+	}
+	return nil
+}
+
+// errKeyMismatch is returned for tokens whose signature doesn't match the key.
+var errKeyMismatch = fmt.Errorf("%w: signature doesn't match the key", ErrInvalidSignature)
+
+// verify checks the signature and the claims of a token. The returned error wraps ErrInvalidPayload or
+// ErrInvalidSignature, or is errKeyMismatch when a new key might verify the token.
+func verify(token string, key *rsa.PublicKey) (*notificationClaims, error) {
+	claims := new(notificationClaims)
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		// Notifications are signed with RS256 only; other algorithms aren't a reason to fetch a new key.
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return key, nil
+	})
+	if err == nil {
+		return claims, nil
+	}
+	var validationErr *jwt.ValidationError
+	if errors.As(err, &validationErr) {
+		if validationErr.Errors&jwt.ValidationErrorMalformed != 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, err.Error())
+		}
+		if validationErr.Errors&jwt.ValidationErrorSignatureInvalid != 0 {
+			return nil, errKeyMismatch
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrInvalidSignature, err.Error())
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrStaleNotification):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrKeyUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadRequest
+	}
+}
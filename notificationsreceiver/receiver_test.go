@@ -0,0 +1,197 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Handler`, func() {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	claims := jwt.MapClaims{
+		"iat":          issuedAt.Unix(),
+		"account_id":   "account",
+		"channel_name": "channel",
+		"occurrence": map[string]interface{}{
+			"id":          "occurrence",
+			"kind":        "FINDING",
+			"provider_id": "my-tool",
+			"note_name":   "account/providers/my-tool/notes/vulnerability",
+			"finding":     map[string]interface{}{"severity": "HIGH"},
+		},
+	}
+	sign := func(key *rsa.PrivateKey, claims jwt.Claims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		Expect(err).To(BeNil())
+		return fmt.Sprintf(`{"data": %q}`, token)
+	}
+
+	var received []*notificationsreceiver.Notification
+	var callbackErr error
+	handler := notificationsreceiver.NewHandler(notificationsreceiver.StaticKey(&privateKey.PublicKey),
+		func(ctx context.Context, notification *notificationsreceiver.Notification) error {
+			received = append(received, notification)
+			return callbackErr
+		})
+	post := func(body string) int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return recorder.Code
+	}
+	BeforeEach(func() {
+		received = nil
+		callbackErr = nil
+		handler.Seen = notificationsreceiver.NewMemorySeenStore(0)
+	})
+
+	It(`decodes verified notifications and calls the callback`, func() {
+		Expect(post(sign(privateKey, claims))).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(1))
+		notification := received[0]
+		Expect(notification.AccountID).To(Equal("account"))
+		Expect(notification.ChannelName).To(Equal("channel"))
+		Expect(notification.ProviderName).To(Equal("my-tool"))
+		Expect(notification.FindingType).To(Equal("vulnerability"))
+		Expect(notification.Severity).To(Equal(findingsapiv1.Severity_High))
+		Expect(*notification.Occurrence.ID).To(Equal("occurrence"))
+		Expect(notification.IssuedAt).To(Equal(issuedAt.UTC()))
+	})
+	It(`rejects notifications that aren't signed with the notifications key`, func() {
+		Expect(post(sign(otherKey, claims))).To(Equal(http.StatusUnauthorized))
+		expired := jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}
+		Expect(post(sign(privateKey, expired))).To(Equal(http.StatusUnauthorized))
+		Expect(received).To(BeEmpty())
+	})
+	It(`only accepts RS256 and keeps the reason of a rejection out of the response`, func() {
+		var logged bytes.Buffer
+		handler.ErrorLog = log.New(&logged, "", 0)
+		defer func() { handler.ErrorLog = nil }()
+
+		rs512, err := jwt.NewWithClaims(jwt.SigningMethodRS512, claims).SignedString(privateKey)
+		Expect(err).To(BeNil())
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(fmt.Sprintf(`{"data": %q}`, rs512))))
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(recorder.Body.String()).To(Equal("Unauthorized\n"))
+		Expect(logged.String()).To(ContainSubstring("unexpected signing method RS512"))
+
+		hs256, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(x509.MarshalPKCS1PublicKey(&privateKey.PublicKey))
+		Expect(err).To(BeNil())
+		Expect(post(fmt.Sprintf(`{"data": %q}`, hs256))).To(Equal(http.StatusUnauthorized))
+		Expect(received).To(BeEmpty())
+	})
+	It(`rejects malformed payloads`, func() {
+		Expect(post(`not json`)).To(Equal(http.StatusBadRequest))
+		Expect(post(`{}`)).To(Equal(http.StatusBadRequest))
+		Expect(post(`{"data": "not a token"}`)).To(Equal(http.StatusBadRequest))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(received).To(BeEmpty())
+	})
+	It(`rejects stale notifications`, func() {
+		stale := jwt.MapClaims{"iat": time.Now().Add(-time.Hour).Unix()}
+		Expect(post(sign(privateKey, stale))).To(Equal(http.StatusUnauthorized))
+		future := jwt.MapClaims{"iat": time.Now().Add(time.Hour).Unix()}
+		Expect(post(sign(privateKey, future))).To(Equal(http.StatusUnauthorized))
+
+		// Tokens without an iat claim are rejected unless RequireIssuedAt is unset.
+		withoutIssuedAt := jwt.MapClaims{"account_id": "account"}
+		Expect(post(sign(privateKey, withoutIssuedAt))).To(Equal(http.StatusUnauthorized))
+		handler.RequireIssuedAt = false
+		defer func() { handler.RequireIssuedAt = true }()
+		Expect(post(sign(privateKey, withoutIssuedAt))).To(Equal(http.StatusOK))
+		received = nil
+
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, stale).SignedString(privateKey)
+		_, err := handler.ParseToken(context.Background(), token)
+		Expect(errors.Is(err, notificationsreceiver.ErrStaleNotification)).To(BeTrue())
+		Expect(received).To(BeEmpty())
+	})
+	It(`acknowledges retried deliveries without calling the callback again`, func() {
+		payload := sign(privateKey, claims)
+		Expect(post(payload)).To(Equal(http.StatusOK))
+		Expect(post(payload)).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(1))
+
+		withID := jwt.MapClaims{"iat": time.Now().Unix(), "jti": "delivery"}
+		Expect(post(sign(privateKey, withID))).To(Equal(http.StatusOK))
+		withID["account_id"] = "retried with another signature"
+		Expect(post(sign(privateKey, withID))).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(2))
+		Expect(received[1].ID).To(Equal("delivery"))
+
+		// Without a jti claim, a retry signed again at another time is identified by its notification fields.
+		resigned := jwt.MapClaims{}
+		for name, value := range claims {
+			resigned[name] = value
+		}
+		resigned["iat"] = issuedAt.Add(time.Second).Unix()
+		Expect(post(sign(privateKey, resigned))).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(2))
+	})
+	It(`keeps the IDs of notifications without iat claim past the freshness window`, func() {
+		handler.RequireIssuedAt = false
+		handler.MaxAge = 10 * time.Millisecond
+		handler.MaxClockSkew = 0
+		defer func() {
+			handler.RequireIssuedAt = true
+			handler.MaxAge = notificationsreceiver.DefaultMaxAge
+			handler.MaxClockSkew = notificationsreceiver.DefaultMaxClockSkew
+		}()
+
+		withoutIssuedAt := sign(privateKey, jwt.MapClaims{"jti": "replayed"})
+		Expect(post(withoutIssuedAt)).To(Equal(http.StatusOK))
+		time.Sleep(2 * handler.MaxAge)
+		Expect(post(withoutIssuedAt)).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(1))
+	})
+	It(`asks for a new delivery when the callback or the key provider fails`, func() {
+		callbackErr = errors.New("busy")
+		Expect(post(sign(privateKey, claims))).To(Equal(http.StatusInternalServerError))
+		callbackErr = nil
+		Expect(post(sign(privateKey, claims))).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(2))
+
+		unavailable := notificationsreceiver.NewHandler(
+			notificationsreceiver.KeyProviderFunc(func(context.Context) (*rsa.PublicKey, error) {
+				return nil, errors.New("down")
+			}), nil)
+		_, err := unavailable.Parse(context.Background(), strings.NewReader(sign(privateKey, claims)))
+		Expect(errors.Is(err, notificationsreceiver.ErrKeyUnavailable)).To(BeTrue())
+	})
+})
@@ -0,0 +1,285 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package receivertest : Simulates the deliveries of Security Advisor to test the consumers of Webhook channels.
+//
+//	sender, _ := receivertest.NewSender()
+//	keyServer := httptest.NewServer(sender)
+//	defer keyServer.Close()
+//
+//	// Point the notifications service of the consumer to keyServer.URL, then
+//	notification := receivertest.NewNotification("account", channel, occurrence)
+//	response, err := sender.Send(ctx, consumerURL, notification)
+package receivertest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+)
+
+// Malformation : A way of breaking a notification payload
+type Malformation string
+
+// Constants associated with Malformation.
+// - INVALID_JSON&#58; The body isn't JSON.
+// - MISSING_DATA&#58; The body has no "data" field.
+// - INVALID_TOKEN&#58; The "data" field isn't a JWT.
+// - WRONG_KEY&#58; The token is signed with a key other than the one served by the sender.
+// - EXPIRED&#58; The token has expired.
+// - TAMPERED&#58; The claims of the token were changed after signing.
+// - STALE&#58; The token was signed a day ago, outside of the freshness window of the receiver.
+const (
+	Malformation_InvalidJSON  Malformation = "INVALID_JSON"
+	Malformation_MissingData  Malformation = "MISSING_DATA"
+	Malformation_InvalidToken Malformation = "INVALID_TOKEN"
+	Malformation_WrongKey     Malformation = "WRONG_KEY"
+	Malformation_Expired      Malformation = "EXPIRED"
+	Malformation_Tampered     Malformation = "TAMPERED"
+	Malformation_Stale        Malformation = "STALE"
+)
+
+// Sender : Signs and posts notifications the way Security Advisor does. It is also an http.Handler serving its
+// public key at /v1/{account_id}/notifications/public_key, the path of GetPublicKey.
+type Sender struct {
+
+	// The key notifications are signed with.
+	PrivateKey *rsa.PrivateKey
+
+	// The client used to post notifications. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	mutex    sync.Mutex
+	lastBody []byte
+}
+
+// NewSender : Instantiate Sender with a new key pair
+func NewSender() (*Sender, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &Sender{PrivateKey: privateKey}, nil
+}
+
+// PublicKeyPEM : Returns the public key of the sender, PEM encoded like the response of GetPublicKey
+func (sender *Sender) PublicKeyPEM() (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&sender.PrivateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// KeyProvider : Returns a KeyProvider supplying the public key of the sender
+func (sender *Sender) KeyProvider() notificationsreceiver.KeyProvider {
+	return notificationsreceiver.StaticKey(&sender.PrivateKey.PublicKey)
+}
+
+// ServeHTTP : Serves the public key of the sender as the notifications public_key endpoint
+func (sender *Sender) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) != 4 || segments[0] != "v1" || segments[2] != "notifications" || segments[3] != "public_key" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	publicKey, err := sender.PublicKeyPEM()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	response := notificationsapiv1.PublicKeyResponse{PublicKey: core.StringPtr(publicKey)}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// NewNotification : Builds the notification Security Advisor sends through the channel for the occurrence.
+// The provider, finding type and severity are taken from the occurrence.
+func NewNotification(accountID string, channel *notificationsapiv1.ChannelResponseDefinition, occurrence *findingsapiv1.ApiOccurrence) *notificationsreceiver.Notification {
+	notification := &notificationsreceiver.Notification{
+		AccountID:  accountID,
+		Occurrence: occurrence,
+		IssuedAt:   time.Now(),
+	}
+	if channel != nil && channel.Name != nil {
+		notification.ChannelName = *channel.Name
+	}
+	if occurrence == nil {
+		return notification
+	}
+	if occurrence.ProviderID != nil {
+		notification.ProviderName = *occurrence.ProviderID
+	}
+	if occurrence.NoteName != nil {
+		if _, _, noteID, err := findingsapiv1.ParseNoteName(*occurrence.NoteName); err == nil {
+			notification.FindingType = noteID
+		}
+	}
+	notification.Severity = occurrence.Finding.GetSeverity()
+	return notification
+}
+
+// Sign : Returns the notification as a token signed with the key of the sender.
+// The "iat" claim is the IssuedAt time of the notification, or now when it is zero.
+func (sender *Sender) Sign(notification *notificationsreceiver.Notification) (string, error) {
+	return sign(sender.PrivateKey, notification, 0)
+}
+
+// Payload : Returns the body posting the notification
+func (sender *Sender) Payload(notification *notificationsreceiver.Notification) ([]byte, error) {
+	token, err := sender.Sign(notification)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(notificationsreceiver.Payload{Data: token})
+}
+
+// MalformedPayload : Returns a body posting the notification, broken as described by malformation
+func (sender *Sender) MalformedPayload(notification *notificationsreceiver.Notification, malformation Malformation) ([]byte, error) {
+	var token string
+	var err error
+	switch malformation {
+	case Malformation_InvalidJSON:
+		return []byte(`{"data": `), nil
+	case Malformation_MissingData:
+		return []byte(`{}`), nil
+	case Malformation_InvalidToken:
+		token = "not-a-token"
+	case Malformation_WrongKey:
+		var otherKey *rsa.PrivateKey
+		otherKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err == nil {
+			token, err = sign(otherKey, notification, 0)
+		}
+	case Malformation_Expired:
+		token, err = sign(sender.PrivateKey, notification, -time.Minute)
+	case Malformation_Tampered:
+		token, err = sender.Sign(notification)
+		if err == nil {
+			token, err = tamper(token)
+		}
+	case Malformation_Stale:
+		stale := *notification
+		stale.IssuedAt = time.Now().Add(-24 * time.Hour)
+		token, err = sender.Sign(&stale)
+	default:
+		err = fmt.Errorf("unknown malformation %q", malformation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(notificationsreceiver.Payload{Data: token})
+}
+
+// Send : Signs the notification and posts it to url
+func (sender *Sender) Send(ctx context.Context, url string, notification *notificationsreceiver.Notification) (*http.Response, error) {
+	body, err := sender.Payload(notification)
+	if err != nil {
+		return nil, err
+	}
+	return sender.SendRaw(ctx, url, body)
+}
+
+// SendMalformed : Posts the notification to url, broken as described by malformation
+func (sender *Sender) SendMalformed(ctx context.Context, url string, notification *notificationsreceiver.Notification, malformation Malformation) (*http.Response, error) {
+	body, err := sender.MalformedPayload(notification, malformation)
+	if err != nil {
+		return nil, err
+	}
+	return sender.SendRaw(ctx, url, body)
+}
+
+// Replay : Posts the last body sent by the sender to url again
+func (sender *Sender) Replay(ctx context.Context, url string) (*http.Response, error) {
+	sender.mutex.Lock()
+	body := sender.lastBody
+	sender.mutex.Unlock()
+	if body == nil {
+		return nil, fmt.Errorf("no notification was sent yet")
+	}
+	return sender.SendRaw(ctx, url, body)
+}
+
+// SendRaw : Posts body to url as is
+func (sender *Sender) SendRaw(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	sender.mutex.Lock()
+	sender.lastBody = body
+	sender.mutex.Unlock()
+
+	client := sender.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(request)
+}
+
+// sign returns the notification as a token signed with key, expiring after expiresIn when it isn't zero.
+func sign(key *rsa.PrivateKey, notification *notificationsreceiver.Notification, expiresIn time.Duration) (string, error) {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{}
+	if err = json.Unmarshal(data, &claims); err != nil {
+		return "", err
+	}
+	issuedAt := notification.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now()
+	}
+	claims["iat"] = issuedAt.Unix()
+	if expiresIn != 0 {
+		claims["exp"] = issuedAt.Add(expiresIn).Unix()
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+}
+
+// tamper replaces the claims of a signed token, keeping its signature.
+func tamper(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed token")
+	}
+	parts[1] = jwt.EncodeSegment([]byte(`{"account_id":"tampered"}`))
+	return strings.Join(parts, "."), nil
+}
@@ -0,0 +1,111 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package receivertest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v3/core"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver/receivertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Sender`, func() {
+	ctx := context.Background()
+	channel := &notificationsapiv1.ChannelResponseDefinition{Name: core.StringPtr("channel")}
+	occurrence := &findingsapiv1.ApiOccurrence{
+		ID:         core.StringPtr("occurrence"),
+		Kind:       core.StringPtr("FINDING"),
+		ProviderID: core.StringPtr("my-tool"),
+		NoteName:   core.StringPtr("account/providers/my-tool/notes/vulnerability"),
+		Finding:    &findingsapiv1.Finding{Severity: core.StringPtr("CRITICAL")},
+	}
+
+	var sender *receivertest.Sender
+	var keyServer, consumer *httptest.Server
+	var received []*notificationsreceiver.Notification
+	BeforeEach(func() {
+		var err error
+		sender, err = receivertest.NewSender()
+		Expect(err).To(BeNil())
+		keyServer = httptest.NewServer(sender)
+
+		service, err := notificationsapiv1.NewNotificationsApiV1(&notificationsapiv1.NotificationsApiV1Options{
+			URL:           keyServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		received = nil
+		consumer = httptest.NewServer(notificationsreceiver.NewHandler(
+			notificationsreceiver.NewServiceKeyProvider(service, "account"),
+			func(ctx context.Context, notification *notificationsreceiver.Notification) error {
+				received = append(received, notification)
+				return nil
+			}))
+	})
+	AfterEach(func() {
+		consumer.Close()
+		keyServer.Close()
+	})
+
+	It(`delivers notifications verified with the served public key`, func() {
+		response, err := sender.Send(ctx, consumer.URL, receivertest.NewNotification("account", channel, occurrence))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(1))
+		Expect(received[0].ChannelName).To(Equal("channel"))
+		Expect(received[0].ProviderName).To(Equal("my-tool"))
+		Expect(received[0].FindingType).To(Equal("vulnerability"))
+		Expect(received[0].Severity).To(Equal(findingsapiv1.Severity_Critical))
+
+		publicKey, err := sender.PublicKeyPEM()
+		Expect(err).To(BeNil())
+		Expect(publicKey).To(HavePrefix("-----BEGIN PUBLIC KEY-----"))
+
+		response, err = sender.Replay(ctx, consumer.URL)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(received).To(HaveLen(1))
+	})
+	It(`sends malformed payloads`, func() {
+		expected := map[receivertest.Malformation]int{
+			receivertest.Malformation_InvalidJSON:  http.StatusBadRequest,
+			receivertest.Malformation_MissingData:  http.StatusBadRequest,
+			receivertest.Malformation_InvalidToken: http.StatusBadRequest,
+			receivertest.Malformation_WrongKey:     http.StatusUnauthorized,
+			receivertest.Malformation_Expired:      http.StatusUnauthorized,
+			receivertest.Malformation_Tampered:     http.StatusUnauthorized,
+			receivertest.Malformation_Stale:        http.StatusUnauthorized,
+		}
+		notification := receivertest.NewNotification("account", channel, occurrence)
+		for malformation, statusCode := range expected {
+			response, err := sender.SendMalformed(ctx, consumer.URL, notification, malformation)
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(statusCode), string(malformation))
+		}
+		Expect(received).To(BeEmpty())
+
+		_, err := sender.MalformedPayload(notification, "UNKNOWN")
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,292 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SeenStore : Remembers the IDs of the notifications delivered to the callback, so that retried deliveries are
+// acknowledged without calling the callback again
+type SeenStore interface {
+
+	// MarkSeen records the ID until expiresAt and reports whether it was already recorded. A zero expiresAt keeps the
+	// ID until the store drops it for capacity.
+	MarkSeen(id string, expiresAt time.Time) (alreadySeen bool, err error)
+
+	// Forget removes the ID, so that the notification is delivered again when it is retried.
+	Forget(id string) error
+}
+
+// DefaultSeenCapacity : The number of IDs kept by the default SeenStore of a Handler
+const DefaultSeenCapacity = 10000
+
+// MemorySeenStore : A SeenStore keeping at most a given number of IDs in memory. The least recently marked IDs are
+// dropped first.
+type MemorySeenStore struct {
+	capacity int
+	mutex    sync.Mutex
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type seenEntry struct {
+	id        string
+	expiresAt time.Time
+}
+
+// alive tells whether the entry hasn't expired at now. Entries without expiry never do.
+func (entry *seenEntry) alive(now time.Time) bool {
+	return entry.expiresAt.IsZero() || entry.expiresAt.After(now)
+}
+
+// NewMemorySeenStore : Instantiate MemorySeenStore. A capacity of 0 means DefaultSeenCapacity.
+func NewMemorySeenStore(capacity int) *MemorySeenStore {
+	if capacity <= 0 {
+		capacity = DefaultSeenCapacity
+	}
+	return &MemorySeenStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// MarkSeen : Records the ID until expiresAt and reports whether it was already recorded
+func (store *MemorySeenStore) MarkSeen(id string, expiresAt time.Time) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.markSeen(id, expiresAt, time.Now()), nil
+}
+
+// Forget : Removes the ID
+func (store *MemorySeenStore) Forget(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.forget(id)
+	return nil
+}
+
+// Len : Returns the number of IDs in the store, including expired ones not dropped yet
+func (store *MemorySeenStore) Len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.order.Len()
+}
+
+func (store *MemorySeenStore) markSeen(id string, expiresAt time.Time, now time.Time) bool {
+	if element, ok := store.entries[id]; ok {
+		entry := element.Value.(*seenEntry)
+		if entry.alive(now) {
+			return true
+		}
+		entry.expiresAt = expiresAt
+		store.order.MoveToFront(element)
+		return false
+	}
+
+	store.entries[id] = store.order.PushFront(&seenEntry{id: id, expiresAt: expiresAt})
+	for store.order.Len() > store.capacity {
+		oldest := store.order.Back()
+		store.order.Remove(oldest)
+		delete(store.entries, oldest.Value.(*seenEntry).id)
+	}
+	return false
+}
+
+func (store *MemorySeenStore) forget(id string) {
+	if element, ok := store.entries[id]; ok {
+		store.order.Remove(element)
+		delete(store.entries, id)
+	}
+}
+
+// live returns the unexpired entries, oldest first.
+func (store *MemorySeenStore) live(now time.Time) []*seenEntry {
+	entries := make([]*seenEntry, 0, store.order.Len())
+	for element := store.order.Back(); element != nil; element = element.Prev() {
+		if entry := element.Value.(*seenEntry); entry.alive(now) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// FileSeenStore : A SeenStore persisting the IDs in a file, so that they survive restarts of the receiver.
+// The IDs are also kept in a MemorySeenStore bounding their number. The file is a log of the changes, with quoted IDs,
+// compacted once it holds twice as many lines as the capacity.
+type FileSeenStore struct {
+	path   string
+	memory *MemorySeenStore
+	mutex  sync.Mutex
+	file   *os.File
+	lines  int
+}
+
+// OpenFileSeenStore : Opens or creates the store at path. A capacity of 0 means DefaultSeenCapacity.
+func OpenFileSeenStore(path string, capacity int) (*FileSeenStore, error) {
+	store := &FileSeenStore{
+		path:   path,
+		memory: NewMemorySeenStore(capacity),
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	if err := store.compact(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// MarkSeen : Records the ID until expiresAt and reports whether it was already recorded
+func (store *FileSeenStore) MarkSeen(id string, expiresAt time.Time) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.memory.markSeen(id, expiresAt, time.Now()) {
+		return true, nil
+	}
+	return false, store.append(fmt.Sprintf("+ %d %s\n", unixTime(expiresAt), strconv.Quote(id)))
+}
+
+// Forget : Removes the ID
+func (store *FileSeenStore) Forget(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.memory.forget(id)
+	return store.append(fmt.Sprintf("- 0 %s\n", strconv.Quote(id)))
+}
+
+// Close : Closes the file of the store
+func (store *FileSeenStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.file.Close()
+}
+
+// unixTime returns the expiry written in the file, 0 for entries without expiry.
+func unixTime(expiresAt time.Time) int64 {
+	if expiresAt.IsZero() {
+		return 0
+	}
+	return expiresAt.Unix()
+}
+
+// fromUnixTime reverses unixTime.
+func fromUnixTime(expiresAt int64) time.Time {
+	if expiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(expiresAt, 0)
+}
+
+// append writes the line and syncs the file, so that an acknowledged delivery is still known after a crash.
+func (store *FileSeenStore) append(line string) error {
+	if _, err := store.file.WriteString(line); err != nil {
+		return err
+	}
+	if err := store.file.Sync(); err != nil {
+		return err
+	}
+	store.lines++
+	if store.lines > 2*store.memory.capacity {
+		return store.compact()
+	}
+	return nil
+}
+
+// load replays the log of the file into the memory store.
+func (store *FileSeenStore) load() error {
+	file, err := os.Open(store.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	now := time.Now()
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		// The ID is quoted, so that it may hold spaces.
+		fields := strings.SplitN(scanner.Text(), " ", 3)
+		if len(fields) != 3 {
+			return fmt.Errorf("%s:%d: malformed line", store.path, lineNumber)
+		}
+		id, err := strconv.Unquote(fields[2])
+		if err != nil {
+			return fmt.Errorf("%s:%d: malformed ID: %s", store.path, lineNumber, err.Error())
+		}
+		switch fields[0] {
+		case "+":
+			expiresAt, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %s", store.path, lineNumber, err.Error())
+			}
+			store.memory.forget(id)
+			store.memory.markSeen(id, fromUnixTime(expiresAt), now)
+		case "-":
+			store.memory.forget(id)
+		default:
+			return fmt.Errorf("%s:%d: malformed line", store.path, lineNumber)
+		}
+	}
+	return scanner.Err()
+}
+
+// compact rewrites the file with the unexpired IDs only.
+func (store *FileSeenStore) compact() error {
+	temp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return err
+	}
+	entries := store.memory.live(time.Now())
+	writer := bufio.NewWriter(temp)
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "+ %d %s\n", unixTime(entry.expiresAt), strconv.Quote(entry.id))
+	}
+	if err = writer.Flush(); err == nil {
+		err = temp.Sync()
+	}
+	if err == nil {
+		err = temp.Close()
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), store.path)
+	}
+	if err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+
+	if store.file != nil {
+		store.file.Close()
+	}
+	store.file, err = os.OpenFile(store.path, os.O_WRONLY|os.O_APPEND, 0600)
+	store.lines = len(entries)
+	return err
+}
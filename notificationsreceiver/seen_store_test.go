@@ -0,0 +1,119 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationsreceiver_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsreceiver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SeenStore`, func() {
+	later := time.Now().Add(time.Hour)
+
+	It(`keeps a bounded number of IDs in memory`, func() {
+		store := notificationsreceiver.NewMemorySeenStore(2)
+		for _, id := range []string{"a", "b", "c"} {
+			seen, err := store.MarkSeen(id, later)
+			Expect(err).To(BeNil())
+			Expect(seen).To(BeFalse())
+		}
+		Expect(store.Len()).To(Equal(2))
+		Expect(store.MarkSeen("c", later)).To(BeTrue())
+		Expect(store.MarkSeen("a", later)).To(BeFalse())
+
+		Expect(store.Forget("a")).To(Succeed())
+		Expect(store.MarkSeen("a", later)).To(BeFalse())
+
+		Expect(store.MarkSeen("expired", time.Now().Add(-time.Second))).To(BeFalse())
+		Expect(store.MarkSeen("expired", later)).To(BeFalse())
+
+		Expect(store.MarkSeen("forever", time.Time{})).To(BeFalse())
+		Expect(store.MarkSeen("forever", later)).To(BeTrue())
+	})
+	It(`persists the IDs in a file`, func() {
+		dir, err := ioutil.TempDir("", "seen")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "seen.log")
+
+		store, err := notificationsreceiver.OpenFileSeenStore(path, 3)
+		Expect(err).To(BeNil())
+		Expect(store.MarkSeen("a", later)).To(BeFalse())
+		Expect(store.MarkSeen("b", later)).To(BeFalse())
+		Expect(store.MarkSeen("expired", time.Now().Add(-time.Second))).To(BeFalse())
+		Expect(store.Forget("b")).To(Succeed())
+		Expect(store.MarkSeen("forever", time.Time{})).To(BeFalse())
+		Expect(store.Close()).To(Succeed())
+
+		store, err = notificationsreceiver.OpenFileSeenStore(path, 3)
+		Expect(err).To(BeNil())
+		defer store.Close()
+		Expect(store.MarkSeen("a", later)).To(BeTrue())
+		Expect(store.MarkSeen("forever", later)).To(BeTrue())
+		Expect(store.MarkSeen("b", later)).To(BeFalse())
+		Expect(store.MarkSeen("expired", later)).To(BeFalse())
+
+		// The log is compacted once it holds twice the capacity.
+		for i := 0; i < 10; i++ {
+			Expect(store.MarkSeen(fmt.Sprintf("id-%d", i), later)).To(BeFalse())
+		}
+		data, err := ioutil.ReadFile(path)
+		Expect(err).To(BeNil())
+		Expect(len(strings.Split(strings.TrimSpace(string(data)), "\n"))).To(BeNumerically("<=", 6))
+	})
+	It(`persists IDs holding spaces or line breaks`, func() {
+		dir, err := ioutil.TempDir("", "seen")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "seen.log")
+		ids := []string{"with space", "with\ttab", "with\nline", `"quoted"`, ""}
+
+		store, err := notificationsreceiver.OpenFileSeenStore(path, 0)
+		Expect(err).To(BeNil())
+		for _, id := range ids {
+			Expect(store.MarkSeen(id, later)).To(BeFalse())
+		}
+		Expect(store.Forget("with space")).To(Succeed())
+		Expect(store.Close()).To(Succeed())
+
+		store, err = notificationsreceiver.OpenFileSeenStore(path, 0)
+		Expect(err).To(BeNil())
+		defer store.Close()
+		Expect(store.MarkSeen("with space", later)).To(BeFalse())
+		for _, id := range ids[1:] {
+			Expect(store.MarkSeen(id, later)).To(BeTrue())
+		}
+	})
+	It(`rejects malformed files`, func() {
+		file, err := ioutil.TempFile("", "seen")
+		Expect(err).To(BeNil())
+		defer os.Remove(file.Name())
+		fmt.Fprintln(file, "garbage")
+		file.Close()
+
+		_, err = notificationsreceiver.OpenFileSeenStore(file.Name(), 0)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,382 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package output renders lists of API models, such as occurrences, notes and channels, as tables, JSON, NDJSON,
+// YAML, CSV or Go templates.
+//
+// Items are rendered from their JSON representation, so columns and templates use the JSON field names of the
+// models. A column is a dotted path into an item, e.g. "finding.severity" or "finding.next_steps.0.title".
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Constants associated with the Options.Format property.
+// - table&#58; Aligned columns with a header line.
+// - json&#58; An indented JSON array.
+// - ndjson&#58; One JSON object per line.
+// - yaml&#58; A YAML sequence.
+// - csv&#58; Comma-separated columns with a header line.
+// - template&#58; Options.Template executed for each item.
+const (
+	Format_Table    = "table"
+	Format_JSON     = "json"
+	Format_NDJSON   = "ndjson"
+	Format_YAML     = "yaml"
+	Format_CSV      = "csv"
+	Format_Template = "template"
+)
+
+// Formats : The supported formats, in the order they are listed in messages.
+var Formats = []string{Format_Table, Format_JSON, Format_NDJSON, Format_YAML, Format_CSV, Format_Template}
+
+// Options : The rendering options.
+type Options struct {
+
+	// One of the Format constants. Default is json, like the -o flag of the secadvisor command.
+	Format string
+
+	// The columns to render, as dotted paths. Tables and CSV default to the columns of DefaultColumns. When set,
+	// JSON, NDJSON and YAML items only have these fields, keyed by column.
+	Columns []string
+
+	// The text/template executed for each item, for the template format. The item is its JSON representation,
+	// e.g. {{.id}}, and each execution is followed by a new line.
+	Template string
+
+	// Omits the header line of tables and CSV.
+	NoHeaders bool
+}
+
+// defaultColumns are the columns rendered for the known models when none is selected.
+var defaultColumns = map[reflect.Type][]string{
+	reflect.TypeOf(findingsapiv1.ApiOccurrence{}):                  {"id", "kind", "note_name", "finding.severity", "context.resource_name", "update_time"},
+	reflect.TypeOf(findingsapiv1.ApiNote{}):                        {"id", "kind", "short_description", "finding.severity", "update_time"},
+	reflect.TypeOf(findingsapiv1.ApiProvider{}):                    {"id", "name"},
+	reflect.TypeOf(notificationsapiv1.ChannelResponseDefinition{}): {"channel_id", "name", "type", "enabled", "severity", "endpoint"},
+	reflect.TypeOf(notificationsapiv1.GetChannelResponseChannel{}): {"channel_id", "name", "type", "enabled", "severity", "endpoint"},
+}
+
+// ParseColumns : Splits a comma-separated list of columns, ignoring blanks
+func ParseColumns(columns string) []string {
+	var result []string
+	for _, column := range strings.Split(columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// DefaultColumns : Returns the columns rendered for items when no column is selected. The known models have
+// predefined columns; other items use their top-level fields, sorted.
+func DefaultColumns(items interface{}) ([]string, error) {
+	elementType := reflect.TypeOf(items)
+	for elementType != nil && (elementType.Kind() == reflect.Slice || elementType.Kind() == reflect.Array || elementType.Kind() == reflect.Ptr) {
+		elementType = elementType.Elem()
+	}
+	if columns, ok := defaultColumns[elementType]; ok {
+		return append([]string{}, columns...), nil
+	}
+
+	values, err := toValues(items)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]bool{}
+	for _, value := range values {
+		if object, ok := value.(map[string]interface{}); ok {
+			for field := range object {
+				fields[field] = true
+			}
+		}
+	}
+	columns := make([]string, 0, len(fields))
+	for field := range fields {
+		columns = append(columns, field)
+	}
+	sort.Strings(columns)
+	return columns, nil
+}
+
+// Write : Renders items, a slice of models or a single one, to w
+func Write(w io.Writer, items interface{}, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	values, err := toValues(items)
+	if err != nil {
+		return err
+	}
+	columns := opts.Columns
+	format := opts.Format
+	if format == "" {
+		format = Format_JSON
+	}
+	if len(columns) == 0 && (format == Format_Table || format == Format_CSV) {
+		if columns, err = DefaultColumns(items); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case Format_Table:
+		return writeTable(w, values, columns, opts.NoHeaders)
+	case Format_CSV:
+		return writeCSV(w, values, columns, opts.NoHeaders)
+	case Format_JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(selectColumns(values, columns))
+	case Format_NDJSON:
+		encoder := json.NewEncoder(w)
+		for _, value := range selectColumns(values, columns) {
+			if err = encoder.Encode(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Format_YAML:
+		data, err := yaml.Marshal(yamlValues(values, columns))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case Format_Template:
+		return writeTemplate(w, values, opts.Template)
+	default:
+		return fmt.Errorf("unsupported output format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Lookup : Returns the value at the dotted path of an item's JSON representation, and whether it exists.
+// Numeric segments index arrays.
+func Lookup(value interface{}, path string) (interface{}, bool) {
+	if path == "" || path == "." {
+		return value, true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, ok := current[segment]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Cell : Formats the value at the dotted path of an item for tables and CSV. Missing values are empty, lists of
+// scalars are comma-separated and objects are compact JSON.
+func Cell(value interface{}, path string) string {
+	value, ok := Lookup(value, path)
+	if !ok || value == nil {
+		return ""
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []interface{}:
+		parts := make([]string, len(typed))
+		for i, element := range typed {
+			switch element.(type) {
+			case map[string]interface{}, []interface{}:
+				return compactJSON(value)
+			}
+			parts[i] = Cell(element, "")
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		return compactJSON(value)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// toValues converts items to the generic form of their JSON representation, one value per item.
+func toValues(items interface{}) ([]interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch typed := value.(type) {
+	case []interface{}:
+		return typed, nil
+	case nil:
+		return []interface{}{}, nil
+	default:
+		return []interface{}{typed}, nil
+	}
+}
+
+// selectColumns keeps the columns of each value, or returns the values as is without columns.
+func selectColumns(values []interface{}, columns []string) []interface{} {
+	if len(columns) == 0 {
+		return values
+	}
+	selected := make([]interface{}, len(values))
+	for i, value := range values {
+		object := make(map[string]interface{}, len(columns))
+		for _, column := range columns {
+			object[column], _ = Lookup(value, column)
+		}
+		selected[i] = object
+	}
+	return selected
+}
+
+// yamlValues converts the values for YAML, keeping the order of the columns and the type of the numbers.
+func yamlValues(values []interface{}, columns []string) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i, value := range values {
+		if len(columns) == 0 {
+			converted[i] = yamlValue(value)
+			continue
+		}
+		object := make(yaml.MapSlice, len(columns))
+		for j, column := range columns {
+			field, _ := Lookup(value, column)
+			object[j] = yaml.MapItem{Key: column, Value: yamlValue(field)}
+		}
+		converted[i] = object
+	}
+	return converted
+}
+
+func yamlValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer
+		}
+		if float, err := typed.Float64(); err == nil {
+			return float
+		}
+		return typed.String()
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, element := range typed {
+			converted[i] = yamlValue(element)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, element := range typed {
+			converted[key] = yamlValue(element)
+		}
+		return converted
+	default:
+		return value
+	}
+}
+
+func writeTable(w io.Writer, values []interface{}, columns []string, noHeaders bool) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if !noHeaders {
+		headers := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = strings.ToUpper(strings.NewReplacer(".", "_").Replace(column))
+		}
+		fmt.Fprintln(table, strings.Join(headers, "\t"))
+	}
+	for _, value := range values {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(Cell(value, column))
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	return table.Flush()
+}
+
+func writeCSV(w io.Writer, values []interface{}, columns []string, noHeaders bool) error {
+	writer := csv.NewWriter(w)
+	if !noHeaders {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, value := range values {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = Cell(value, column)
+		}
+		if err := writer.Write(cells); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeTemplate(w io.Writer, values []interface{}, text string) error {
+	if text == "" {
+		return fmt.Errorf("the %s format requires a template", Format_Template)
+	}
+	tmpl, err := template.New("output").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %s", err.Error())
+	}
+	for _, value := range values {
+		if err = tmpl.Execute(w, value); err != nil {
+			return err
+		}
+		if _, err = io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,113 @@
+/**
+ * (C) Copyright IBM Corp. 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/findingsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/notificationsapiv1"
+	"github.com/ibm-cloud-security/security-advisor-sdk-go/output"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Write`, func() {
+	var occurrences []findingsapiv1.ApiOccurrence
+	BeforeEach(func() {
+		occurrences = nil
+		Expect(json.Unmarshal([]byte(`[
+			{"id": "o1", "kind": "FINDING", "note_name": "providers/p/notes/n", "context": {"resource_name": "cluster, east"},
+			 "finding": {"severity": "HIGH", "next_steps": [{"title": "Patch", "url": "https://example.com"}]}},
+			{"id": "o2", "kind": "KPI", "note_name": "providers/p/notes/k", "kpi": {"value": 2, "total": 10}}
+		]`), &occurrences)).To(Succeed())
+	})
+	render := func(items interface{}, opts *output.Options) string {
+		var out bytes.Buffer
+		Expect(output.Write(&out, items, opts)).To(Succeed())
+		return out.String()
+	}
+
+	It(`renders tables with the default columns of the model`, func() {
+		Expect(render(occurrences, &output.Options{Format: output.Format_Table})).To(Equal(strings.Join([]string{
+			"ID  KIND     NOTE_NAME            FINDING_SEVERITY  CONTEXT_RESOURCE_NAME  UPDATE_TIME",
+			"o1  FINDING  providers/p/notes/n  HIGH              cluster, east          ",
+			"o2  KPI      providers/p/notes/k                                           ",
+			"",
+		}, "\n")))
+		Expect(render(occurrences, &output.Options{Format: output.Format_Table, Columns: output.ParseColumns("id, finding.next_steps.0.title,kpi"), NoHeaders: true})).To(Equal(strings.Join([]string{
+			"o1  Patch  ",
+			`o2         {"total":10,"value":2}`,
+			"",
+		}, "\n")))
+	})
+	It(`renders CSV`, func() {
+		Expect(render(occurrences, &output.Options{Format: output.Format_CSV, Columns: []string{"id", "context.resource_name", "kpi.value"}})).To(Equal(strings.Join([]string{
+			"id,context.resource_name,kpi.value",
+			`o1,"cluster, east",`,
+			"o2,,2",
+			"",
+		}, "\n")))
+	})
+	It(`renders JSON, NDJSON and YAML`, func() {
+		Expect(render(occurrences[1], nil)).To(Equal(render(occurrences[1], &output.Options{Format: output.Format_JSON})))
+		Expect(render(occurrences[1], &output.Options{Format: output.Format_JSON})).To(MatchJSON(`[{"id": "o2", "kind": "KPI",
+			"note_name": "providers/p/notes/k", "kpi": {"value": 2, "total": 10}}]`))
+		Expect(render(occurrences, &output.Options{Format: output.Format_NDJSON, Columns: []string{"id", "finding.severity"}})).To(Equal(strings.Join([]string{
+			`{"finding.severity":"HIGH","id":"o1"}`,
+			`{"finding.severity":null,"id":"o2"}`,
+			"",
+		}, "\n")))
+		Expect(render(occurrences, &output.Options{Format: output.Format_YAML, Columns: []string{"id", "kpi.value"}})).To(Equal(strings.Join([]string{
+			"- id: o1",
+			"  kpi.value: null",
+			"- id: o2",
+			"  kpi.value: 2",
+			"",
+		}, "\n")))
+	})
+	It(`executes templates for each item`, func() {
+		Expect(render(occurrences, &output.Options{Format: output.Format_Template, Template: "{{.id}}: {{.note_name}}"})).To(Equal("o1: providers/p/notes/n\no2: providers/p/notes/k\n"))
+
+		var out bytes.Buffer
+		Expect(output.Write(&out, occurrences, &output.Options{Format: output.Format_Template})).NotTo(Succeed())
+		Expect(output.Write(&out, occurrences, &output.Options{Format: output.Format_Template, Template: "{{"})).NotTo(Succeed())
+		Expect(output.Write(&out, occurrences, &output.Options{Format: "xml"})).To(MatchError(ContainSubstring("unsupported output format")))
+	})
+	It(`chooses default columns`, func() {
+		columns, err := output.DefaultColumns([]notificationsapiv1.ChannelResponseDefinition{})
+		Expect(err).To(BeNil())
+		Expect(columns).To(Equal([]string{"channel_id", "name", "type", "enabled", "severity", "endpoint"}))
+
+		columns, err = output.DefaultColumns([]map[string]int{{"b": 1}, {"a": 2}})
+		Expect(err).To(BeNil())
+		Expect(columns).To(Equal([]string{"a", "b"}))
+	})
+	It(`looks up dotted paths`, func() {
+		var value interface{}
+		Expect(json.Unmarshal([]byte(`{"a": {"b": [{"c": "d"}], "e": ["f", "g"]}}`), &value)).To(Succeed())
+		found, ok := output.Lookup(value, "a.b.0.c")
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal("d"))
+		_, ok = output.Lookup(value, "a.b.1")
+		Expect(ok).To(BeFalse())
+		Expect(output.Cell(value, "a.e")).To(Equal("f,g"))
+		Expect(output.Cell(value, "a.b")).To(Equal(`[{"c":"d"}]`))
+		Expect(output.Cell(value, "missing")).To(Equal(""))
+	})
+})